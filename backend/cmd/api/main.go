@@ -6,38 +6,57 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	primaryHttp "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/static"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/config"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_auth"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_comments"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_products"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_profile"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_sla"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/buildinfo"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/metrics"
 	ratelimiter "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/rate_limiter"
 	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/redis/go-redis/v9"
 )
 
 // main is the application entry point.
 // It performs the following steps:
 // 1. Loads and validates application configuration.
 // 2. Initializes global security services (e.g., JWT).
-// 3. Establishes a database connection.
+// 3. Establishes a database connection and, when enabled, applies pending seeds.
 // 4. Creates domain services and their dependencies (repositories, validators).
 // 5. Configures the HTTP router with endpoints and middleware.
-// 6. Starts listening on the configured port.
+// 6. Starts listening on the configured port (HTTPS when TLS is configured, plus a plain HTTP redirect listener).
+// 7. On SIGINT/SIGTERM, shuts the server down gracefully and stops background jobs before the database is closed.
 
 // If any of these steps fails, main will log the error and exit the application.
 func main() {
 	// Step 1: Load and validate configuration
 	appConfig := config.NewAppConfig()
-	appConfig.ValidateConfig()
+	buildinfo.Print(log.Default(), appConfig.GetPort())
+	validateConfig(appConfig)
 
 	// Step 2: Initialize global services (e.g., JWT auth)
 	initializeCommonServices(appConfig)
@@ -49,105 +68,442 @@ func main() {
 	}
 	defer db.Close()
 
+	if appConfig.GetSeedOnStartup() {
+		runSeeds(appConfig, db)
+	}
+
+	queryer := setupQueryer(appConfig, db)
+	refreshTokenRepo := repository.NewSQLTokenRepository(queryer)
+
+	// Background jobs run until SIGINT/SIGTERM, or until stopJobs is called at the end of the shutdown sequence.
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	jobsCtx, stopJobs := context.WithCancel(signalCtx)
+	defer stopJobs()
+
 	// Step 4: Dependency injection for domain services
-	userRepo := setupUserRepository(db)
-	userServiceLogin := setupLoginService(userRepo)
-	userServiceRegister := setupRegisterService(userRepo)
-	commentGetService, commentAddService := setupCommentService(db)
-	rateHandler := setupRateLimiter(appConfig)
+	businessMetrics := setupBusinessMetrics()
+	hasher := setupHasher(appConfig)
+	userRepo := setupUserRepository(queryer, hasher)
+	securityAuth.SetDefaultRefreshTokens(refreshTokenRepo, userRepo, appConfig.GetRefreshTokenConfig())
+	userServiceLogin := setupLoginService(userRepo, hasher, appConfig, businessMetrics)
+	userServiceRegister := setupRegisterService(userRepo, appConfig, businessMetrics)
+	commentGetService, commentAddService, commentDeleteService, commentUpdateService := setupCommentService(queryer, businessMetrics)
+	userProfileService := setupUserProfileService(queryer)
+	passwordChangeService := setupPasswordChangeService(userRepo, hasher)
+	userDeletionService := setupUserDeletionService(userRepo, refreshTokenRepo)
+	userListService := setupUserListService(userRepo)
+	commentPinService := setupCommentPinService(queryer)
+	productGetService := setupProductService(queryer)
+	redisClient := setupRedisClient(appConfig)
+	if redisClient != nil {
+		defer redisClient.Close()
+	}
+	rateHandler, rateLimiterManager := setupRateLimiter(jobsCtx, appConfig, appConfig.GetRateLimitConfig(), redisClient, "ratelimit:")
+	csrfTokenRateHandler, csrfTokenRateLimiterManager := setupRateLimiter(jobsCtx, appConfig, appConfig.GetCSRFTokenRateLimitConfig(), redisClient, "ratelimit:csrf:")
 	staticFileAdapter := setupStaticFileAdapter(appConfig)
+	idempotencyRepo := repository.NewSQLIdempotencyRepository(queryer)
+	// The request log bypasses the query-logging queryer, so persisting a request never logs another query line.
+	requestLogRepo := repository.NewSQLRequestLogRepository(db)
+	auditRepo := repository.NewSQLAuditRepository(queryer)
+	geoDB := setupGeoDB(appConfig)
+	if geoDB != nil {
+		defer geoDB.Close()
+	}
+	dashboardCollector := setupDashboardCollector(db, rateLimiterManager, csrfTokenRateLimiterManager)
+	// Revoked access tokens only need to be remembered until they expire, so they are kept in memory.
+	tokenRevocationService := service_auth.NewTokenRevocationService(repository.NewMemoryTokenRepository())
 
 	// Step 5: Configure HTTP router with handlers and middleware
 	router := primaryHttp.NewRouter(
+		appConfig,
 		userServiceLogin,
 		userServiceRegister,
 		commentGetService,
 		commentAddService,
+		commentDeleteService,
+		commentUpdateService,
+		commentPinService,
+		productGetService,
+		userProfileService,
+		passwordChangeService,
+		userDeletionService,
+		userListService,
 		rateHandler,
+		csrfTokenRateHandler,
 		staticFileAdapter,
+		geoDB,
+		idempotencyRepo,
+		requestLogRepo,
+		auditRepo,
+		setupSLAReportService(requestLogRepo),
+		dashboardCollector,
+		tokenRevocationService,
+		db,
 	)
 
-	// Step 6: Start HTTP server	
+	// Step 6: Start HTTP server
 	port := appConfig.GetPort()
-	log.Printf("Server started at http://localhost:%s", port)
-	log.Printf("Serving static files from: %s", staticFileAdapter.GetStaticDir())
-	log.Fatal(http.ListenAndServe(":"+port, router))
+	server := &http.Server{
+		Addr:    ":" + port,
+		Handler: router,
+	}
+	applyServerTimeouts(server, appConfig.GetServerTimeouts())
+
+	go func() {
+		log.Printf("Serving static files from: %s", staticFileAdapter.GetStaticDir())
+		var err error
+		if appConfig.IsTLSEnabled() {
+			log.Printf("Server started at https://localhost:%s", port)
+			err = server.ListenAndServeTLS(appConfig.GetTLSCertFile(), appConfig.GetTLSKeyFile())
+		} else {
+			log.Printf("Server started at http://localhost:%s", port)
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Error starting server: %v", err)
+		}
+	}()
+
+	redirectServer := setupHTTPRedirectServer(appConfig)
+
+	// Step 7: Graceful shutdown on SIGINT/SIGTERM
+	<-signalCtx.Done()
+
+	log.Println("Shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error during server shutdown: %v", err)
+	}
+	if redirectServer != nil {
+		if err := redirectServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Error during redirect server shutdown: %v", err)
+		}
+	}
+
+	// Background jobs are stopped before the deferred db.Close runs.
+	stopJobs()
+}
+
+// shutdownTimeout bounds how long in-flight requests may take to finish during graceful shutdown.
+const shutdownTimeout = 10 * time.Second
+
+// applyServerTimeouts sets the read, header, write and idle timeouts of server, so slow clients cannot hold connections open forever.
+func applyServerTimeouts(server *http.Server, timeouts models.ServerTimeouts) {
+	server.ReadTimeout = timeouts.Read
+	server.ReadHeaderTimeout = timeouts.ReadHeader
+	server.WriteTimeout = timeouts.Write
+	server.IdleTimeout = timeouts.Idle
+}
+
+// setupHTTPRedirectServer starts, when TLS is enabled, a plain HTTP listener on the configured redirect port that only redirects clients to HTTPS.
+// It returns nil when TLS is disabled; otherwise the caller must Shutdown the returned server.
+func setupHTTPRedirectServer(appConfig *config.AppConfig) *http.Server {
+	if !appConfig.IsTLSEnabled() {
+		return nil
+	}
+
+	redirectServer := &http.Server{
+		Addr:    ":" + appConfig.GetHTTPRedirectPort(),
+		Handler: middleware.HTTPSRedirectMiddleware(appConfig.GetPort())(http.NotFoundHandler()),
+	}
+	applyServerTimeouts(redirectServer, appConfig.GetServerTimeouts())
+
+	go func() {
+		log.Printf("Redirecting http://localhost:%s to HTTPS", appConfig.GetHTTPRedirectPort())
+		if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Error starting HTTP redirect server: %v", err)
+		}
+	}()
+	return redirectServer
+}
+
+// validateConfig reports every invalid setting returned by AppConfig.ValidateConfig.
+
+// In production any error is fatal; in development the errors are only printed as warnings so the server can still start with local defaults.
+func validateConfig(appConfig *config.AppConfig) {
+	configErrors := appConfig.ValidateConfig()
+	for _, configErr := range configErrors {
+		log.Printf("WARNING: invalid configuration %s", configErr.Error())
+	}
+
+	if len(configErrors) > 0 && appConfig.IsProduction() {
+		log.Fatalf("Refusing to start in production with %d configuration error(s)", len(configErrors))
+	}
 }
 
 // initializeCommonServices sets up services that are shared globally across the application.
 
-// Currently, this function initializes the default JWT authentication service using the secret key from configuration.
+// Currently, this function installs the default slog logger (JSON in production, text otherwise) used by the logging and timing middlewares, initializes the default JWT authentication service using the secret key from configuration, and switches JSON responses to indented output when server.json_pretty_print is set.
 func initializeCommonServices(appConfig *config.AppConfig) {
-	securityAuth.SetDefaultJWTService(appConfig.GetJWTSecret())
+	slog.SetDefault(newLogger(appConfig))
+	securityAuth.SetDefaultJWTService(appConfig.GetJWTSecret(), appConfig.GetJWTClockSkew())
+	if appConfig.GetJSONPrettyPrint() {
+		httpUtil.SetDefaultJSONEncoder(httpUtil.PrettyJSONEncoder{})
+	}
 }
 
+// newLogger returns the structured logger of the application: JSON records on stderr in production, for log aggregation, and human-readable text records otherwise.
+func newLogger(appConfig *config.AppConfig) *slog.Logger {
+	if appConfig.IsProduction() {
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, nil))
+}
+
+// dbConnectTimeout bounds the whole connection retry loop at startup.
+const dbConnectTimeout = 60 * time.Second
+
+// dbConnectInitialBackoff is the wait after the first failed connection attempt; it doubles after each further failure.
+const dbConnectInitialBackoff = 1 * time.Second
+
 // setupDatabase establishes a connection to the MySQL database.
 
 // It uses configuration values such as username, password, host, and database name to construct the DSN string and open the connection. It returns a *sqlx.DB instance and an error if the connection fails.
+// Because MySQL may still be starting (e.g. under Docker Compose), failed attempts are retried up to GetDBConnectRetries times with exponential backoff (1s, 2s, 4s, ...) capped at GetDBConnectMaxBackoff, all within dbConnectTimeout.
 func setupDatabase(appConfig *config.AppConfig) (*sqlx.DB, error) {
-	cfg := appConfig.GetConfig()
-	user := cfg.GetString("database.user")
-	password := cfg.GetString("database.password")
-	host := cfg.GetString("database.host")
-	port := cfg.GetInt("database.port")
-	dbName := cfg.GetString("database.name")
-	
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true", user, password, host, port, dbName)
-	return sqlx.Connect("mysql", dsn)
+	dsn := appConfig.GetDatabaseDSN()
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbConnectTimeout)
+	defer cancel()
+
+	retries := appConfig.GetDBConnectRetries()
+	maxBackoff := appConfig.GetDBConnectMaxBackoff()
+	backoff := dbConnectInitialBackoff
+
+	var err error
+	for attempt := 1; attempt <= retries; attempt++ {
+		var db *sqlx.DB
+		db, err = sqlx.ConnectContext(ctx, "mysql", dsn)
+		if err == nil {
+			return db, nil
+		}
+		log.Printf("Database connection attempt %d/%d failed: %v", attempt, retries, err)
+
+		if attempt == retries {
+			break
+		}
+		wait := backoff
+		if wait > maxBackoff {
+			wait = maxBackoff
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("giving up after %d attempt(s) within %s: %w", attempt, dbConnectTimeout, err)
+		}
+		backoff *= 2
+	}
+	return nil, fmt.Errorf("giving up after %d attempt(s): %w", retries, err)
+}
+
+// runSeeds applies the pending seed files before the server starts.
+// A failing seed is fatal, since the server would otherwise start without the data it expects (e.g. the initial admin account).
+func runSeeds(appConfig *config.AppConfig, db *sqlx.DB) {
+	applied, err := dbUtil.RunSeeds(db, appConfig.GetSeedsDir())
+	if err != nil {
+		log.Fatalf("Error applying database seeds: %v", err)
+	}
+	log.Printf("Database seeds applied: %d", len(applied))
+}
+
+// setupQueryer returns the query executor handed to the repositories.
+
+// In debug mode the connection is wrapped in a dbUtil.LoggingDB so every query is logged with its duration, and slow queries with their execution plan; otherwise the raw connection is used.
+func setupQueryer(appConfig *config.AppConfig, db *sqlx.DB) dbUtil.Queryer {
+	if !appConfig.IsDebugMode() {
+		return db
+	}
+
+	log.Println("Debug mode enabled: logging database queries")
+	return dbUtil.NewLoggingDB(db, appConfig.GetSlowQueryThreshold()).WithExplain(true)
+}
+
+// setupBusinessMetrics creates the registration, login and comment counters and registers them on metrics.Registry, so they are served on GET /metrics.
+func setupBusinessMetrics() *metrics.BusinessMetrics {
+	businessMetrics := metrics.NewBusinessMetrics()
+	if err := businessMetrics.Register(metrics.Registry); err != nil {
+		log.Fatalf("Error registering business metrics: %v", err)
+	}
+	return businessMetrics
+}
+
+// setupHasher returns the password hasher used for new and upgraded passwords.
+// The algorithm comes from security.password_hash_algorithm: Argon2id (the default) uses the cost parameters from security.argon2 and salts of security.salt_bytes random bytes, bcrypt uses its default cost. Hashes of the other algorithm are still verified and upgraded on login.
+func setupHasher(appConfig *config.AppConfig) securityAuth.Hasher {
+	if securityAuth.HashAlgorithm(appConfig.GetActiveHashAlgorithm()) == securityAuth.HashAlgorithmBcrypt {
+		return securityAuth.BcryptHasher{}
+	}
+	saltGenerator := securityAuth.NewRandomSaltGenerator(appConfig.GetSaltByteLength())
+	return securityAuth.NewArgon2idHasher(appConfig.GetArgon2Config(), saltGenerator)
 }
 
 // setupUserRepository returns an implementation of the UserRepository interface.
 
-// It sets up dependencies for user authentication such as salt generation and password hashing and injects them into the SQL-based repository.
-func setupUserRepository(db *sqlx.DB) output.UserRepository {
-	hasher := securityAuth.BcryptHasher{}
+// It injects the password hasher (carrying the configured salt generator) and the database connection into the SQL-based repository.
+func setupUserRepository(db dbUtil.Queryer, hasher securityAuth.Hasher) output.UserRepository {
 	return repository.NewSQLUserRepository(db, hasher)
 }
 
 // setupLoginService initializes and returns the user login service.
 
 // This service validates credentials and authenticates users.
-// It relies on validators for username and password and uses the user repository to query user data.
-func setupLoginService(userRepo output.UserRepository) input.UserServiceLogin {
+// It relies on validators for username and password, uses the user repository to query user data and the hasher to upgrade hashes not produced by the active algorithm.
+// Login outcomes are counted in businessMetrics.
+func setupLoginService(userRepo output.UserRepository, hasher securityAuth.Hasher, appConfig *config.AppConfig, businessMetrics *metrics.BusinessMetrics) input.UserServiceLogin {
 	userNameValidator := &service_auth.UserNameValidator{}
 	passwordValidator := &service_auth.PasswordValidator{}
-	return service_auth.NewUserLoginService(userRepo, userNameValidator, passwordValidator)
+	activeAlgorithm := securityAuth.HashAlgorithm(appConfig.GetActiveHashAlgorithm())
+	return service_auth.NewUserLoginService(userRepo, hasher, activeAlgorithm, userNameValidator, passwordValidator, businessMetrics)
 }
 
 // setupRegisterService initializes and returns the user registration service.
 // It validates user input and stores new users in the database using the provided repository.
-func setupRegisterService(userRepo output.UserRepository) input.UserServiceRegister {
-	userNameValidator := &service_auth.UserNameValidator{}
+// New usernames must also satisfy the configured UsernamePolicy (maximum length and allowed characters); login keeps the unrestricted validator so older accounts can still sign in.
+func setupRegisterService(userRepo output.UserRepository, appConfig *config.AppConfig, businessMetrics *metrics.BusinessMetrics) input.UserServiceRegister {
+	userNameValidator := service_auth.NewUserNameValidator(appConfig.GetUsernamePolicy())
 	passwordValidator := &service_auth.PasswordValidator{}
-	return service_auth.NewUserRegisterService(userRepo, userNameValidator, passwordValidator)
+	return service_auth.NewUserRegisterService(userRepo, userNameValidator, passwordValidator, businessMetrics)
 }
 
-// setupCommentService initializes services for retrieving and creating user comments.
-// This binds the comment repository and validation rules into service implementations.
+// setupPasswordChangeService initializes the service letting authenticated users change their password.
+// New passwords must satisfy the same PasswordValidator as registration and are hashed with the active algorithm.
+func setupPasswordChangeService(userRepo output.UserRepository, hasher securityAuth.Hasher) input.PasswordChangeService {
+	return service_auth.NewPasswordChangeService(userRepo, hasher, &service_auth.PasswordValidator{})
+}
+
+// setupUserDeletionService initializes the service letting authenticated users delete their own account.
+// Accounts are soft-deleted, so their comments are kept and the username stays reserved; their refresh tokens in tokenRepo are revoked.
+func setupUserDeletionService(userRepo output.UserRepository, tokenRepo output.TokenRepository) input.UserDeletionService {
+	return service_auth.NewUserDeletionService(userRepo, tokenRepo)
+}
+
+// setupUserListService initializes the service listing registered accounts on GET /admin/users.
+func setupUserListService(userRepo output.UserRepository) input.UserListService {
+	return service_auth.NewUserListService(userRepo)
+}
+
+// setupCommentService initializes services for retrieving, creating, deleting and editing user comments.
+// This binds the comment repository, the application settings (for anonymous comments) and validation rules into service implementations.
 // Parameters:
-//   - db: active *sqlx.DB connection
+//   - db: query executor for the active database connection
+//   - businessMetrics: counters for the outcome of added comments
 
 // Returns:
 //   - input.CommentGetService: service interface to fetch comments
 //   - input.CommentAddService: service interface to add new comments
-func setupCommentService(db *sqlx.DB) (input.CommentGetService, input.CommentAddService) {
+//   - input.CommentDeleteService: service interface to delete comments by their authors
+//   - input.CommentUpdateService: service interface to edit comments by their authors
+func setupCommentService(db dbUtil.Queryer, businessMetrics *metrics.BusinessMetrics) (input.CommentGetService, input.CommentAddService, input.CommentDeleteService, input.CommentUpdateService) {
 	commentRepo := repository.NewSqlCommentRepository(db)
+	settingsRepo := repository.NewSQLAppSettingsRepository(db)
 	commentValidator := &service_comments.CommentValidator{}
-	return  service_comments.NewCommentGetService(commentRepo, commentValidator), service_comments.NewCommentAddService(commentRepo, commentValidator)
+	return  service_comments.NewCommentGetService(commentRepo, commentValidator), service_comments.NewCommentAddService(commentRepo, commentValidator, settingsRepo, businessMetrics), service_comments.NewCommentDeleteService(commentRepo), service_comments.NewCommentUpdateService(commentRepo, commentValidator)
 }
 
-// setupRateLimiter configures and returns a rate limiting handler.
-// It uses rate limit settings (requests per second and burst) defined in the application configuration to protect the API against abuse or DoS attacks.
-func setupRateLimiter(appConfig *config.AppConfig) ratelimiter.RateLimiterHandler {
-	limiterConfig := appConfig.GetRateLimitConfig()
-	return ratelimiter.NewDefaultRateLimiter(limiterConfig.RequestPerSecond, limiterConfig.Burst)
+// setupCommentPinService initializes the service pinning comments as homepage testimonials on POST /admin/comments/{id}/pin and /unpin.
+func setupCommentPinService(db dbUtil.Queryer) input.CommentPinService {
+	return service_comments.NewCommentPinService(repository.NewSqlCommentRepository(db))
+}
+
+// setupUserProfileService initializes the service that reads and updates user profiles.
+// It binds the SQL profile repository and the display name validator.
+func setupUserProfileService(db dbUtil.Queryer) input.UserProfileService {
+	profileRepo := repository.NewSQLUserProfileRepository(db)
+	displayNameValidator := &service_profile.DisplayNameValidator{}
+	return service_profile.NewUserProfileService(profileRepo, displayNameValidator)
+}
+
+// setupProductService initializes the service that serves the watch catalogue, bound to the SQL product repository.
+func setupProductService(db dbUtil.Queryer) input.ProductGetService {
+	return service_products.NewProductGetService(repository.NewSqlProductRepository(db))
+}
+
+// setupRedisClient connects to the Redis server from rate_limiting.redis_url, or returns nil when it is not configured.
+// It fatally logs and exits if the URL is malformed.
+func setupRedisClient(appConfig *config.AppConfig) *redis.Client {
+	redisURL := appConfig.GetRateLimiterRedisURL()
+	if redisURL == "" {
+		return nil
+	}
+
+	options, err := redis.ParseURL(redisURL)
+	if err != nil {
+		log.Fatalf("Invalid rate_limiting.redis_url: %v", err)
+	}
+	return redis.NewClient(options)
+}
+
+// setupRateLimiter configures and returns a rate limiting handler together with its manager, and starts the cleaner that purges its inactive entries.
+// It uses the given rate limit settings (requests per second and burst) to protect the API against abuse or DoS attacks.
+// The cleaner runs on the schedule from rate_limiting.cleanup until ctx is cancelled.
+// With a redisClient the buckets are kept in Redis under keyPrefix instead, shared by every instance; Redis expires them itself, so no cleaner is started and no in-memory manager is returned.
+func setupRateLimiter(ctx context.Context, appConfig *config.AppConfig, limiterConfig models.LimiterConfig, redisClient *redis.Client, keyPrefix string) (ratelimiter.RateLimiterHandler, *ratelimiter.DefaultRateLimiterManager) {
+	if redisClient != nil {
+		return ratelimiter.NewRateLimiterWithManager(ratelimiter.NewRedisRateLimiterManager(redisClient, keyPrefix, limiterConfig)), nil
+	}
+
+	manager := ratelimiter.NewRateLimiterManager()
+	manager.SetDefaultLimiterConfig(limiterConfig)
+
+	cleanupConfig := appConfig.GetRateLimiterCleanup()
+	cleaner := ratelimiter.NewRateLimiterCleaner(manager)
+	cleaner.Start(
+		ctx,
+		time.Duration(cleanupConfig.ExpirationMinutes)*time.Minute,
+		time.Duration(cleanupConfig.CleanupIntervalMinutes)*time.Minute,
+	)
+
+	return ratelimiter.NewRateLimiterWithManager(manager), manager
+}
+
+// setupSLAReportService initializes the service building the daily reports served on GET /admin/metrics/sla from the request log.
+func setupSLAReportService(requestLogRepo output.RequestLogRepository) input.SLAReportService {
+	return service_sla.NewSLAReportService(requestLogRepo)
+}
+
+// setupDashboardCollector registers the health indicators served on GET /admin/dashboard:
+//   - db_connections: connection pool statistics of db
+//   - rate_limiter_ips: number of client IPs tracked by the in-memory rate limiter managers; nil managers (Redis-backed limiters) are skipped
+func setupDashboardCollector(db *sqlx.DB, limiterManagers ...*ratelimiter.DefaultRateLimiterManager) *metrics.DashboardCollector {
+	return metrics.NewDashboardCollector().
+		Register("db_connections", metrics.DashboardMetricFunc(func(ctx context.Context) (interface{}, error) {
+			return db.Stats(), nil
+		})).
+		Register("rate_limiter_ips", metrics.DashboardMetricFunc(func(ctx context.Context) (interface{}, error) {
+			count := 0
+			for _, manager := range limiterManagers {
+				if manager != nil {
+					count += manager.Count()
+				}
+			}
+			return count, nil
+		}))
 }
 
 // setupStaticFileAdapter creates and returns an adapter for serving static files.
 
 // The adapter serves assets such as images, stylesheets, or JavaScript files
-// from a directory defined in the configuration.
+// from a directory defined in the configuration, and builds their public URLs
+// under the configured static URL prefix.
 func setupStaticFileAdapter(appConfig *config.AppConfig) output.StaticFilePort {
 	staticDir := appConfig.GetStaticDir()
-	return static.NewStaticFileAdapter(staticDir)
+	return static.NewStaticFileAdapter(staticDir).WithURLPrefix(appConfig.GetStaticURLPrefix())
+}
+
+// setupGeoDB opens the GeoLite2 country database used for geographic filtering.
+
+// It returns nil when no database path is configured, which disables the geo filter. A configured but unreadable database is a fatal error, since silently skipping the filter could violate regional restrictions.
+func setupGeoDB(appConfig *config.AppConfig) *maxminddb.Reader {
+	geoDBPath := appConfig.GetGeoDBPath()
+	if geoDBPath == "" {
+		return nil
+	}
+
+	geoDB, err := maxminddb.Open(geoDBPath)
+	if err != nil {
+		log.Fatalf("Error opening GeoLite2 database %s: %v", geoDBPath, err)
+	}
+	return geoDB
 }
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+)
+
+func TestReadTimeoutAbortsSlowRequestBody(t *testing.T) {
+	readErrors := make(chan error, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.ReadAll(r.Body)
+		readErrors <- err
+	}))
+	applyServerTimeouts(server.Config, models.ServerTimeouts{
+		Read:       100 * time.Millisecond,
+		ReadHeader: 100 * time.Millisecond,
+		Write:      time.Second,
+		Idle:       time.Second,
+	})
+	server.Start()
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	// Announce a 10-byte body but only send one byte of it.
+	fmt.Fprint(conn, "POST / HTTP/1.1\r\nHost: localhost\r\nContent-Length: 10\r\n\r\nx")
+
+	select {
+	case err := <-readErrors:
+		if err == nil {
+			t.Error("Expected reading the slow body to fail with a timeout, Got: nil")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Read timeout did not abort the slow request body")
+	}
+}
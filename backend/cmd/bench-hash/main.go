@@ -0,0 +1,46 @@
+// Package main provides a command that benchmarks password hashing on the current machine and prints recommended Argon2id settings.
+
+// The recommended block can be pasted into the configuration file under security.argon2. Run it on hardware comparable to production, since the cost of a hash depends on the CPU and the memory bandwidth.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+// main benchmarks bcrypt for reference, searches Argon2id parameters reaching the -target duration and prints them as a configuration block.
+func main() {
+	targetMillis := flag.Int("target", 500, "target duration of a single hash, in milliseconds")
+	iterations := flag.Int("iterations", 5, "number of hashes averaged when timing the suggested configuration")
+	flag.Parse()
+
+	bcryptAvg, err := securityAuth.BenchmarkHasher(securityAuth.BcryptHasher{}, "benchmark-password", *iterations)
+	if err != nil {
+		log.Fatalf("Error benchmarking bcrypt: %v", err)
+	}
+	log.Printf("bcrypt (default cost): %v per hash", bcryptAvg)
+
+	config, err := securityAuth.SuggestArgon2Params(*targetMillis)
+	if err != nil {
+		log.Fatalf("Error suggesting Argon2id parameters: %v", err)
+	}
+
+	hasher := securityAuth.NewArgon2idHasher(config, securityAuth.NewRandomSaltGenerator(16))
+	argon2Avg, err := securityAuth.BenchmarkHasher(hasher, "benchmark-password", *iterations)
+	if err != nil {
+		log.Fatalf("Error benchmarking Argon2id: %v", err)
+	}
+	log.Printf("argon2id (suggested): %v per hash, target %dms", argon2Avg, *targetMillis)
+
+	fmt.Printf(`security:
+  password_hash_algorithm: argon2id
+  argon2:
+    time: %d
+    memory: %d
+    threads: %d
+    key_length: %d
+`, config.Time, config.Memory, config.Threads, config.KeyLen)
+}
@@ -0,0 +1,62 @@
+// Package main provides a command that prints the MySQL execution plan of a query for offline analysis.
+
+// The query is read from stdin and explained against the configured database; it is never executed. Values for ? placeholders are passed as command-line arguments:
+//
+//	echo "SELECT * FROM comments WHERE UserID = ?" | go run ./cmd/explain 42
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/config"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// main reads the query from stdin, runs EXPLAIN FORMAT=JSON with the command-line arguments bound to its placeholders and prints the indented plan.
+func main() {
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatalf("Error reading query from stdin: %v", err)
+	}
+	query := strings.TrimSuffix(strings.TrimSpace(string(input)), ";")
+	if query == "" {
+		log.Fatal("No query given on stdin")
+	}
+
+	args := make([]interface{}, 0, len(os.Args)-1)
+	for _, arg := range os.Args[1:] {
+		args = append(args, arg)
+	}
+
+	appConfig := config.NewAppConfig()
+	db, err := sqlx.Connect("mysql", appConfig.GetDatabaseDSN())
+	if err != nil {
+		log.Fatalf("Error connecting to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	plan, err := dbUtil.ExplainQuery(ctx, db, query, args)
+	if err != nil {
+		log.Fatalf("Error explaining query: %v", err)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(plan), "", "  "); err != nil {
+		fmt.Println(plan)
+		return
+	}
+	fmt.Println(indented.String())
+}
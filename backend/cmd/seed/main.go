@@ -0,0 +1,39 @@
+// Package main provides a command that applies the database seeds (e.g. the initial admin account) without starting the API server.
+
+// It reads the same configuration as the API server, applies every pending *.up.sql file of the seeds directory in order and records each one in the schema_seeds table, so running it again is a no-op.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/config"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// main connects to the configured database and applies the pending seeds.
+// The seeds directory defaults to database.seeds_dir and can be overridden with -dir.
+func main() {
+	appConfig := config.NewAppConfig()
+
+	dir := flag.String("dir", appConfig.GetSeedsDir(), "directory containing the *.up.sql seed files")
+	flag.Parse()
+
+	db, err := sqlx.Connect("mysql", appConfig.GetDatabaseDSN())
+	if err != nil {
+		log.Fatalf("Error connecting to database: %v", err)
+	}
+	defer db.Close()
+
+	applied, err := dbUtil.RunSeeds(db, *dir)
+	if err != nil {
+		log.Fatalf("Error applying seeds: %v", err)
+	}
+	if len(applied) == 0 {
+		log.Println("No pending seeds")
+		return
+	}
+	log.Printf("Applied %d seed(s): %v", len(applied), applied)
+}
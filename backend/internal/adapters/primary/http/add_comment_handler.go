@@ -41,13 +41,14 @@ func NewCommentAddsHandler(commentService input.CommentAddService) *CommentsAddH
 
 //   - 200 OK with a success message on success.
 //   - 400 Bad Request if the method is not POST or the JSON body is invalid.
+//   - 403 Forbidden if IsAnonymous is set while anonymous comments are disabled.
 //   - 500 Internal Server Error if context lacks user ID or adding the comment fails.
 
 // Steps:
 //  1. Verify HTTP method is POST; otherwise, return 400 with "Method Not Allowed".
 //  2. Decode request body into models.Review; on JSON syntax errors, return 400.
 //  3. Extract user ID from context using middleware.GetUserIDContextKey(); if missing or wrong type, return 500.
-//  4. Invoke commentService.AddComment with user ID, review content, and rating; on error, return 500.
+//  4. Invoke commentService.AddComment with user ID, review content, and rating, or AddAnonymousComment when IsAnonymous is set; on error, return 500 (403 when anonymous comments are disabled).
 //  5. Send a JSON response with status 200 and message "Comment added".
 
 // Parameters:
@@ -56,14 +57,14 @@ func NewCommentAddsHandler(commentService input.CommentAddService) *CommentsAddH
 func (h *CommentsAddHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	// Step 1: Method check
 	if r.Method != http.MethodPost {
-		httpUtil.HandleError(w, errors.NewBadRequestError(errors.ErrMethodNotAllowed))
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrMethodNotAllowed))
 		return
 	}
 
 	// Step 2: Decode JSON body
 	var account models.Review
 	if err := json.NewDecoder(r.Body).Decode(&account); err != nil {
-		httpUtil.HandleError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
 		return
 	}
 
@@ -72,14 +73,23 @@ func (h *CommentsAddHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	userIDValue := ctx.Value(middleware.GetUserIdContextKey())
 	userIdInt, ok := userIDValue.(int)
 	if !ok {
-		httpUtil.HandleError(w, errors.NewInternalError(errors.ErrInternalServer))
+		httpUtil.WriteError(w, errors.NewInternalError(errors.ErrInternalServer))
 		return
 	}
 
 	// Step 4: Call domain service to add the comment
-	err := h.commentService.AddComment(userIdInt, account.Content, account.Rating)
+	var err error
+	if account.IsAnonymous {
+		err = h.commentService.AddAnonymousComment(account.Content, account.Rating)
+	} else {
+		err = h.commentService.AddComment(userIdInt, account.Content, account.Rating)
+	}
+	if errors.IsForbidden(err) {
+		httpUtil.WriteError(w, err)
+		return
+	}
 	if err != nil {
-		httpUtil.HandleError(w, errors.NewInternalError(errors.ErrInternalServer))
+		httpUtil.WriteError(w, errors.NewInternalError(errors.ErrInternalServer))
 		return
 	}
 
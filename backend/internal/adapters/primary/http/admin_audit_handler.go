@@ -0,0 +1,70 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the AdminAuditHandler, which lets administrators review the audit log of impersonations.
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// AdminAuditHandler serves GET /admin/audit/impersonations.
+type AdminAuditHandler struct {
+	auditRepo output.AuditRepository
+}
+
+// NewAdminAuditHandler creates a new instance of AdminAuditHandler.
+func NewAdminAuditHandler(auditRepo output.AuditRepository) *AdminAuditHandler {
+	return &AdminAuditHandler{
+		auditRepo: auditRepo,
+	}
+}
+
+// auditEntriesResponse is the body returned by GET /admin/audit/impersonations.
+type auditEntriesResponse struct {
+	Items []models.AuditEntry `json:"items"`
+}
+
+// HandleImpersonations returns the recorded impersonations, most recent first, as {"items": [...]} with an HTTP 200 (OK) status.
+// The optional user_id query parameter keeps the impersonations of one user, and from and to (RFC 3339) keep those started in [from, to). An invalid parameter yields a 400 (Bad Request) response.
+func (h *AdminAuditHandler) HandleImpersonations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.AuditFilter{Action: models.AuditActionImpersonation}
+
+	if raw := query.Get("user_id"); raw != "" {
+		userID, err := strconv.Atoi(raw)
+		if err != nil || userID <= 0 {
+			httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidAuditUserID))
+			return
+		}
+		filter.UserID = userID
+	}
+	for _, bound := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		raw := query.Get(bound.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidAuditTime))
+			return
+		}
+		*bound.target = parsed
+	}
+
+	entries, err := h.auditRepo.List(filter)
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	httpUtil.SendJSONResponse(w, http.StatusOK, auditEntriesResponse{Items: entries})
+}
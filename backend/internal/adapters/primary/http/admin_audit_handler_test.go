@@ -0,0 +1,69 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+)
+
+func TestAdminAuditHandlerImpersonations(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 12, 0, 0, 0, time.UTC) }
+	auditRepo := repotesting.NewInMemoryAuditRepository(repotesting.WithAuditEntries([]models.AuditEntry{
+		{Action: models.AuditActionImpersonation, ActorID: 1, UserID: 7, Reason: "ticket 1: login loop", CreatedAt: day(1)},
+		{Action: models.AuditActionImpersonation, ActorID: 1, UserID: 8, Reason: "ticket 2: missing comment", CreatedAt: day(2)},
+		{Action: models.AuditActionImpersonation, ActorID: 1, UserID: 7, Reason: "ticket 3: profile error", CreatedAt: day(3)},
+		{Action: models.AuditActionImpersonation, ActorID: 1, UserID: 7, Reason: "ticket 4: slow catalogue", CreatedAt: day(4)},
+	}))
+	handler := NewAdminAuditHandler(auditRepo)
+
+	tests := []struct {
+		name        string
+		query       string
+		wantStatus  int
+		wantReasons []string
+	}{
+		{name: "all", wantStatus: http.StatusOK, wantReasons: []string{"ticket 4: slow catalogue", "ticket 3: profile error", "ticket 2: missing comment", "ticket 1: login loop"}},
+		{name: "date range", query: "?from=2026-03-02T00:00:00Z&to=2026-03-04T00:00:00Z", wantStatus: http.StatusOK, wantReasons: []string{"ticket 3: profile error", "ticket 2: missing comment"}},
+		{name: "user and date range", query: "?user_id=7&from=2026-03-02T00:00:00Z&to=2026-03-04T00:00:00Z", wantStatus: http.StatusOK, wantReasons: []string{"ticket 3: profile error"}},
+		{name: "offset timestamps", query: "?from=2026-03-03T14:30:00%2B02:00", wantStatus: http.StatusOK, wantReasons: []string{"ticket 4: slow catalogue"}},
+		{name: "invalid from", query: "?from=2026-03-02", wantStatus: http.StatusBadRequest},
+		{name: "invalid to", query: "?to=yesterday", wantStatus: http.StatusBadRequest},
+		{name: "invalid user_id", query: "?user_id=alice", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.HandleImpersonations(rec, httptest.NewRequest(http.MethodGet, "/admin/audit/impersonations"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, Got %d: %s", tt.wantStatus, rec.Code, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var response auditEntriesResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			var reasons []string
+			for _, entry := range response.Items {
+				reasons = append(reasons, entry.Reason)
+			}
+			if len(reasons) != len(tt.wantReasons) {
+				t.Fatalf("Expected %v, Got %v", tt.wantReasons, reasons)
+			}
+			for i := range reasons {
+				if reasons[i] != tt.wantReasons[i] {
+					t.Errorf("Expected %v, Got %v", tt.wantReasons, reasons)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the AdminCommentDeleteHandler, which lets administrators remove any comment.
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/gorilla/mux"
+)
+
+// AdminCommentDeleteHandler serves DELETE /admin/comments/{id}.
+type AdminCommentDeleteHandler struct {
+	commentService input.CommentDeleteService
+}
+
+// NewAdminCommentDeleteHandler creates a new instance of AdminCommentDeleteHandler.
+func NewAdminCommentDeleteHandler(commentService input.CommentDeleteService) *AdminCommentDeleteHandler {
+	return &AdminCommentDeleteHandler{
+		commentService: commentService,
+	}
+}
+
+// Handle deletes the comment identified by the {id} path variable without the ownership check of DELETE /comments/{id}; the route must be restricted to administrators.
+// It responds with 204 (No Content) on success and 404 if the comment does not exist.
+func (h *AdminCommentDeleteHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrCommentNotFound))
+		return
+	}
+
+	if err := h.commentService.ForceDeleteComment(commentID); err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/gorilla/mux"
+)
+
+func TestAdminCommentDeleteRoute(t *testing.T) {
+	securityAuth.SetDefaultJWTService("0123456789abcdef0123456789abcdef", securityAuth.DefaultClockSkewTolerance)
+	adminToken, err := securityAuth.GenerateJWT(1, "admin", models.RoleAdmin)
+	if err != nil {
+		t.Fatalf("GenerateJWT failed: %v", err)
+	}
+	// The comment's author is not an administrator either, so owning the comment does not help.
+	userToken, _ := securityAuth.GenerateJWT(7, "alice", models.RoleUser)
+
+	service := &ownedCommentsService{owners: map[int]int{1: 7, 2: 8}}
+	router := mux.NewRouter()
+	router.Handle("/admin/comments/{id:[0-9]+}", middleware.Chain(
+		middleware.AuthMiddleware(&middleware.AuthOptions{CookieName: "token"}),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)(http.HandlerFunc(NewAdminCommentDeleteHandler(service).Handle))).Methods("DELETE")
+
+	tests := []struct {
+		name      string
+		token     string
+		commentID string
+		want      int
+	}{
+		{"unauthenticated", "", "1", http.StatusUnauthorized},
+		{"authenticated non-admin", userToken, "1", http.StatusForbidden},
+		{"admin deletes another user's comment", adminToken, "2", http.StatusNoContent},
+		{"admin deletes missing comment", adminToken, "2", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/admin/comments/"+tt.commentID, nil)
+			if tt.token != "" {
+				req.AddCookie(&http.Cookie{Name: "token", Value: tt.token})
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("Expected status %d, Got %d", tt.want, rec.Code)
+			}
+		})
+	}
+
+	if _, ok := service.owners[1]; !ok {
+		t.Error("Expected the non-admin request to leave comment 1 in place")
+	}
+}
@@ -0,0 +1,52 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the AdminCommentPinHandler, which lets administrators pin comments as homepage testimonials.
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/gorilla/mux"
+)
+
+// AdminCommentPinHandler serves POST /admin/comments/{id}/pin and POST /admin/comments/{id}/unpin.
+type AdminCommentPinHandler struct {
+	commentService input.CommentPinService
+}
+
+// NewAdminCommentPinHandler creates a new instance of AdminCommentPinHandler.
+func NewAdminCommentPinHandler(commentService input.CommentPinService) *AdminCommentPinHandler {
+	return &AdminCommentPinHandler{
+		commentService: commentService,
+	}
+}
+
+// HandlePin pins the comment identified by the {id} path variable; the route must be restricted to administrators.
+// It responds with 204 (No Content) on success, also when the comment was already pinned, 404 if the comment does not exist and 409 if models.MaxPinnedComments comments are already pinned.
+func (h *AdminCommentPinHandler) HandlePin(w http.ResponseWriter, r *http.Request) {
+	h.handle(w, r, h.commentService.Pin)
+}
+
+// HandleUnpin unpins the comment identified by the {id} path variable; the route must be restricted to administrators.
+// It responds with 204 (No Content) on success, also when the comment was not pinned, and 404 if the comment does not exist.
+func (h *AdminCommentPinHandler) HandleUnpin(w http.ResponseWriter, r *http.Request) {
+	h.handle(w, r, h.commentService.Unpin)
+}
+
+// handle applies action to the comment identified by the {id} path variable.
+func (h *AdminCommentPinHandler) handle(w http.ResponseWriter, r *http.Request, action func(commentID int) error) {
+	commentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrCommentNotFound))
+		return
+	}
+
+	if err := action(commentID); err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_comments"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/gorilla/mux"
+)
+
+func TestAdminCommentPinRoutes(t *testing.T) {
+	securityAuth.SetDefaultJWTService("0123456789abcdef0123456789abcdef", securityAuth.DefaultClockSkewTolerance)
+	adminToken, err := securityAuth.GenerateJWT(1, "admin", models.RoleAdmin)
+	if err != nil {
+		t.Fatalf("GenerateJWT failed: %v", err)
+	}
+	userToken, _ := securityAuth.GenerateJWT(7, "alice", models.RoleUser)
+
+	seeded := []models.Comment{{ID: 1, UserID: 7, Content: "Great watch", Rating: 5}}
+	for i := 0; i < models.MaxPinnedComments; i++ {
+		seeded = append(seeded, models.Comment{ID: 10 + i, UserID: 8, Content: "Pinned", Rating: 5, Pinned: i > 0})
+	}
+	repo := repotesting.NewInMemoryCommentRepository(repotesting.WithComments(seeded))
+	handler := NewAdminCommentPinHandler(service_comments.NewCommentPinService(repo))
+
+	adminOnly := middleware.Chain(
+		middleware.AuthMiddleware(&middleware.AuthOptions{CookieName: "token"}),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+	router := mux.NewRouter()
+	router.Handle("/admin/comments/{id:[0-9]+}/pin", adminOnly(http.HandlerFunc(handler.HandlePin))).Methods("POST")
+	router.Handle("/admin/comments/{id:[0-9]+}/unpin", adminOnly(http.HandlerFunc(handler.HandleUnpin))).Methods("POST")
+
+	// MaxPinnedComments-1 comments start pinned, so one more pin reaches the limit.
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		want  int
+	}{
+		{"unauthenticated", "", "/admin/comments/1/pin", http.StatusUnauthorized},
+		{"authenticated non-admin", userToken, "/admin/comments/1/pin", http.StatusForbidden},
+		{"admin pins", adminToken, "/admin/comments/10/pin", http.StatusNoContent},
+		{"admin pins again", adminToken, "/admin/comments/10/pin", http.StatusNoContent},
+		{"admin pins beyond the limit", adminToken, "/admin/comments/1/pin", http.StatusConflict},
+		{"admin pins missing comment", adminToken, "/admin/comments/99/pin", http.StatusNotFound},
+		{"admin unpins", adminToken, "/admin/comments/11/unpin", http.StatusNoContent},
+		{"admin pins after unpinning", adminToken, "/admin/comments/1/pin", http.StatusNoContent},
+		{"admin unpins missing comment", adminToken, "/admin/comments/99/unpin", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.token != "" {
+				req.AddCookie(&http.Cookie{Name: "token", Value: tt.token})
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("Expected status %d, Got %d", tt.want, rec.Code)
+			}
+		})
+	}
+
+	pinned, _ := repo.GetPinned()
+	if len(pinned) != models.MaxPinnedComments || pinned[0].ID != 1 {
+		t.Errorf("Expected comment 1 to be the most recently pinned of %d, Got %+v", models.MaxPinnedComments, pinned)
+	}
+}
@@ -0,0 +1,62 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the AdminConfigHandler, which lets administrators inspect and edit the running configuration.
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/config"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// AdminConfigHandler serves GET and PATCH /admin/config.
+type AdminConfigHandler struct {
+	appConfig *config.AppConfig
+}
+
+// NewAdminConfigHandler creates a new instance of AdminConfigHandler.
+func NewAdminConfigHandler(appConfig *config.AppConfig) *AdminConfigHandler {
+	return &AdminConfigHandler{
+		appConfig: appConfig,
+	}
+}
+
+// HandleGet returns every setting, keyed by its dotted name, as JSON with an HTTP 200 (OK) status.
+// Values of settings whose name contains password, secret, key or token are redacted.
+func (h *AdminConfigHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	httpUtil.SendJSONResponse(w, http.StatusOK, h.appConfig.SanitizedSettings())
+}
+
+// HandlePatch applies a JSON object of dotted setting names and values, e.g. {"feature_flags.disabled_routes": ["/products"]}, and responds with the updated sanitized settings.
+//
+// Only settings reloaded at runtime can be changed, currently feature_flags.disabled_routes; the others are read once at startup and must be changed in the configuration file before a restart.
+// It responds with 400 if the body is not a JSON object, 422 if a key is unknown, requires a restart or the change would make the configuration invalid (nothing is applied in that case), and 500 if the configuration file could not be rewritten.
+func (h *AdminConfigHandler) HandlePatch(w http.ResponseWriter, r *http.Request) {
+	var overrides map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&overrides); err != nil || len(overrides) == 0 {
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
+		return
+	}
+
+	configErrors, err := h.appConfig.ApplyOverrides(overrides)
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewInternalError(errors.ErrConfigPersist).WithError(err))
+		return
+	}
+	if len(configErrors) > 0 {
+		problems := make([]string, 0, len(configErrors))
+		for _, configErr := range configErrors {
+			problems = append(problems, configErr.Error())
+		}
+		httpUtil.WriteError(w, errors.NewValidationError(fmt.Sprintf("%s: %s", errors.ErrInvalidConfigOverride, strings.Join(problems, "; "))))
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	httpUtil.SendJSONResponse(w, http.StatusOK, h.appConfig.SanitizedSettings())
+}
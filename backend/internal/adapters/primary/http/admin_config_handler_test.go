@@ -0,0 +1,68 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/config"
+)
+
+// TestAdminConfigHandlerConcurrentRequests is meant for go test -race: GET and PATCH /admin/config read and change the configuration while requests read it.
+func TestAdminConfigHandlerConcurrentRequests(t *testing.T) {
+	appConfig := config.NewAppConfig()
+	handler := NewAdminConfigHandler(appConfig)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			handler.HandleGet(rec, httptest.NewRequest(http.MethodGet, "/admin/config", nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("GET: Expected status %d, Got %d", http.StatusOK, rec.Code)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			body := strings.NewReader(`{"feature_flags.disabled_routes": ["/products"]}`)
+			rec := httptest.NewRecorder()
+			handler.HandlePatch(rec, httptest.NewRequest(http.MethodPatch, "/admin/config", body))
+			if rec.Code != http.StatusOK {
+				t.Errorf("PATCH: Expected status %d, Got %d: %s", http.StatusOK, rec.Code, rec.Body)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			appConfig.IsRouteDisabled("/products")
+			appConfig.GetRateLimitConfig()
+		}()
+	}
+	wg.Wait()
+
+	if !appConfig.IsRouteDisabled("/products") {
+		t.Error("Expected /products to be disabled after the PATCH requests")
+	}
+}
+
+func TestAdminConfigHandlerRejectsSettingsNeedingRestart(t *testing.T) {
+	appConfig := config.NewAppConfig()
+	handler := NewAdminConfigHandler(appConfig)
+	before := appConfig.GetRateLimitConfig()
+
+	rec := httptest.NewRecorder()
+	handler.HandlePatch(rec, httptest.NewRequest(http.MethodPatch, "/admin/config", strings.NewReader(`{"rate_limiting.requests": 99}`)))
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, Got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "rate_limiting.requests") {
+		t.Errorf("Expected the response to name rate_limiting.requests, Got: %s", rec.Body)
+	}
+	if after := appConfig.GetRateLimitConfig(); after != before {
+		t.Errorf("Expected the rate limit to be unchanged, Got %+v", after)
+	}
+}
@@ -0,0 +1,29 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the AdminDashboardHandler, which reports the health indicators of the running instance.
+package http
+
+import (
+	"net/http"
+
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/metrics"
+)
+
+// AdminDashboardHandler serves GET /admin/dashboard.
+type AdminDashboardHandler struct {
+	collector *metrics.DashboardCollector
+}
+
+// NewAdminDashboardHandler creates a new instance of AdminDashboardHandler reporting the metrics registered on collector.
+func NewAdminDashboardHandler(collector *metrics.DashboardCollector) *AdminDashboardHandler {
+	return &AdminDashboardHandler{
+		collector: collector,
+	}
+}
+
+// Handle collects every dashboard metric concurrently and returns them as JSON with an HTTP 200 (OK) status.
+// Metrics that fail or exceed metrics.DashboardTimeout are reported as null and described under "errors", so one broken indicator never hides the others.
+func (h *AdminDashboardHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	httpUtil.SendJSONResponse(w, http.StatusOK, h.collector.Collect(r.Context()))
+}
@@ -0,0 +1,107 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the AdminImpersonateHandler, which lets administrators act as another user to diagnose issues.
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/http/cookies"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/gorilla/mux"
+)
+
+// AdminImpersonateHandler serves POST /admin/users/{id}/impersonate.
+type AdminImpersonateHandler struct {
+	userProfileService input.UserProfileService
+	auditRepo          output.AuditRepository
+	authCookieName     string
+	isProduction       bool
+}
+
+// NewAdminImpersonateHandler creates a new instance of AdminImpersonateHandler.
+// Target users are looked up through userProfileService, so deleted accounts cannot be impersonated, and every impersonation is recorded in auditRepo; the impersonation token is stored in the cookie named authCookieName, marked Secure when isProduction is set.
+func NewAdminImpersonateHandler(userProfileService input.UserProfileService, auditRepo output.AuditRepository, authCookieName string, isProduction bool) *AdminImpersonateHandler {
+	return &AdminImpersonateHandler{
+		userProfileService: userProfileService,
+		auditRepo:          auditRepo,
+		authCookieName:     authCookieName,
+		isProduction:       isProduction,
+	}
+}
+
+// Bounds of the reason given for an impersonation, in characters. The maximum matches the audit_log.Reason column.
+const (
+	minImpersonationReasonLength = 10
+	maxImpersonationReasonLength = 500
+)
+
+// impersonateRequest is the JSON body accepted by POST /admin/users/{id}/impersonate.
+type impersonateRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Handle replaces the administrator's authentication cookie with an impersonation token for the user identified by the {id} path variable (see securityAuth.GenerateImpersonationJWT); the route must be restricted to administrators.
+// The token and the cookie expire after securityAuth.ImpersonationTokenTTL, and the token always carries the user role. The refresh cookie is left untouched, so once the impersonation cookie expires the administrator's own session is renewed from it.
+// The body must be {"reason": "..."} with a reason of 10 to 500 characters, recorded with the impersonation in the audit log (see models.AuditActionImpersonation) before the cookie is set.
+// It responds with 200 (OK) and {"user_id": ..., "impersonated_by": ...}, 400 if the body or the reason is invalid, or 404 if the user does not exist.
+func (h *AdminImpersonateHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	impersonatorID, ok := r.Context().Value(middleware.GetUserIdContextKey()).(int)
+	if !ok {
+		httpUtil.WriteError(w, errors.NewAuthError(errors.ErrUnauthorized))
+		return
+	}
+
+	var request impersonateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
+		return
+	}
+	reason := strings.TrimSpace(request.Reason)
+	if length := utf8.RuneCountInString(reason); length < minImpersonationReasonLength || length > maxImpersonationReasonLength {
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidImpersonationReason))
+		return
+	}
+
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrUserNotFound))
+		return
+	}
+
+	profile, err := h.userProfileService.GetProfile(userID)
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+
+	token, err := securityAuth.GenerateImpersonationJWT(profile.ID, profile.UserName, impersonatorID)
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewInternalError(errors.ErrTokenGeneration).WithError(err))
+		return
+	}
+
+	err = h.auditRepo.Record(models.AuditEntry{
+		Action:    models.AuditActionImpersonation,
+		ActorID:   impersonatorID,
+		UserID:    profile.ID,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+	cookies.SetAuthCookie(w, h.authCookieName, token, h.isProduction, cookies.WithMaxAge(securityAuth.ImpersonationTokenTTL))
+	httpUtil.SendJSONResponse(w, http.StatusOK, whoAmIResponse{UserID: profile.ID, ImpersonatedBy: impersonatorID})
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/gorilla/mux"
+)
+
+// profilesService serves fixed profiles by user ID.
+type profilesService struct {
+	profiles map[int]models.UserProfile
+}
+
+func (s *profilesService) GetProfile(userID int) (models.UserProfile, error) {
+	profile, ok := s.profiles[userID]
+	if !ok {
+		return models.UserProfile{}, errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	return profile, nil
+}
+
+func (s *profilesService) UpdateDisplayName(userID int, displayName string) (models.UserProfile, error) {
+	return models.UserProfile{}, nil
+}
+
+func TestAdminImpersonateRoute(t *testing.T) {
+	securityAuth.SetDefaultJWTService("0123456789abcdef0123456789abcdef", securityAuth.DefaultClockSkewTolerance)
+	adminToken, err := securityAuth.GenerateJWT(1, "admin", models.RoleAdmin)
+	if err != nil {
+		t.Fatalf("GenerateJWT failed: %v", err)
+	}
+	userToken, _ := securityAuth.GenerateJWT(7, "alice", models.RoleUser)
+
+	service := &profilesService{profiles: map[int]models.UserProfile{7: {ID: 7, UserName: "alice", Role: models.RoleUser}}}
+	auditRepo := repotesting.NewInMemoryAuditRepository()
+	authMW := middleware.AuthMiddleware(&middleware.AuthOptions{CookieName: "token"})
+	router := mux.NewRouter()
+	router.Handle("/admin/users/{id:[0-9]+}/impersonate", middleware.Chain(authMW, middleware.RoleMiddleware(models.RoleAdmin))(
+		http.HandlerFunc(NewAdminImpersonateHandler(service, auditRepo, "token", false).Handle))).Methods("POST")
+	router.Handle("/auth/whoami", authMW(http.HandlerFunc(NewWhoAmIHandler().Handle))).Methods("GET")
+
+	const reason = `{"reason": "ticket 4521: comments fail to load"}`
+	send := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.AddCookie(&http.Cookie{Name: "token", Value: token})
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := send(http.MethodPost, "/admin/users/7/impersonate", userToken, reason); rec.Code != http.StatusForbidden {
+		t.Errorf("non-admin: Expected status %d, Got %d", http.StatusForbidden, rec.Code)
+	}
+	if rec := send(http.MethodPost, "/admin/users/99/impersonate", adminToken, reason); rec.Code != http.StatusNotFound {
+		t.Errorf("missing user: Expected status %d, Got %d", http.StatusNotFound, rec.Code)
+	}
+	for name, body := range map[string]string{
+		"malformed body": `{"reason":`,
+		"missing reason": `{}`,
+		"short reason":   `{"reason": "  debug  "}`,
+	} {
+		if rec := send(http.MethodPost, "/admin/users/7/impersonate", adminToken, body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: Expected status %d, Got %d", name, http.StatusBadRequest, rec.Code)
+		}
+	}
+	if entries, _ := auditRepo.List(models.AuditFilter{Action: models.AuditActionImpersonation}); len(entries) != 0 {
+		t.Fatalf("Expected rejected requests not to be audited, Got %+v", entries)
+	}
+
+	rec := send(http.MethodPost, "/admin/users/7/impersonate", adminToken, reason)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("admin: Expected status %d, Got %d", http.StatusOK, rec.Code)
+	}
+	entries, _ := auditRepo.List(models.AuditFilter{Action: models.AuditActionImpersonation})
+	if len(entries) != 1 || entries[0].ActorID != 1 || entries[0].UserID != 7 || entries[0].Reason != "ticket 4521: comments fail to load" {
+		t.Errorf("Expected one audit entry of admin 1 impersonating user 7 with the reason, Got %+v", entries)
+	}
+	var impersonationToken string
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == "token" {
+			impersonationToken = cookie.Value
+			if lifetime := time.Until(cookie.Expires); lifetime > securityAuth.ImpersonationTokenTTL || lifetime < securityAuth.ImpersonationTokenTTL-time.Minute {
+				t.Errorf("Expected the cookie to last %v, Got %v", securityAuth.ImpersonationTokenTTL, lifetime)
+			}
+		}
+	}
+
+	// The impersonation session is seen by GET /auth/whoami and has no administrator access.
+	var whoAmI whoAmIResponse
+	if err := json.NewDecoder(send(http.MethodGet, "/auth/whoami", impersonationToken, "").Body).Decode(&whoAmI); err != nil {
+		t.Fatalf("decoding whoami: %v", err)
+	}
+	if whoAmI.UserID != 7 || whoAmI.ImpersonatedBy != 1 {
+		t.Errorf("Expected user 7 impersonated by 1, Got %+v", whoAmI)
+	}
+	if rec := send(http.MethodPost, "/admin/users/7/impersonate", impersonationToken, reason); rec.Code != http.StatusForbidden {
+		t.Errorf("impersonated session: Expected status %d, Got %d", http.StatusForbidden, rec.Code)
+	}
+}
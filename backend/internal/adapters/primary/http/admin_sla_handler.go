@@ -0,0 +1,45 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the AdminSLAHandler, which reports daily latency percentiles and error rates per route.
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// AdminSLAHandler serves GET /admin/metrics/sla.
+type AdminSLAHandler struct {
+	slaReportService input.SLAReportService
+}
+
+// NewAdminSLAHandler creates a new instance of AdminSLAHandler.
+func NewAdminSLAHandler(slaReportService input.SLAReportService) *AdminSLAHandler {
+	return &AdminSLAHandler{
+		slaReportService: slaReportService,
+	}
+}
+
+// Handle returns the SLA report of the UTC day given by the date query parameter (YYYY-MM-DD, today when absent) as JSON with an HTTP 200 (OK) status.
+// An invalid date yields a 400 (Bad Request) response. Requests are only recorded while logging.sla_log_enabled is set.
+func (h *AdminSLAHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	date := time.Now().UTC()
+	if raw := r.URL.Query().Get("date"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidReportDate))
+			return
+		}
+		date = parsed
+	}
+
+	report, err := h.slaReportService.GetDailyReport(date)
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+	httpUtil.SendJSONResponse(w, http.StatusOK, report)
+}
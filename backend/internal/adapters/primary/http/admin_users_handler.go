@@ -0,0 +1,46 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the AdminUsersHandler, which lets administrators audit registered accounts.
+package http
+
+import (
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// Default and maximum number of users returned per page by GET /admin/users.
+const (
+	defaultUsersPerPage = 20
+	maxUsersPerPage     = 100
+)
+
+// AdminUsersHandler serves GET /admin/users.
+type AdminUsersHandler struct {
+	userListService input.UserListService
+}
+
+// NewAdminUsersHandler creates a new instance of AdminUsersHandler.
+func NewAdminUsersHandler(userListService input.UserListService) *AdminUsersHandler {
+	return &AdminUsersHandler{
+		userListService: userListService,
+	}
+}
+
+// Handle returns one page of registered users as {"items": [...], "meta": {...}} with an HTTP 200 (OK) status and a Link header to the neighbouring pages.
+// It reads the page and per_page (or page_size) query parameters, defaulting to 1 and 20; invalid parameters yield a 422 (Unprocessable Entity) response. Users are listed as profiles, so no password hash is ever exposed.
+func (h *AdminUsersHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	page, perPage, err := httpUtil.ParsePageParams(r, defaultUsersPerPage, maxUsersPerPage)
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+
+	users, err := h.userListService.ListUsers(page, perPage)
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	httpUtil.SendPageResponse(w, r, users)
+}
@@ -0,0 +1,48 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the CommentDeleteHandler, which lets users remove their own comments.
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/gorilla/mux"
+)
+
+// CommentDeleteHandler serves DELETE /comments/{id}.
+type CommentDeleteHandler struct {
+	commentService input.CommentDeleteService
+}
+
+// NewCommentDeleteHandler creates a new instance of CommentDeleteHandler.
+func NewCommentDeleteHandler(commentService input.CommentDeleteService) *CommentDeleteHandler {
+	return &CommentDeleteHandler{
+		commentService: commentService,
+	}
+}
+
+// Handle deletes the comment identified by the {id} path variable on behalf of the authenticated user.
+// It responds with 204 (No Content) on success, 404 if the comment does not exist and 403 if another user wrote it.
+func (h *CommentDeleteHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.GetUserIdContextKey()).(int)
+	if !ok {
+		httpUtil.WriteError(w, errors.NewAuthError(errors.ErrUnauthorized))
+		return
+	}
+
+	commentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrCommentNotFound))
+		return
+	}
+
+	if err := h.commentService.DeleteComment(commentID, userID); err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
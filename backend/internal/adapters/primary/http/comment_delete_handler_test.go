@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	"github.com/gorilla/mux"
+)
+
+// ownedCommentsService deletes the comments listed in owners only for their author.
+type ownedCommentsService struct {
+	owners map[int]int
+}
+
+func (s *ownedCommentsService) DeleteComment(commentID, userID int) error {
+	owner, ok := s.owners[commentID]
+	switch {
+	case !ok:
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	case owner != userID:
+		return errors.NewForbiddenError(errors.ErrCommentNotOwned)
+	}
+	delete(s.owners, commentID)
+	return nil
+}
+
+func (s *ownedCommentsService) ForceDeleteComment(commentID int) error {
+	if _, ok := s.owners[commentID]; !ok {
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	}
+	delete(s.owners, commentID)
+	return nil
+}
+
+func TestCommentDeleteHandler(t *testing.T) {
+	handler := NewCommentDeleteHandler(&ownedCommentsService{owners: map[int]int{1: 7, 2: 8}})
+
+	tests := []struct {
+		name      string
+		commentID string
+		want      int
+	}{
+		{"own comment", "1", http.StatusNoContent},
+		{"already deleted", "1", http.StatusNotFound},
+		{"another user's comment", "2", http.StatusForbidden},
+		{"missing comment", "99", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/comments/"+tt.commentID, nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.GetUserIdContextKey(), 7))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.commentID})
+			rec := httptest.NewRecorder()
+			handler.Handle(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("Expected status %d, Got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
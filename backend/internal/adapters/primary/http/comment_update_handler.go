@@ -0,0 +1,64 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the CommentUpdateHandler, which lets users edit their own comments.
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/gorilla/mux"
+)
+
+// CommentUpdateHandler serves PUT /comments/{id}.
+type CommentUpdateHandler struct {
+	commentService input.CommentUpdateService
+}
+
+// NewCommentUpdateHandler creates a new instance of CommentUpdateHandler.
+func NewCommentUpdateHandler(commentService input.CommentUpdateService) *CommentUpdateHandler {
+	return &CommentUpdateHandler{
+		commentService: commentService,
+	}
+}
+
+// updateCommentRequest is the JSON body accepted by PUT /comments/{id}.
+type updateCommentRequest struct {
+	Content string `json:"content"`
+	Rating  int    `json:"rating"`
+}
+
+// Handle replaces the content and rating of the comment identified by the {id} path variable on behalf of the authenticated user.
+// It responds with the updated comment and an HTTP 200 (OK) status, 400 for malformed JSON, 422 when the content or rating breaks the comment rules,
+// 404 if the comment does not exist and 403 if another user wrote it.
+func (h *CommentUpdateHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.GetUserIdContextKey()).(int)
+	if !ok {
+		httpUtil.WriteError(w, errors.NewAuthError(errors.ErrUnauthorized))
+		return
+	}
+
+	commentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrCommentNotFound))
+		return
+	}
+
+	var request updateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(commentID, userID, request.Content, request.Rating)
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+
+	httpUtil.SendJSONResponse(w, http.StatusOK, comment)
+}
@@ -3,8 +3,11 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
 	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
 	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
@@ -26,15 +29,88 @@ func NewCommentsGetHandler(commentService input.CommentGetService) *CommentsGetH
 	}
 }
 
-// Handle processes incoming HTTP requests to retrieve comments.
+// Default and maximum number of comments returned per page by GET /comments.
+const (
+	defaultCommentsPerPage = 20
+	maxCommentsPerPage     = 100
+)
+
+// Handle processes incoming HTTP requests to retrieve one page of comments.
 
-// It calls the GetComments method of the commentService to fetch comments. If an error occurs during the retrieval, it sends an HTTP error response with a 500 (Internal Server Error) status using a utility function. If successful, it returns the comments in JSON format with an HTTP 200 (OK) status.
+// It reads the page and per_page (or page_size) query parameters (defaulting to 1 and 20) and calls the GetPage method of the commentService. Invalid parameters yield a 422 (Unprocessable Entity) response and retrieval failures a 500 (Internal Server Error). If successful, it returns {"items": [...], "meta": {...}} with an HTTP 200 (OK) status and a Link header to the neighbouring pages; CSV and plain-text clients receive the page's comments as a table.
 func (h *CommentsGetHandler) Handle(w http.ResponseWriter, r *http.Request) {
-	comments, err := h.commentService.AllComments()
+	page, perPage, err := httpUtil.ParsePageParams(r, defaultCommentsPerPage, maxCommentsPerPage)
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+
+	comments, err := h.commentService.GetPage(page, perPage)
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewInternalError("Error getting feedback"))
+		return
+	}
+
+	if httpUtil.MediaTypeFromContext(r.Context()) != httpUtil.MediaTypeJSON {
+		httpUtil.SetPageLinkHeader(w, r, httpUtil.NewPageMeta(comments))
+		httpUtil.SendResponse(w, r, http.StatusOK, commentTable(comments.Items))
+		return
+	}
+	httpUtil.SendPageResponse(w, r, comments)
+}
+
+// histogramCacheMaxAge is how long clients and proxies may cache the rating histogram, in seconds.
+const histogramCacheMaxAge = 60
+
+// HandleHistogram returns the number of comments at each rating level as JSON, e.g. {"1": 3, "2": 7, "3": 15, "4": 42, "5": 18}.
+// The response is public and cacheable for 60 seconds.
+func (h *CommentsGetHandler) HandleHistogram(w http.ResponseWriter, r *http.Request) {
+	histogram, err := h.commentService.GetRatingHistogram()
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewInternalError("Error getting rating histogram"))
+		return
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", histogramCacheMaxAge))
+	httpUtil.SendJSONResponse(w, http.StatusOK, histogram)
+}
+
+// pinnedCacheMaxAge is how long clients and proxies may cache the pinned comments, in seconds.
+const pinnedCacheMaxAge = 300
+
+// HandlePinned returns the comments pinned as homepage testimonials as a JSON array, most recently pinned first.
+// The response is public and cacheable for 5 minutes.
+func (h *CommentsGetHandler) HandlePinned(w http.ResponseWriter, r *http.Request) {
+	comments, err := h.commentService.GetPinned()
 	if err != nil {
-		httpUtil.HandleError(w, errors.NewInternalError("Error getting feedback"))
+		httpUtil.WriteError(w, errors.NewInternalError("Error getting pinned comments"))
 		return
 	}
 
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", pinnedCacheMaxAge))
 	httpUtil.SendJSONResponse(w, http.StatusOK, comments)
 }
+
+// commentTable adapts a list of comments to httpUtil.CSVFormatter. It encodes to JSON exactly like []models.Comment.
+type commentTable []models.Comment
+
+// CSVHeader returns the comment column names.
+func (t commentTable) CSVHeader() []string {
+	return []string{"ID", "Date", "UserID", "UserName", "Content", "Rating"}
+}
+
+// CSVRows returns one row per comment.
+func (t commentTable) CSVRows() [][]string {
+	rows := make([][]string, 0, len(t))
+	for _, comment := range t {
+		rows = append(rows, []string{
+			strconv.Itoa(comment.ID),
+			comment.Date,
+			strconv.Itoa(comment.UserID),
+			comment.UserName,
+			comment.Content,
+			strconv.Itoa(comment.Rating),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,44 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the CSRFTokenHandler, which issues CSRF tokens to single-page applications.
+package http
+
+import (
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// CSRFTokenHandler serves GET /auth/csrf-token.
+type CSRFTokenHandler struct {
+	isProduction bool
+	secret       []byte
+}
+
+// NewCSRFTokenHandler creates a new instance of CSRFTokenHandler.
+
+// Tokens are signed with secret, which must be the one given to middleware.CSRFMiddleware. In production the CSRF cookie is marked Secure.
+func NewCSRFTokenHandler(isProduction bool, secret []byte) *CSRFTokenHandler {
+	return &CSRFTokenHandler{
+		isProduction: isProduction,
+		secret:       secret,
+	}
+}
+
+// Handle generates a new signed CSRF token, stores it in the HttpOnly csrf_token cookie and returns it in the X-CSRF-Token header and as {"csrf_token": "..."} with an HTTP 200 (OK) status.
+// The client must send the returned value in the X-CSRF-Token header of every mutating request. The response is never cached, since each call rotates the token.
+func (h *CSRFTokenHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	token, err := middleware.GenerateCSRFToken(h.secret)
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewInternalError(errors.ErrCSRFTokenGeneration).WithError(err))
+		return
+	}
+
+	middleware.SetCSRFCookie(w, token, h.isProduction)
+	w.Header().Set(middleware.CSRFHeaderName, token)
+	w.Header().Set("Cache-Control", "no-store")
+	httpUtil.SendJSONResponse(w, http.StatusOK, map[string]string{
+		"csrf_token": token,
+	})
+}
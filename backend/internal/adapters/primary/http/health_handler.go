@@ -0,0 +1,55 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the HealthHandler, which reports whether the instance and its database are able to serve traffic.
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// healthCheckTimeout bounds the database ping of a health check.
+const healthCheckTimeout = 2 * time.Second
+
+// DatabasePinger checks that the database is reachable; *sqlx.DB implements it.
+type DatabasePinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// HealthResponse is the body of GET /health.
+//
+// Fields:
+//   - Status: "ok" when every dependency is reachable, "degraded" otherwise.
+//   - DB: "ok", or "error: " followed by the ping error.
+type HealthResponse struct {
+	Status string `json:"status"`
+	DB     string `json:"db"`
+}
+
+// HealthHandler serves GET /health for load balancers and orchestrators.
+type HealthHandler struct {
+	db DatabasePinger
+}
+
+// NewHealthHandler creates a new instance of HealthHandler checking db, usually the application's *sqlx.DB.
+func NewHealthHandler(db DatabasePinger) *HealthHandler {
+	return &HealthHandler{
+		db: db,
+	}
+}
+
+// Handle pings the database with a timeout of healthCheckTimeout.
+// It responds with HTTP 200 (OK) and {"status":"ok","db":"ok"} when the ping succeeds, and with HTTP 503 (Service Unavailable) and a "degraded" status otherwise.
+func (h *HealthHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	w.Header().Set("Cache-Control", "no-store")
+	if err := h.db.PingContext(ctx); err != nil {
+		httpUtil.SendJSONResponse(w, http.StatusServiceUnavailable, HealthResponse{Status: "degraded", DB: "error: " + err.Error()})
+		return
+	}
+	httpUtil.SendJSONResponse(w, http.StatusOK, HealthResponse{Status: "ok", DB: "ok"})
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubPinger returns err from every ping.
+type stubPinger struct {
+	err error
+}
+
+func (p stubPinger) PingContext(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("ping without a deadline")
+	}
+	return p.err
+}
+
+func TestHealthHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		pingErr    error
+		wantStatus int
+		want       HealthResponse
+	}{
+		{name: "healthy", wantStatus: http.StatusOK, want: HealthResponse{Status: "ok", DB: "ok"}},
+		{
+			name:       "database down",
+			pingErr:    errors.New("connection refused"),
+			wantStatus: http.StatusServiceUnavailable,
+			want:       HealthResponse{Status: "degraded", DB: "error: connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewHealthHandler(stubPinger{err: tt.pingErr}).Handle(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Status Expected: %d, Got: %d", tt.wantStatus, rec.Code)
+			}
+			var got HealthResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Invalid JSON body %q: %v", rec.Body.String(), err)
+			}
+			if got != tt.want {
+				t.Errorf("Body Expected: %+v, Got: %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ package http
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
 	"os"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
 	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
 	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/http/cookies"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
 )
 
 // LoginHandler handles HTTP requests related to user login.
@@ -19,41 +21,62 @@ import (
 // It acts as an adapter between HTTP requests and the core domain's login functionality, using the UserServiceLogin interface to process login operations.
 type LoginHandler struct {
 	userServiceLogin input.UserServiceLogin
+	authCookieName   string
+	refreshTokens    models.RefreshTokenConfig
 }
 
 // NewLoginHandler creates a new instance of LoginHandler.
 
-// It receives an implementation of the UserServiceLogin interface, which encapsulates the business logic for authenticating users.
-func NewLoginHandler(userServiceLogin input.UserServiceLogin) *LoginHandler {
+// It receives an implementation of the UserServiceLogin interface, which encapsulates the business logic for authenticating users, the name of the cookie the token is stored in, and the refresh token settings.
+func NewLoginHandler(userServiceLogin input.UserServiceLogin, authCookieName string, refreshTokens models.RefreshTokenConfig) *LoginHandler {
 	return &LoginHandler{
 		userServiceLogin: userServiceLogin,
+		authCookieName:   authCookieName,
+		refreshTokens:    refreshTokens,
 	}
 }
 
 // Handle processes HTTP login requests.
 
-// It validates that the request method is POST, decodes the JSON body into an Account model, and calls the login service to perform authentication. If the login operation is successful, it sets an authentication cookie based on the application's environment and sends a JSON response with a success message. Otherwise, it handles errors appropriately.
+// It validates that the request method is POST, decodes the JSON body into an Account model, and calls the login service to perform authentication. If the login operation is successful, it sets an authentication cookie (and a refresh cookie when refresh tokens are enabled) based on the application's environment and sends a JSON response with a success message. Otherwise, it handles errors appropriately.
 func (h *LoginHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		httpUtil.HandleError(w, errors.NewBadRequestError(errors.ErrMethodNotAllowed))
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrMethodNotAllowed))
 		return
 	}
 
 	var account models.Account
 	if err := json.NewDecoder(r.Body).Decode(&account); err != nil {
-		httpUtil.HandleError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
 		return
 	}
 
 	token, err := h.userServiceLogin.Login(account)
 	if err != nil {
-		httpUtil.HandleError(w, err)
+		httpUtil.WriteError(w, err)
 		return
 	}
 
 	isProduction := os.Getenv("ENV") == "production"
-	cookies.SetAuthCookie(w, token, isProduction)
+	cookies.SetAuthCookie(w, h.authCookieName, token, isProduction)
+	setRefreshCookie(w, h.refreshTokens, token, isProduction)
 	httpUtil.SendJSONResponse(w, http.StatusOK, map[string]string{
 		"message": "Successful login",
 	})
 }
+
+// setRefreshCookie issues a refresh token for the session of accessToken and stores it in the refresh cookie.
+// Nothing is set when refresh tokens are disabled. A failure is only logged: the session is already established, it just cannot be renewed without logging in again.
+func setRefreshCookie(w http.ResponseWriter, refreshTokens models.RefreshTokenConfig, accessToken string, isProduction bool) {
+	if !refreshTokens.Enabled() {
+		return
+	}
+	refreshToken, err := securityAuth.IssueRefreshToken(accessToken)
+	if err != nil {
+		log.Printf("[WARN] could not issue refresh token: %v", err)
+		return
+	}
+	if refreshToken != "" {
+		cookies.SetRefreshCookie(w, refreshTokens.CookieName, refreshToken, refreshTokens.TTL, isProduction)
+	}
+}
@@ -0,0 +1,63 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the LogoutHandler, which ends a session by revoking its tokens and clearing their cookies.
+package http
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/http/cookies"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+// LogoutHandler serves POST /logout.
+type LogoutHandler struct {
+	tokenRevocationService input.TokenRevocationService
+	authCookieName         string
+	refreshTokens          models.RefreshTokenConfig
+}
+
+// NewLogoutHandler creates a new instance of LogoutHandler.
+
+// It receives the service that revokes the access token, the name of the cookie the authentication token is stored in and the refresh token settings.
+func NewLogoutHandler(tokenRevocationService input.TokenRevocationService, authCookieName string, refreshTokens models.RefreshTokenConfig) *LogoutHandler {
+	return &LogoutHandler{
+		tokenRevocationService: tokenRevocationService,
+		authCookieName:         authCookieName,
+		refreshTokens:          refreshTokens,
+	}
+}
+
+// Handle revokes the access token and the refresh token of the request, expires their cookies and responds with an HTTP 200 (OK) status.
+// It succeeds whether or not the client was logged in, or its token has already expired, so it is safe to call repeatedly.
+// A failure to revoke is logged but does not fail the logout, since the cookies are cleared either way.
+func (h *LogoutHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	endSession(w, r, h.tokenRevocationService, h.authCookieName, h.refreshTokens)
+
+	httpUtil.SendJSONResponse(w, http.StatusOK, map[string]string{
+		"message": "Successfully logged out",
+	})
+}
+
+// endSession revokes the access and refresh tokens sent with r and expires their cookies.
+// Revocation failures are only logged, since clearing the cookies ends the session on the client either way.
+func endSession(w http.ResponseWriter, r *http.Request, tokenRevocationService input.TokenRevocationService, authCookieName string, refreshTokens models.RefreshTokenConfig) {
+	if cookie, err := r.Cookie(authCookieName); err == nil && cookie.Value != "" {
+		if err := tokenRevocationService.RevokeToken(cookie.Value); err != nil {
+			log.Printf("[WARN] could not revoke access token: %v", err)
+		}
+	}
+	cookies.ClearCookie(w, authCookieName)
+
+	if refreshTokens.Enabled() {
+		if cookie, err := r.Cookie(refreshTokens.CookieName); err == nil && cookie.Value != "" {
+			if err := securityAuth.RevokeRefreshToken(cookie.Value); err != nil {
+				log.Printf("[WARN] could not revoke refresh token: %v", err)
+			}
+		}
+		cookies.ClearCookie(w, refreshTokens.CookieName)
+	}
+}
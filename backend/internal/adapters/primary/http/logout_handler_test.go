@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+)
+
+// recordingRevocationService records the tokens passed to RevokeToken.
+type recordingRevocationService struct {
+	revoked []string
+}
+
+func (s *recordingRevocationService) RevokeToken(token string) error {
+	s.revoked = append(s.revoked, token)
+	return nil
+}
+
+func (s *recordingRevocationService) IsRevoked(tokenID string) (bool, error) {
+	return false, nil
+}
+
+func TestLogoutRevokesTokenAndClearsCookie(t *testing.T) {
+	revocation := &recordingRevocationService{}
+	handler := NewLogoutHandler(revocation, "token", models.RefreshTokenConfig{})
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "expired-or-valid-token"})
+	rec := httptest.NewRecorder()
+	handler.Handle(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, Got %d", http.StatusOK, rec.Code)
+	}
+	if len(revocation.revoked) != 1 || revocation.revoked[0] != "expired-or-valid-token" {
+		t.Errorf("Expected the token to be revoked, Got %v", revocation.revoked)
+	}
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("Expected 1 Set-Cookie header, Got %d", len(cookies))
+	}
+	if cookies[0].Name != "token" || cookies[0].Value != "" || cookies[0].MaxAge >= 0 {
+		t.Errorf("Expected the token cookie to be cleared, Got %q=%q with MaxAge %d", cookies[0].Name, cookies[0].Value, cookies[0].MaxAge)
+	}
+}
+
+func TestLogoutWithoutCookieSucceeds(t *testing.T) {
+	revocation := &recordingRevocationService{}
+	handler := NewLogoutHandler(revocation, "token", models.RefreshTokenConfig{})
+
+	rec := httptest.NewRecorder()
+	handler.Handle(rec, httptest.NewRequest(http.MethodPost, "/logout", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, Got %d", http.StatusOK, rec.Code)
+	}
+	if len(revocation.revoked) != 0 {
+		t.Errorf("Expected nothing to be revoked, Got %v", revocation.revoked)
+	}
+}
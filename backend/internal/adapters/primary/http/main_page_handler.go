@@ -3,16 +3,23 @@
 package http
 
 import (
+	"log"
 	"net/http"
 	"path/filepath"
+	"strings"
 	"text/template"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
 )
 
 // MainPageHandler handles HTTP requests to the main page.
 
 // It serves the main HTML page of the application, typically used as the entry point for client-side rendered applications.
 type MainPageHandler struct {
-	staticDir string
+	staticDir         string
+	staticFileService output.StaticFilePort
 }
 
 // NewMainPageHandler creates a new instance of MainPageHandler.
@@ -28,9 +35,40 @@ func (h *MainPageHandler) SetStaticDir(staticDir string) {
 	h.staticDir = staticDir
 }
 
+// SetStaticFileService sets the static file service used to build versioned asset URLs.
+
+// Without it, the versionedAsset template function returns paths unchanged and staticURL returns an empty string.
+func (h *MainPageHandler) SetStaticFileService(staticFileService output.StaticFilePort) {
+	h.staticFileService = staticFileService
+}
+
+// versionedAsset returns the content-hash versioned URL of a static asset, for use as {{ versionedAsset "/css/style.css" }} in templates.
+// If the URL cannot be built (no static file service or missing file), the path is returned unchanged so the page still renders.
+func (h *MainPageHandler) versionedAsset(path string) string {
+	if h.staticFileService == nil {
+		return path
+	}
+
+	versioned, err := h.staticFileService.VersionedURL(path)
+	if err != nil {
+		log.Printf("[WARN] could not version asset %s: %v", path, err)
+		return path
+	}
+	return versioned
+}
+
+// staticURL returns the static URL prefix without its trailing slash, for use as {{ staticURL }}/css/style.css in templates.
+// With the default "/" prefix it returns an empty string, so the result is a root-relative path.
+func (h *MainPageHandler) staticURL() string {
+	if h.staticFileService == nil {
+		return ""
+	}
+	return strings.TrimSuffix(h.staticFileService.GetPublicURL(""), "/")
+}
+
 // Handle processes HTTP requests to the main page.
 
-// It determines the path to the index.html file, either from the configured static directory or a default path. It then parses and executes the template, writing the rendered HTML to the response. If an error occurs during template parsing, it responds with an HTTP 500 Internal Server Error.
+// It determines the path to the index.html file, either from the configured static directory or a default path. It then parses and executes the template with the versionedAsset and staticURL functions available, writing the rendered HTML to the response. If an error occurs during template parsing, it responds with an HTTP 500 Internal Server Error.
 func (h *MainPageHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	// Determine the path to index.html
 	var indexPath string
@@ -44,10 +82,26 @@ func (h *MainPageHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse and execute the template
-	tmpl, err := template.ParseFiles(indexPath)
+	funcMap := template.FuncMap{
+		"versionedAsset": h.versionedAsset,
+		"staticURL":      h.staticURL,
+	}
+	tmpl, err := template.New(filepath.Base(indexPath)).Funcs(funcMap).ParseFiles(indexPath)
 	if err != nil {
 		http.Error(w, "Error loading page", http.StatusInternalServerError)
 		return
 	}
 	tmpl.Execute(w, nil)
 }
+
+// HandleSPAFallback serves the main page for navigational requests to paths without a route, so a single-page application can resolve them on the client.
+
+// Only GET and HEAD requests for paths without a file extension are navigational; anything else, such as a missing script or stylesheet, is answered with a JSON 404 so broken asset URLs are not masked by HTML.
+func (h *MainPageHandler) HandleSPAFallback(w http.ResponseWriter, r *http.Request) {
+	isNavigation := (r.Method == http.MethodGet || r.Method == http.MethodHead) && filepath.Ext(r.URL.Path) == ""
+	if !isNavigation {
+		httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrRouteNotFound))
+		return
+	}
+	h.Handle(w, r)
+}
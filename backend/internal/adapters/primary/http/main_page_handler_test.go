@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHandleSPAFallback(t *testing.T) {
+	staticDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(staticDir, "index.html"), []byte("<html><body>store</body></html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewMainPageHandler()
+	handler.SetStaticDir(staticDir)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{"navigational path", http.MethodGet, "/dashboard", http.StatusOK},
+		{"nested navigational path", http.MethodGet, "/orders/42", http.StatusOK},
+		{"missing asset", http.MethodGet, "/nonexistent.js", http.StatusNotFound},
+		{"unknown API call", http.MethodPost, "/dashboard", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.HandleSPAFallback(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("Expected status %d, Got %d", tt.want, rec.Code)
+			}
+			if tt.want == http.StatusOK && !strings.Contains(rec.Body.String(), "<html>") {
+				t.Errorf("Expected the main page HTML, Got %q", rec.Body.String())
+			}
+		})
+	}
+}
@@ -9,6 +9,10 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/config"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/http/cookies"
 	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
 )
 
@@ -20,6 +24,25 @@ type contextKey string
 // in the request context. It is unexported to prevent misuse.
 const userIDContextKey contextKey = "userID"
 
+// impersonatedByContextKey is the key under which the ID of an impersonating user is stored in the request context.
+const impersonatedByContextKey contextKey = "impersonatedBy"
+
+// roleContextKey is the key under which the authenticated user's role is stored in the request context.
+const roleContextKey contextKey = "role"
+
+// GetRole returns the role of the authenticated user, taken from the role claim of the access token.
+// It reports false for unauthenticated requests and tokens without a role claim.
+func GetRole(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(roleContextKey).(string)
+	return role, ok && role != ""
+}
+
+// GetImpersonatedBy returns the ID of the user impersonating the authenticated user, if the request was made with an impersonation token.
+func GetImpersonatedBy(ctx context.Context) (int, bool) {
+	impersonatorID, ok := ctx.Value(impersonatedByContextKey).(int)
+	return impersonatorID, ok && impersonatorID > 0
+}
+
 // GetUserIDContextKey returns the context key used to retrieve the user ID
 // from an HTTP request's context. This allows handlers to extract the
 // authenticated user's ID from context:
@@ -36,19 +59,40 @@ type AuthOptions struct {
 	// ExcludedPaths is a slice of URL patterns that will not require authentication.
 	// Both exact matches and directory prefixes (ending with '/') are supported.
 	ExcludedPaths []string
+
+	// CookieName is the name of the cookie carrying the JWT token.
+	CookieName string
+
+	// RefreshTokens configures the refresh cookie used to renew a missing or expired access token; disabled when its TTL is zero.
+	RefreshTokens models.RefreshTokenConfig
+
+	// SecureCookies marks the cookies set on refresh as HTTPS only.
+	SecureCookies bool
+
+	// RevokedTokens rejects access tokens revoked on logout; nil disables the check.
+	RevokedTokens input.TokenRevocationService
 }
 
 // DefaultAuthOptions creates and returns a new AuthOptions instance with default values.
 // The default configuration excludes common public paths like home, authentication pages,
-// and static asset directories from requiring authentication.
+// and static asset directories from requiring authentication, and reads the token from
+// the cookie named by appConfig.GetAuthCookieName(), falling back to the refresh cookie from appConfig.GetRefreshTokenConfig().
 // Returns a pointer to the newly created AuthOptions.
-func DefaultAuthOptions() *AuthOptions {
+func DefaultAuthOptions(appConfig *config.AppConfig) *AuthOptions {
 	return &AuthOptions{
+		CookieName:    appConfig.GetAuthCookieName(),
+		RefreshTokens: appConfig.GetRefreshTokenConfig(),
+		SecureCookies: appConfig.IsProduction(),
 		ExcludedPaths: []string{
 			"/",
 			"/login",
 			"/comments",
+			"/comments/histogram",
+			"/comments/pinned",
+			"/products",
+			"/products/",
 			"/register",
+			"/auth/csrf-token",
 			"/css/",
 			"/js/",
 			"/assets/"},
@@ -58,11 +102,11 @@ func DefaultAuthOptions() *AuthOptions {
 // AuthMiddleware returns an HTTP middleware that enforces authentication using JWT tokens stored in cookies. It wraps an existing http.Handler and performs the following logic:
 
 // 1. If the request path matches any of the patterns in opts.ExcludedPaths, the request is allowed to proceed without authentication.
-// 2. Otherwise, the middleware looks for a "token" cookie in the request.
-// 3. If the cookie is missing or empty, responds with 401 Unauthorized.
+// 2. Otherwise, the middleware looks for the cookie named by opts.CookieName in the request.
+// 3. If the cookie is missing or empty, the session is renewed from the refresh cookie (see refreshSession); without a valid refresh token, responds with 401 Unauthorized.
 // 4. Parses and validates the JWT token using the security_auth package.
-// 5. If token parsing fails (invalid or expired), responds with 401 Unauthorized.
-// 6. On successful validation, extracts the UserId from token claims, stores it in the request context under userIDContextKey, and calls the next handler.
+// 5. If the token is expired, the session is renewed from the refresh cookie as in step 3; if it is otherwise invalid, revoked (see AuthOptions.RevokedTokens), or cannot be renewed, responds with 401 Unauthorized.
+// 6. On successful validation, extracts the UserId and Role from token claims, stores them in the request context (see GetUserIdContextKey and GetRole), and calls the next handler. Impersonation tokens also store the impersonator's ID (see GetImpersonatedBy).
 
 // Parameters:
 //   - opts: pointer to AuthOptions specifying paths to exclude from auth.
@@ -72,41 +116,92 @@ func DefaultAuthOptions() *AuthOptions {
 func AuthMiddleware(options *AuthOptions) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			for _, excludedPath := range options.ExcludedPaths {
-				if excludedPath == "/" {
-					if r.URL.Path == "/" {
-						next.ServeHTTP(w, r)
-						return
-					}
-					continue
-				}
-
-				if r.URL.Path == excludedPath {
-					next.ServeHTTP(w, r)
-					return
-				}
-				if strings.HasSuffix(excludedPath, "/") && strings.HasPrefix(r.URL.Path, excludedPath) {
-					next.ServeHTTP(w, r)
-					return
-				}
+			if matchesPath(r.URL.Path, options.ExcludedPaths) {
+				next.ServeHTTP(w, r)
+				return
 			}
-			cookie, err := r.Cookie("token")
+			cookie, err := r.Cookie(options.CookieName)
 			if err != nil || cookie.Value == "" {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				claims := refreshSession(w, r, options)
+				if claims == nil {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(withClaims(r.Context(), claims)))
 				return
 			}
 
 			tokenString := cookie.Value
 			claims, err := securityAuth.ParseTokenWithClaims(tokenString)
-			if err != nil {
+			if err != nil && securityAuth.IsTokenExpired(err) {
+				claims = refreshSession(w, r, options)
+			}
+			if claims == nil || isRevoked(claims, options) {
 				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 				return
 			}
 
-			ctx := r.Context()
-			contextWithUser := context.WithValue(ctx, userIDContextKey, claims.UserId)
-
-			next.ServeHTTP(w, r.WithContext(contextWithUser))
+			next.ServeHTTP(w, r.WithContext(withClaims(r.Context(), claims)))
 		})
 	}
+}
+
+// matchesPath reports whether path matches one of patterns: either exactly, or, for patterns ending with '/' other than "/" itself, as a prefix.
+func matchesPath(path string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if path == pattern {
+			return true
+		}
+		if pattern != "/" && strings.HasSuffix(pattern, "/") && strings.HasPrefix(path, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRevoked reports whether the access token with the given claims was revoked, treating a failed lookup as revoked.
+func isRevoked(claims *models.Claims, options *AuthOptions) bool {
+	if options.RevokedTokens == nil || claims.ID == "" {
+		return false
+	}
+	revoked, err := options.RevokedTokens.IsRevoked(claims.ID)
+	return err != nil || revoked
+}
+
+// withClaims stores the authenticated user's ID and role, and the impersonator's ID for impersonation tokens, in ctx.
+// The user ID is also recorded for RecoveryMiddleware, which cannot see the returned context.
+func withClaims(ctx context.Context, claims *models.Claims) context.Context {
+	recordUserID(ctx, claims.UserId)
+	contextWithUser := context.WithValue(ctx, userIDContextKey, claims.UserId)
+	contextWithUser = context.WithValue(contextWithUser, roleContextKey, claims.Role)
+	if claims.ImpersonatedBy > 0 {
+		contextWithUser = context.WithValue(contextWithUser, impersonatedByContextKey, claims.ImpersonatedBy)
+	}
+	return contextWithUser
+}
+
+// refreshSession redeems the refresh cookie of the request for a new access token and refresh token, and sets both cookies on the response.
+// It returns the claims of the new access token, or nil when refresh tokens are disabled or the request has no valid refresh token; a rejected refresh cookie is cleared.
+func refreshSession(w http.ResponseWriter, r *http.Request, options *AuthOptions) *models.Claims {
+	if !options.RefreshTokens.Enabled() {
+		return nil
+	}
+	cookie, err := r.Cookie(options.RefreshTokens.CookieName)
+	if err != nil || cookie.Value == "" {
+		return nil
+	}
+
+	accessToken, refreshToken, err := securityAuth.RefreshToken(cookie.Value)
+	if err != nil {
+		cookies.ClearCookie(w, options.RefreshTokens.CookieName)
+		return nil
+	}
+	claims, err := securityAuth.ParseTokenWithClaims(accessToken)
+	if err != nil {
+		return nil
+	}
+
+	cookies.SetAuthCookie(w, options.CookieName, accessToken, options.SecureCookies)
+	cookies.SetRefreshCookie(w, options.RefreshTokens.CookieName, refreshToken, options.RefreshTokens.TTL, options.SecureCookies)
+	return claims
 }
\ No newline at end of file
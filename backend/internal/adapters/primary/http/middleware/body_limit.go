@@ -0,0 +1,36 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains a middleware that bounds the size of request bodies, so a single request cannot exhaust the server's memory.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// DefaultMaxBodyBytes is the request body limit applied by DefaultBodyLimitMiddleware.
+const DefaultMaxBodyBytes = 1 << 20 // 1 MB
+
+// BodyLimitMiddleware returns a middleware that limits request bodies to maxBytes.
+
+// Requests declaring a Content-Length above maxBytes are answered with 413 Payload Too Large before the next handler runs. Other bodies, including chunked ones of unknown length, are wrapped with http.MaxBytesReader, so a handler reading past the limit gets an *http.MaxBytesError from its decoder instead of more data.
+func BodyLimitMiddleware(maxBytes int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				httpUtil.HandleError(w, errors.NewPayloadTooLargeError(errors.ErrRequestBodyTooLarge))
+				return
+			}
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// DefaultBodyLimitMiddleware is a convenience function that applies BodyLimitMiddleware with DefaultMaxBodyBytes.
+func DefaultBodyLimitMiddleware(next http.Handler) http.Handler {
+	return BodyLimitMiddleware(DefaultMaxBodyBytes)(next)
+}
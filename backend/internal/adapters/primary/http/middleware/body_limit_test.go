@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		chunked       bool
+		wantStatus    int
+		wantReadError bool
+	}{
+		{name: "within the limit", body: "0123456789", wantStatus: http.StatusOK},
+		{name: "declared length over the limit", body: "0123456789X", wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "chunked body over the limit", body: "0123456789X", chunked: true, wantStatus: http.StatusOK, wantReadError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlerRan := false
+			var readErr error
+			handler := BodyLimitMiddleware(10)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerRan = true
+				_, readErr = io.ReadAll(r.Body)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/comments/newComments", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Status Expected: %d, Got: %d", tt.wantStatus, rec.Code)
+			}
+			if handlerRan == (tt.wantStatus == http.StatusRequestEntityTooLarge) {
+				t.Errorf("Handler ran: %v", handlerRan)
+			}
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(readErr, &maxBytesErr) != tt.wantReadError {
+				t.Errorf("Read error Expected a MaxBytesError: %v, Got: %v", tt.wantReadError, readErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains a middleware that buffers small responses so they are sent with a Content-Length header instead of chunked encoding.
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+)
+
+// contentLengthWriter buffers the status and body written by a handler until either the handler returns or the body outgrows maxBuffer.
+// Once it switches to streaming, headers are sent and every further write goes straight to the underlying writer.
+type contentLengthWriter struct {
+	http.ResponseWriter
+	maxBuffer int64
+	buffer    bytes.Buffer
+	status    int
+	streaming bool
+}
+
+// WriteHeader records the status code; it is sent when the response is flushed.
+func (w *contentLengthWriter) WriteHeader(code int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+// Write buffers b, switching to streaming when the buffered body would exceed maxBuffer.
+func (w *contentLengthWriter) Write(b []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+	if int64(w.buffer.Len()+len(b)) > w.maxBuffer {
+		if err := w.startStreaming(); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buffer.Write(b)
+}
+
+// Flush implements http.Flusher. A handler that flushes wants its output delivered immediately, so the writer switches to streaming.
+func (w *contentLengthWriter) Flush() {
+	if !w.streaming {
+		w.startStreaming()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// startStreaming sends the recorded status and the buffered body without a Content-Length header.
+func (w *contentLengthWriter) startStreaming() error {
+	w.streaming = true
+	w.ResponseWriter.WriteHeader(w.statusCode())
+	_, err := w.ResponseWriter.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+	return err
+}
+
+// finish sends a buffered response with its Content-Length header. It does nothing if the writer already switched to streaming.
+func (w *contentLengthWriter) finish() {
+	if w.streaming {
+		return
+	}
+
+	status := w.statusCode()
+	if bodyAllowed(status) && w.Header().Get("Content-Length") == "" {
+		w.Header().Set("Content-Length", strconv.Itoa(w.buffer.Len()))
+	}
+	w.ResponseWriter.WriteHeader(status)
+	w.ResponseWriter.Write(w.buffer.Bytes())
+}
+
+// statusCode returns the recorded status, defaulting to 200 (OK) like net/http.
+func (w *contentLengthWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// bodyAllowed reports whether a response with the given status may carry a body, and therefore a Content-Length header.
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// ContentLengthMiddleware returns a middleware that buffers responses of up to maxBuffer bytes and sends them with a Content-Length header.
+
+// Larger responses, and responses whose handler calls Flush, are streamed as usual without Content-Length once they outgrow the buffer. It is meant for JSON API routes; static files already get a Content-Length from http.FileServer.
+func ContentLengthMiddleware(maxBuffer int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writer := &contentLengthWriter{ResponseWriter: w, maxBuffer: maxBuffer}
+			next.ServeHTTP(writer, r)
+			writer.finish()
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+)
+
+func TestContentLengthMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		status        int
+		contentLength string
+	}{
+		{"small body is buffered", `{"message":"ok"}`, http.StatusCreated, "16"},
+		{"large body is streamed", strings.Repeat("x", 64), http.StatusOK, ""},
+		{"no content has no length", "", http.StatusNoContent, ""},
+	}
+
+	for _, tt := range tests {
+		handler := middleware.ContentLengthMiddleware(32)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			for _, chunk := range strings.SplitAfter(tt.body, "x") {
+				w.Write([]byte(chunk))
+			}
+		}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/comments", nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: Expected status %d, Got %d", tt.name, tt.status, rec.Code)
+		}
+		if got := rec.Header().Get("Content-Length"); got != tt.contentLength {
+			t.Errorf("%s: Expected Content-Length %q, Got %q", tt.name, tt.contentLength, got)
+		}
+		if rec.Body.String() != tt.body {
+			t.Errorf("%s: Expected body %q, Got %q", tt.name, tt.body, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains a content negotiation middleware that resolves the preferred response media type from the Accept header.
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// ContentNegotiationMiddleware returns a middleware that stores the media type preferred by the client (JSON, CSV or plain text) in the request context.
+
+// Handlers that answer with httpUtil.SendResponse use it to pick the response format. Vary: Accept is added so caches keep one copy per format.
+func ContentNegotiationMiddleware() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mediaType := httpUtil.NegotiateMediaType(r.Header.Get("Accept"))
+			if !varyContains(w.Header(), "Accept") {
+				w.Header().Add("Vary", "Accept")
+			}
+			next.ServeHTTP(w, r.WithContext(httpUtil.WithMediaType(r.Context(), mediaType)))
+		})
+	}
+}
+
+// varyContains reports whether the Vary header already lists the given request header.
+func varyContains(header http.Header, name string) bool {
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
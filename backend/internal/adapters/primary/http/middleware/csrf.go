@@ -0,0 +1,185 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains the signed double-submit cookie CSRF protection: token generation, the CSRF cookie and a middleware that issues tokens on safe requests and validates them on mutating ones.
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/http/cookies"
+)
+
+const (
+	// CSRFCookieName is the default name of the HttpOnly cookie holding the CSRF token.
+	CSRFCookieName = "csrf_token"
+
+	// CSRFHeaderName is the default header carrying the CSRF token: clients echo it on mutating requests, and CSRFMiddleware sets it on responses to safe requests.
+	CSRFHeaderName = "X-CSRF-Token"
+
+	// csrfTokenBytes is the number of random bytes in a CSRF token.
+	csrfTokenBytes = 32
+
+	// csrfCookieMaxAge matches the lifetime of the authentication cookie.
+	csrfCookieMaxAge = 12 * time.Hour
+)
+
+// GenerateCSRFToken returns a new CSRF token signed with secret.
+// The token is a random nonce and its HMAC-SHA256 signature, both unpadded base64url and joined by a dot.
+func GenerateCSRFToken(secret []byte) (string, error) {
+	nonce := make([]byte, csrfTokenBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	encodedNonce := base64.RawURLEncoding.EncodeToString(nonce)
+	return encodedNonce + "." + signCSRFNonce(secret, encodedNonce), nil
+}
+
+// ValidateCSRFToken reports whether token was generated by GenerateCSRFToken with secret.
+func ValidateCSRFToken(secret []byte, token string) bool {
+	encodedNonce, signature, found := strings.Cut(token, ".")
+	if !found || encodedNonce == "" {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(signCSRFNonce(secret, encodedNonce)))
+}
+
+// signCSRFNonce returns the base64url HMAC-SHA256 of encodedNonce under secret.
+func signCSRFNonce(secret []byte, encodedNonce string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encodedNonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SetCSRFCookie stores token in the CSRF cookie named CSRFCookieName.
+// The cookie is HttpOnly and SameSite=Strict, so clients obtain the token value from the X-CSRF-Token response header or the response body of GET /auth/csrf-token instead of reading the cookie. It is marked Secure in production.
+func SetCSRFCookie(w http.ResponseWriter, token string, isProduction bool) {
+	setCSRFCookie(w, CSRFCookieName, token, isProduction)
+}
+
+// setCSRFCookie implements SetCSRFCookie for any cookie name.
+func setCSRFCookie(w http.ResponseWriter, name, token string, secure bool) {
+	cookies.SetCookie(w, cookies.NewCookieConfig(
+		name,
+		cookies.WithValue(token),
+		cookies.WithMaxAge(csrfCookieMaxAge),
+		cookies.WithHttpOnly(true),
+		cookies.WithSameSite(http.SameSiteStrictMode),
+		cookies.WithSecure(secure),
+	))
+}
+
+// DefaultCSRFExcludedPaths returns the paths CSRFMiddleware skips by default: the token endpoint and the static file prefixes, which only serve safe requests.
+// Unlike AuthOptions.ExcludedPaths it does not include POST /login or POST /register, so a cross-site form cannot sign a victim in to an attacker's account; clients fetch a token from GET /auth/csrf-token before logging in.
+func DefaultCSRFExcludedPaths() []string {
+	return []string{
+		"/auth/csrf-token",
+		"/css/",
+		"/js/",
+		"/assets/",
+	}
+}
+
+// CSRFOption defines functional options for CSRFMiddleware.
+type CSRFOption func(*csrfOptions)
+
+// csrfOptions holds the settings of CSRFMiddleware.
+type csrfOptions struct {
+	cookieName    string
+	headerName    string
+	excludedPaths []string
+	secureCookie  bool
+}
+
+// WithCSRFCookieName replaces the default CSRFCookieName.
+func WithCSRFCookieName(name string) CSRFOption {
+	return func(o *csrfOptions) {
+		o.cookieName = name
+	}
+}
+
+// WithCSRFHeaderName replaces the default CSRFHeaderName.
+func WithCSRFHeaderName(name string) CSRFOption {
+	return func(o *csrfOptions) {
+		o.headerName = name
+	}
+}
+
+// WithCSRFExcludedPaths skips token validation for the given paths, matched like AuthOptions.ExcludedPaths (exact paths, or prefixes ending with '/').
+func WithCSRFExcludedPaths(paths []string) CSRFOption {
+	return func(o *csrfOptions) {
+		o.excludedPaths = paths
+	}
+}
+
+// WithCSRFSecureCookie marks the CSRF cookie issued by the middleware as HTTPS only.
+func WithCSRFSecureCookie(secure bool) CSRFOption {
+	return func(o *csrfOptions) {
+		o.secureCookie = secure
+	}
+}
+
+// CSRFMiddleware returns a middleware that enforces the signed double-submit cookie pattern with tokens signed by secret.
+
+// Safe methods (GET, HEAD, OPTIONS, TRACE) are never rejected: when the request carries no validly signed CSRF cookie, a new token is stored in it, and the current token is returned in the CSRF response header.
+// For any other method, outside the excluded paths, the token in the CSRF request header must be validly signed and equal to the value of the CSRF cookie; otherwise the request is rejected with 403 Forbidden. The comparison runs in constant time.
+func CSRFMiddleware(secret []byte, options ...CSRFOption) Middleware {
+	opts := csrfOptions{
+		cookieName: CSRFCookieName,
+		headerName: CSRFHeaderName,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(opts.cookieName)
+			hasValidCookie := err == nil && ValidateCSRFToken(secret, cookie.Value)
+
+			if isSafeMethod(r.Method) {
+				token := ""
+				if hasValidCookie {
+					token = cookie.Value
+				} else if token, err = GenerateCSRFToken(secret); err != nil {
+					httpUtil.WriteError(w, errors.NewInternalError(errors.ErrCSRFTokenGeneration).WithError(err))
+					return
+				} else {
+					setCSRFCookie(w, opts.cookieName, token, opts.secureCookie)
+				}
+				w.Header().Set(opts.headerName, token)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if matchesPath(r.URL.Path, opts.excludedPaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			headerToken := r.Header.Get(opts.headerName)
+			if !hasValidCookie || headerToken == "" ||
+				subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(headerToken)) != 1 {
+				httpUtil.HandleError(w, errors.NewForbiddenError(errors.ErrInvalidCSRFToken))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isSafeMethod reports whether method is one of the RFC 9110 safe methods, which must not change server state.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
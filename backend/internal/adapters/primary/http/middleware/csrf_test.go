@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var testCSRFSecret = []byte("test-csrf-secret")
+
+func TestCSRFMiddleware(t *testing.T) {
+	handler := CSRFMiddleware(testCSRFSecret, WithCSRFExcludedPaths([]string{"/login", "/assets/"}))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+	valid, err := GenerateCSRFToken(testCSRFSecret)
+	if err != nil {
+		t.Fatalf("GenerateCSRFToken() error = %v", err)
+	}
+	other, _ := GenerateCSRFToken(testCSRFSecret)
+	forged, _ := GenerateCSRFToken([]byte("another-secret"))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		cookie string
+		header string
+		want   int
+	}{
+		{"safe method without token", http.MethodGet, "/comments", "", "", http.StatusNoContent},
+		{"matching token", http.MethodPost, "/comments/newComments", valid, valid, http.StatusNoContent},
+		{"missing cookie", http.MethodPost, "/comments/newComments", "", valid, http.StatusForbidden},
+		{"missing header", http.MethodPatch, "/auth/profile", valid, "", http.StatusForbidden},
+		{"mismatched token", http.MethodDelete, "/comments/1", valid, other, http.StatusForbidden},
+		{"token signed with another secret", http.MethodPut, "/comments/1", forged, forged, http.StatusForbidden},
+		{"unsigned token", http.MethodPost, "/comments/newComments", "abc", "abc", http.StatusForbidden},
+		{"excluded path", http.MethodPost, "/login", "", "", http.StatusNoContent},
+		{"excluded prefix", http.MethodPost, "/assets/upload", "", "", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				req.Header.Set(CSRFHeaderName, tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultCSRFExcludedPathsProtectLoginAndRegister(t *testing.T) {
+	handler := CSRFMiddleware(testCSRFSecret, WithCSRFExcludedPaths(DefaultCSRFExcludedPaths()))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+	for _, path := range []string{"/login", "/register"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("POST %s without a token: status = %d, want %d", path, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestCSRFMiddlewareIssuesTokenOnSafeRequests(t *testing.T) {
+	handler := CSRFMiddleware(testCSRFSecret, WithCSRFCookieName("xsrf"), WithCSRFHeaderName("X-XSRF-Token"))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	token := rec.Header().Get("X-XSRF-Token")
+	if !ValidateCSRFToken(testCSRFSecret, token) {
+		t.Fatalf("header token %q is not validly signed", token)
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "xsrf" || cookies[0].Value != token || !cookies[0].HttpOnly {
+		t.Fatalf("cookies = %+v, want HttpOnly xsrf=%s", cookies, token)
+	}
+
+	// A request that already carries a valid token keeps it.
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "xsrf", Value: token})
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get("X-XSRF-Token"); got != token || len(rec.Result().Cookies()) != 0 {
+		t.Errorf("header = %q, cookies = %+v, want the existing token and no new cookie", got, rec.Result().Cookies())
+	}
+}
+
+func TestSetCSRFCookieIsHttpOnly(t *testing.T) {
+	token, err := GenerateCSRFToken(testCSRFSecret)
+	if err != nil {
+		t.Fatalf("GenerateCSRFToken() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	SetCSRFCookie(rec, token, true)
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("got %d cookies, want 1", len(cookies))
+	}
+	c := cookies[0]
+	if c.Name != CSRFCookieName || c.Value != token || !c.HttpOnly || !c.Secure {
+		t.Errorf("cookie = %+v, want HttpOnly Secure %s=%s", c, CSRFCookieName, token)
+	}
+}
@@ -0,0 +1,185 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains a deduplication middleware that collapses identical concurrent GET requests into a single handler call and briefly caches the result.
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// dedupResponse is a response recorded by DeduplicationMiddleware and replayed to identical requests.
+type dedupResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	expiresAt  time.Time
+}
+
+// DeduplicationMiddleware returns a middleware that serves identical GET and HEAD requests from a single handler call.
+
+// Requests are identified by a SHA-256 hash of the method, path, sorted query parameters and Accept header. While a response for a key is being computed, identical requests wait for it instead of calling the handler, which prevents a thundering herd on the database when a cache expires. Successful (200 OK) responses are then replayed for ttl. Only the headers set by the wrapped handler are replayed; headers set by outer middleware (request IDs, CORS) are produced per request as usual.
+// Responses are shared between clients, so it must only wrap public endpoints whose output does not depend on the caller. A non-positive ttl disables the middleware.
+func DeduplicationMiddleware(ttl time.Duration) Middleware {
+	if ttl <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	var (
+		mu     sync.Mutex
+		cache  = make(map[string]*dedupResponse)
+		flight flightGroup
+	)
+
+	lookup := func(key string, now time.Time) *dedupResponse {
+		mu.Lock()
+		defer mu.Unlock()
+		resp, ok := cache[key]
+		if !ok {
+			return nil
+		}
+		if now.After(resp.expiresAt) {
+			delete(cache, key)
+			return nil
+		}
+		return resp
+	}
+
+	store := func(key string, resp *dedupResponse, now time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		for k, cached := range cache {
+			if now.After(cached.expiresAt) {
+				delete(cache, k)
+			}
+		}
+		cache[key] = resp
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := deduplicationKey(r)
+			if resp := lookup(key, time.Now()); resp != nil {
+				writeDedupResponse(w, resp)
+				return
+			}
+
+			resp, leader := flight.do(key, func() *dedupResponse {
+				before := w.Header().Clone()
+				capture := NewResponseCapture(w)
+				next.ServeHTTP(capture, r)
+
+				resp := &dedupResponse{
+					statusCode: capture.StatusCode(),
+					header:     addedHeaders(before, w.Header()),
+					body:       append([]byte(nil), capture.Body()...),
+					expiresAt:  time.Now().Add(ttl),
+				}
+				if resp.statusCode == http.StatusOK {
+					store(key, resp, time.Now())
+				}
+				return resp
+			})
+			if leader {
+				return
+			}
+
+			// The leader failed to produce a response (it panicked); handle this request independently.
+			if resp == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			writeDedupResponse(w, resp)
+		})
+	}
+}
+
+// deduplicationKey returns the hex SHA-256 of the request method, path, query parameters sorted by key and Accept header.
+// The Accept header is part of the key because handlers negotiate the response format from it.
+func deduplicationKey(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.Method + "\n" + r.URL.Path + "\n" + r.URL.Query().Encode() + "\n" + r.Header.Get("Accept")))
+	return hex.EncodeToString(sum[:])
+}
+
+// addedHeaders returns the headers of after that are missing from, or differ in, before.
+func addedHeaders(before, after http.Header) http.Header {
+	added := make(http.Header)
+	for name, values := range after {
+		if previous, ok := before[name]; ok && equalValues(previous, values) {
+			continue
+		}
+		added[name] = append([]string(nil), values...)
+	}
+	return added
+}
+
+// equalValues reports whether two header value lists are identical.
+func equalValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// writeDedupResponse replays a recorded response on w.
+func writeDedupResponse(w http.ResponseWriter, resp *dedupResponse) {
+	for name, values := range resp.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(resp.statusCode)
+	w.Write(resp.body)
+}
+
+// flightCall is an in-progress or completed flightGroup.do call.
+type flightCall struct {
+	wg  sync.WaitGroup
+	res *dedupResponse
+}
+
+// flightGroup suppresses duplicate concurrent calls for the same key, in the manner of golang.org/x/sync/singleflight.
+// The zero value is ready to use.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do executes fn for key unless a call for key is already in flight, in which case it waits for that call and returns its result.
+// leader is true for the caller that actually ran fn. If fn panics, waiting callers receive a nil result and the panic propagates to the leader.
+func (g *flightGroup) do(key string, fn func() *dedupResponse) (res *dedupResponse, leader bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.res, false
+	}
+
+	call := &flightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		call.wg.Done()
+	}()
+
+	call.res = fn()
+	return call.res, true
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDeduplicationMiddlewareCollapsesIdenticalRequests(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	handler := DeduplicationMiddleware(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[]}`))
+	}))
+
+	const clients = 10
+	recorders := make([]*httptest.ResponseRecorder, clients)
+	var wg sync.WaitGroup
+	for i := range recorders {
+		recorders[i] = httptest.NewRecorder()
+		wg.Add(1)
+		go func(rec *httptest.ResponseRecorder) {
+			defer wg.Done()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/comments?per_page=20&page=1", nil))
+		}(recorders[i])
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("handler called %d times, want 1", got)
+	}
+	for i, rec := range recorders {
+		if rec.Code != http.StatusOK || rec.Body.String() != `{"items":[]}` || rec.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("client %d got %d %q (Content-Type %q)", i, rec.Code, rec.Body.String(), rec.Header().Get("Content-Type"))
+		}
+	}
+
+	// Query parameters in a different order share the cached response.
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/comments?page=1&per_page=20", nil))
+	if got := calls.Load(); got != 1 {
+		t.Errorf("handler called %d times after reordered query, want 1", got)
+	}
+}
+
+func TestDeduplicationMiddlewareSeparatesAcceptHeaders(t *testing.T) {
+	var calls atomic.Int32
+	handler := DeduplicationMiddleware(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(r.Header.Get("Accept")))
+	}))
+
+	for _, accept := range []string{"application/json", "text/csv"} {
+		req := httptest.NewRequest(http.MethodGet, "/comments", nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Body.String() != accept {
+			t.Errorf("Accept %s: got body %q", accept, rec.Body.String())
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("handler called %d times, want 2", got)
+	}
+}
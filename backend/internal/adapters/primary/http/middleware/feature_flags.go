@@ -0,0 +1,40 @@
+// Package middleware provides HTTP middleware utilities as part of the application's infrastructure layer.
+// This file contains the feature-flag kill switch that lets operators disable individual endpoints during an incident.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// RouteFlags reports whether a route, identified by its path template, has been disabled by configuration.
+// *config.AppConfig satisfies it.
+type RouteFlags interface {
+	IsRouteDisabled(path string) bool
+}
+
+// RouteDisabledHandler returns a handler that always responds with 503 Service Unavailable and {"error": "This endpoint is temporarily unavailable"}.
+// It replaces the handler of a route that is disabled at startup.
+func RouteDisabledHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpUtil.SendJSONResponse(w, http.StatusServiceUnavailable, map[string]string{"error": errors.ErrRouteDisabled})
+	})
+}
+
+// RouteDisabledMiddleware checks flags on every request and answers with RouteDisabledHandler while path is disabled.
+
+// It is used when configuration hot reload is enabled, so that disabling or re-enabling a route takes effect without a restart.
+func RouteDisabledMiddleware(path string, flags RouteFlags) Middleware {
+	disabled := RouteDisabledHandler()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if flags.IsRouteDisabled(path) {
+				disabled.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains a geographic filtering middleware that blocks or allows requests based on the country resolved from the client IP address.
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	ratelimiter "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/rate_limiter"
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// geoRecord is the subset of a GeoLite2 country record needed to resolve the ISO country code.
+type geoRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+// GeoFilterConfig holds the settings used by GeoFilterMiddleware.
+type GeoFilterConfig struct {
+	// Blocklist contains ISO 3166-1 alpha-2 codes that are rejected.
+	Blocklist map[string]bool
+	// Allowlist, when not empty, contains the only ISO codes that are accepted. It takes precedence over Blocklist.
+	Allowlist map[string]bool
+	// IPExtractor resolves the client IP from the request's remote address.
+	IPExtractor ratelimiter.IPExtractor
+}
+
+// GeoFilterOption defines functional options for modifying a GeoFilterConfig.
+type GeoFilterOption func(*GeoFilterConfig)
+
+// WithCountryAllowlist switches the middleware to allowlist mode: only requests from the given countries are accepted.
+// Requests whose country cannot be resolved (e.g. private or loopback addresses) are rejected in this mode.
+func WithCountryAllowlist(allowlist []string) GeoFilterOption {
+	return func(c *GeoFilterConfig) {
+		c.Allowlist = toCountrySet(allowlist)
+	}
+}
+
+// WithGeoIPExtractor overrides the IPExtractor used to obtain the client IP.
+func WithGeoIPExtractor(ipExtractor ratelimiter.IPExtractor) GeoFilterOption {
+	return func(c *GeoFilterConfig) {
+		c.IPExtractor = ipExtractor
+	}
+}
+
+// GeoFilterMiddleware returns a middleware that rejects requests coming from blocked countries with 403 Forbidden.
+
+// The client IP is extracted with the configured IPExtractor and looked up in the GeoLite2 database. If db is nil (the database is not configured), the middleware is a no-op.
+// In blocklist mode, requests whose country cannot be resolved are allowed through.
+func GeoFilterMiddleware(db *maxminddb.Reader, blocklist []string, options ...GeoFilterOption) Middleware {
+	config := &GeoFilterConfig{
+		Blocklist:   toCountrySet(blocklist),
+		IPExtractor: &ratelimiter.DefaultIPExtractor{},
+	}
+	for _, option := range options {
+		option(config)
+	}
+
+	return func(next http.Handler) http.Handler {
+		if db == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			countryCode := lookupCountry(db, config.IPExtractor.Extract(r.RemoteAddr))
+
+			if !isCountryAllowed(config, countryCode) {
+				httpUtil.HandleError(w, errors.NewForbiddenError(errors.ErrRegionNotAvailable))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// lookupCountry returns the upper-case ISO country code for the given IP, or an empty string if it cannot be resolved.
+func lookupCountry(db *maxminddb.Reader, clientIP string) string {
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return ""
+	}
+
+	var record geoRecord
+	if err := db.Lookup(ip, &record); err != nil {
+		return ""
+	}
+	return strings.ToUpper(record.Country.ISOCode)
+}
+
+// isCountryAllowed applies the allowlist (when configured) or the blocklist to a resolved country code.
+func isCountryAllowed(config *GeoFilterConfig, countryCode string) bool {
+	if len(config.Allowlist) > 0 {
+		return config.Allowlist[countryCode]
+	}
+	return !config.Blocklist[countryCode]
+}
+
+// toCountrySet normalises a list of ISO codes into an upper-case lookup set.
+func toCountrySet(countries []string) map[string]bool {
+	set := make(map[string]bool, len(countries))
+	for _, country := range countries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if country != "" {
+			set[country] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,84 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains the middlewares used when TLS is enabled: a redirect from plain HTTP to HTTPS and the Strict-Transport-Security (HSTS) header.
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HSTSConfig defines the Strict-Transport-Security policy sent on HTTPS responses.
+type HSTSConfig struct {
+	// MaxAge is how long browsers must only use HTTPS for the host
+	MaxAge time.Duration
+	// IncludeSubDomains extends the policy to every subdomain
+	IncludeSubDomains bool
+	// Preload allows the host to be added to the browsers' HSTS preload lists
+	Preload bool
+}
+
+// DefaultHSTSConfig returns a one-year policy covering subdomains and eligible for preloading.
+func DefaultHSTSConfig() *HSTSConfig {
+	return &HSTSConfig{
+		MaxAge:            365 * 24 * time.Hour,
+		IncludeSubDomains: true,
+		Preload:           true,
+	}
+}
+
+// HeaderValue renders the policy as a Strict-Transport-Security header value, e.g. "max-age=31536000; includeSubDomains; preload".
+func (c *HSTSConfig) HeaderValue() string {
+	value := fmt.Sprintf("max-age=%d", int(c.MaxAge.Seconds()))
+	if c.IncludeSubDomains {
+		value += "; includeSubDomains"
+	}
+	if c.Preload {
+		value += "; preload"
+	}
+	return value
+}
+
+// isHTTPSRequest reports whether the request reached the application over HTTPS, either directly or through a TLS-terminating proxy.
+func isHTTPSRequest(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// HTTPSRedirectMiddleware returns a middleware that answers plain HTTP requests with 301 Moved Permanently to the same host, path and query over HTTPS on httpsPort.
+// Requests already served over HTTPS (directly or as reported by X-Forwarded-Proto) are passed to the next handler. The port is omitted from the redirect URL when it is the default 443.
+func HTTPSRedirectMiddleware(httpsPort string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isHTTPSRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			host := r.Host
+			if hostname, _, err := net.SplitHostPort(r.Host); err == nil {
+				host = hostname
+			}
+			if httpsPort != "" && httpsPort != "443" {
+				host = net.JoinHostPort(host, httpsPort)
+			}
+
+			http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+		})
+	}
+}
+
+// HSTSMiddleware returns a middleware that adds the Strict-Transport-Security header to responses served over HTTPS.
+// Browsers ignore the header on plain HTTP, so it is not sent there.
+func HSTSMiddleware(config *HSTSConfig) Middleware {
+	headerValue := config.HeaderValue()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isHTTPSRequest(r) {
+				w.Header().Set("Strict-Transport-Security", headerValue)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
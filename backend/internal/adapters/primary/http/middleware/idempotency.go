@@ -0,0 +1,99 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains an idempotency middleware that replays the stored response of a POST request retried with the same X-Idempotency-Key header.
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// IdempotencyKeyHeader is the request header carrying the client-generated idempotency key.
+const IdempotencyKeyHeader = "X-Idempotency-Key"
+
+// idempotencyTTL is how long a stored response is replayed for retries of the same key.
+const idempotencyTTL = 24 * time.Hour
+
+// ResponseCapture is an http.ResponseWriter that forwards the response to the client while recording its status code and body.
+type ResponseCapture struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+// NewResponseCapture creates a ResponseCapture wrapping w, with a default status code of http.StatusOK (200).
+func NewResponseCapture(w http.ResponseWriter) *ResponseCapture {
+	return &ResponseCapture{ResponseWriter: w, statusCode: http.StatusOK}
+}
+
+// WriteHeader records the status code and delegates to the wrapped writer.
+func (rc *ResponseCapture) WriteHeader(code int) {
+	rc.statusCode = code
+	rc.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the body bytes and delegates to the wrapped writer.
+func (rc *ResponseCapture) Write(b []byte) (int, error) {
+	rc.body.Write(b)
+	return rc.ResponseWriter.Write(b)
+}
+
+// StatusCode returns the captured status code.
+func (rc *ResponseCapture) StatusCode() int {
+	return rc.statusCode
+}
+
+// Body returns the captured response body.
+func (rc *ResponseCapture) Body() []byte {
+	return rc.body.Bytes()
+}
+
+// IdempotencyMiddleware returns a middleware that makes POST requests carrying an X-Idempotency-Key header safe to retry.
+
+// If a response was already stored for the key, it is replayed and the handler is not called. Otherwise the handler runs, its response is captured and stored for 24 hours.
+// Keys are scoped to the authenticated user, so it must run after AuthMiddleware. Server errors (5xx) are not stored, allowing the client to retry them.
+// Requests with other methods or without the header pass through unchanged.
+func IdempotencyMiddleware(repo output.IdempotencyRepository) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientKey := r.Header.Get(IdempotencyKeyHeader)
+			if r.Method != http.MethodPost || clientKey == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID, _ := r.Context().Value(userIDContextKey).(int)
+			key := fmt.Sprintf("%d:%s:%s", userID, r.URL.Path, clientKey)
+
+			record, err := repo.Check(key)
+			if err != nil {
+				httpUtil.HandleError(w, err)
+				return
+			}
+
+			if record != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(record.StatusCode)
+				w.Write(record.Body)
+				return
+			}
+
+			capture := NewResponseCapture(w)
+			next.ServeHTTP(capture, r)
+
+			if capture.StatusCode() >= http.StatusInternalServerError {
+				return
+			}
+			if err := repo.Store(key, capture.StatusCode(), capture.Body(), idempotencyTTL); err != nil {
+				slog.Error("could not store idempotent response",
+					slog.String("idempotency_key", clientKey), slog.String("path", r.URL.Path), slog.Any("error", err))
+			}
+		})
+	}
+}
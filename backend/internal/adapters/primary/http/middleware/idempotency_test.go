@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+)
+
+// memoryIdempotencyRepository stores records in a map and never expires them.
+type memoryIdempotencyRepository struct {
+	records map[string]models.IdempotencyRecord
+}
+
+func (r *memoryIdempotencyRepository) Check(key string) (*models.IdempotencyRecord, error) {
+	record, ok := r.records[key]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func (r *memoryIdempotencyRepository) Store(key string, statusCode int, body []byte, ttl time.Duration) error {
+	r.records[key] = models.IdempotencyRecord{Key: key, StatusCode: statusCode, Body: body, ExpiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+func newIdempotentPost(key string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/comments/newComments", nil)
+	req.Header.Set(IdempotencyKeyHeader, key)
+	return req
+}
+
+func TestIdempotencyMiddlewareReplaysStoredResponse(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[string]models.IdempotencyRecord{}}
+	var calls atomic.Int32
+	handler := IdempotencyMiddleware(repo)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"message":"Comment added"}`))
+	}))
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, newIdempotentPost("retry-1"))
+	retry := httptest.NewRecorder()
+	handler.ServeHTTP(retry, newIdempotentPost("retry-1"))
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("handler called %d times, want 1", got)
+	}
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("retry got %d %q, want %d %q", retry.Code, retry.Body.String(), first.Code, first.Body.String())
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected the retry to carry Idempotent-Replayed: true")
+	}
+
+	// A different key is processed again.
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentPost("retry-2"))
+	if got := calls.Load(); got != 2 {
+		t.Errorf("handler called %d times after a new key, want 2", got)
+	}
+}
+
+func TestIdempotencyMiddlewareDoesNotStoreServerErrors(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[string]models.IdempotencyRecord{}}
+	var calls atomic.Int32
+	handler := IdempotencyMiddleware(repo)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentPost("retry-1"))
+	if len(repo.records) != 0 {
+		t.Fatalf("Expected a 5xx response not to be stored, Got %d records", len(repo.records))
+	}
+
+	retry := httptest.NewRecorder()
+	handler.ServeHTTP(retry, newIdempotentPost("retry-1"))
+	if got := calls.Load(); got != 2 || retry.Code != http.StatusCreated {
+		t.Errorf("Expected the retry to reach the handler and get 201, Got %d calls and %d", got, retry.Code)
+	}
+}
@@ -0,0 +1,27 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains a middleware that restricts a route to requests coming from the local machine.
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	ratelimiter "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/rate_limiter"
+)
+
+// LocalhostOnlyMiddleware returns a middleware that rejects with 403 Forbidden every request whose client IP is not a loopback address.
+// The IP is taken from the connection's remote address, so forwarded headers cannot be used to bypass it.
+func LocalhostOnlyMiddleware(ipExtractor ratelimiter.IPExtractor) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := net.ParseIP(ipExtractor.Extract(r.RemoteAddr))
+			if ip == nil || !ip.IsLoopback() {
+				httpUtil.HandleError(w, errors.NewForbiddenError(errors.ErrForbidden))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/tracing"
+)
+
+func TestNewLoggingMiddlewareEmitsStructuredFields(t *testing.T) {
+	var buf bytes.Buffer
+	handler := Chain(
+		RequestIDMiddleware(),
+		NewLoggingMiddleware(slog.New(slog.NewJSONHandler(&buf, nil))),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	tc := tracing.NewTraceContext()
+	req := httptest.NewRequest(http.MethodGet, "/comments/7", nil)
+	req.Header.Set("traceparent", tc.Traceparent())
+	req.Header.Set(RequestIDHeader, "req-42")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("log output is not a JSON record: %v (%s)", err, buf.String())
+	}
+	expected := map[string]any{
+		"level":      "ERROR",
+		"method":     http.MethodGet,
+		"path":       "/comments/7",
+		"status":     float64(http.StatusNotFound),
+		"request_id": "req-42",
+		"trace_id":   tc.TraceID,
+	}
+	for key, want := range expected {
+		if record[key] != want {
+			t.Errorf("%s Expected: %v, Got: %v", key, want, record[key])
+		}
+	}
+	if _, ok := record["duration_ms"].(float64); !ok {
+		t.Errorf("duration_ms Expected: a number, Got: %v", record["duration_ms"])
+	}
+}
+
+func TestTimingMiddlewareWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	config := DefaultTimingConfig().WithLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	config.WarningThreshold = -1 // every request is slow
+
+	handler := TimingMiddleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/comments", nil))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("log output is not a JSON record: %v (%s)", err, buf.String())
+	}
+	if record["level"] != "WARN" || record["method"] != http.MethodPost || record["status"] != float64(http.StatusOK) {
+		t.Errorf("Unexpected timing record: %v", record)
+	}
+}
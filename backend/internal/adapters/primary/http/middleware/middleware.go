@@ -4,10 +4,13 @@
 package middleware
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/tracing"
 	"github.com/gorilla/mux"
 )
 
@@ -31,6 +34,9 @@ func Chain(middlewares ...Middleware) Middleware {
 // This manager is part of the hexagonal architecture, serving as an adapter layer that decouples middleware management from business logic.
 type MiddlewareManager struct {
 	globalMiddlewares []Middleware
+
+	// appliedToRouter is set by ApplyToRouter; from then on the router runs the global middlewares, so Apply must not add them again.
+	appliedToRouter bool
 }
 
 // NewMiddlewareManager creates a new MiddlewareManager with an empty slice of global middlewares.
@@ -49,10 +55,21 @@ func (m *MiddlewareManager) AddGlobal(middleware Middleware) {
 }
 
 // Apply wraps the given http.Handler with both the global middlewares and any additional middlewares provided as arguments.
-// This method merges the global middlewares with route-specific ones using the Chain function.
+// This method merges the global middlewares with route-specific ones using the Chain function. Once ApplyToRouter has been called, the router already runs the global middlewares, so only the route-specific ones are added.
 func (m *MiddlewareManager) Apply(handler http.Handler, middlewares ...Middleware) http.Handler {
+	if m.appliedToRouter {
+		return Chain(middlewares...)(handler)
+	}
+
 	// Combine global middlewares with the provided specific middlewares.
-	allMiddlewares := append(m.globalMiddlewares, middlewares...)
+	allMiddlewares := append(append([]Middleware{}, m.globalMiddlewares...), middlewares...)
+	return Chain(allMiddlewares...)(handler)
+}
+
+// ApplyGlobal wraps the given http.Handler with the global middlewares followed by the route-specific ones, even after ApplyToRouter has been called.
+// Use it for handlers the router runs without its middlewares, such as mux.Router.NotFoundHandler.
+func (m *MiddlewareManager) ApplyGlobal(handler http.Handler, middlewares ...Middleware) http.Handler {
+	allMiddlewares := append(append([]Middleware{}, m.globalMiddlewares...), middlewares...)
 	return Chain(allMiddlewares...)(handler)
 }
 
@@ -63,14 +80,38 @@ func (m *MiddlewareManager) ApplyToRouter(router *mux.Router) {
 		router.Use(func(next http.Handler) http.Handler {
 			return Chain(m.globalMiddlewares...)(next)
 		})
+		m.appliedToRouter = true
 	}
 }
 
-// LoggingMiddleware is an HTTP middleware that logs details about each request.
-// It records the HTTP method, request URL, response status code, and the time duration taken to process the request. If a response contains an error (status code >= 400), it logs the event as an error; otherwise, it logs it as an informational message.
-
-// In production, consider using a structured logging library instead of the standard log package.
+// LoggingMiddleware is an HTTP middleware that logs details about each request through the default slog logger.
+// It is NewLoggingMiddleware(slog.Default()); see there for the logged fields.
 func LoggingMiddleware(next http.Handler) http.Handler {
+	return logRequests(next, slog.Default(), nil)
+}
+
+// NewLoggingMiddleware returns a middleware that logs one structured record per request to logger, or to slog.Default() when logger is nil.
+// Each record carries the method, path, status, duration_ms and request_id attributes, plus the trace_id, both set by RequestIDMiddleware. Responses with a status code >= 400 are logged at error level together with the request headers; the others at info level.
+// The path and headers are taken from the ScrubbedRequest stored by SensitiveFieldScrubber, so sensitive query parameters and credentials never reach the log. Without the scrubber only the URL path is logged, and no headers.
+func NewLoggingMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next http.Handler) http.Handler {
+		return logRequests(next, logger, nil)
+	}
+}
+
+// RequestLogMiddleware returns LoggingMiddleware extended to persist a models.RequestLogRecord of every request in store, for SLA reporting.
+// Requests are recorded under their route template (e.g. "/comments"), falling back to the path when no route matched. Records are saved in the background, so a slow or failing database never delays the response; failures are only logged.
+func RequestLogMiddleware(store output.RequestLogRepository) Middleware {
+	return func(next http.Handler) http.Handler {
+		return logRequests(next, slog.Default(), store)
+	}
+}
+
+// logRequests implements NewLoggingMiddleware, additionally saving each request to store when it is not nil.
+func logRequests(next http.Handler, logger *slog.Logger, store output.RequestLogRepository) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
@@ -83,29 +124,65 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		// Calculate the duration of the request.
 		duration := time.Since(start)
 
+		// Only log the scrubbed form of the URL and headers.
+		loggedURL := r.URL.Path
+		scrubbed, hasScrubbed := GetScrubbedRequest(r.Context())
+		if hasScrubbed {
+			loggedURL = scrubbed.URL
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", loggedURL),
+			slog.Int("status", rw.statusCode),
+			slog.Float64("duration_ms", durationMillis(duration)),
+			slog.String("request_id", GetRequestID(r.Context())),
+			slog.String("trace_id", tracing.TraceID(r.Context())),
+		}
+
 		// Log the request information depending on the status code.
 		if rw.statusCode >= 400 {
-			// Log error if status code indicates failure.
-			log.Printf(
-				"[ERROR] %s %s %d %s",
-				r.Method,
-				r.URL.Path,
-				rw.statusCode,
-				duration,
-			)
+			if hasScrubbed {
+				attrs = append(attrs, slog.Any("headers", scrubbed.Header))
+			}
+			logger.LogAttrs(r.Context(), slog.LevelError, "request failed", attrs...)
 		} else {
-			// Log as informational.
-			log.Printf(
-				"[INFO] %s %s %d %s",
-				r.Method,
-				r.URL.Path,
-				rw.statusCode,
-				duration,
-			)
+			logger.LogAttrs(r.Context(), slog.LevelInfo, "request completed", attrs...)
+		}
+
+		if store != nil {
+			record := models.RequestLogRecord{
+				Method:    r.Method,
+				Route:     routeTemplate(r),
+				Status:    rw.statusCode,
+				Duration:  duration,
+				Timestamp: start,
+			}
+			go func() {
+				if err := store.Save(record); err != nil {
+					logger.Warn("could not persist request log record",
+						slog.String("method", record.Method), slog.String("route", record.Route), slog.Any("error", err))
+				}
+			}()
 		}
 	})
 }
 
+// durationMillis converts d to fractional milliseconds for the duration_ms log attribute.
+func durationMillis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// routeTemplate returns the path template of the route that matched r, or the request path when none did.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return r.URL.Path
+}
+
 // ResponseWriter is a custom implementation of http.ResponseWriter that captures the HTTP response status code for logging purposes.
 
 // It embeds the original http.ResponseWriter and overrides the WriteHeader method.
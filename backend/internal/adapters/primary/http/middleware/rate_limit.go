@@ -22,7 +22,7 @@ func RateLimitMiddleware(ipExtractor ratelimiter.IPExtractor, limiter ratelimite
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			clientIP := ipExtractor.Extract(r.RemoteAddr)
 
-			if !limiter.Allow(clientIP) {
+			if !limiter.Allow(clientIP).Allowed {
 				http.Error(w, "Too many requests", http.StatusTooManyRequests)
 				return
 			}
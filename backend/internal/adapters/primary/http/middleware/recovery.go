@@ -0,0 +1,197 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains a recovery middleware that turns handler panics into 500 responses and reports them together with the context of the request that caused them.
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// redactedHeaders lists the request headers whose values must never reach a log line or an error reporter.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+}
+
+// PanicContext describes a recovered panic and the request that was being served when it happened.
+//
+// Fields:
+//   - Method, Path, Query: request line of the failing request.
+//   - Headers: request headers, with Authorization and Cookie replaced by "[REDACTED]".
+//   - UserID: authenticated user ID recorded by AuthMiddleware, or 0 for anonymous requests.
+//   - RequestID: request ID recorded by RequestIDMiddleware, or empty when it did not run.
+//   - PanicType: "string", "error" or "runtime.Error" depending on the recovered value; "unknown (<type>)" otherwise.
+//   - Value: the value passed to panic.
+//   - Stack: stack trace captured at the point of recovery.
+type PanicContext struct {
+	Method    string
+	Path      string
+	Query     string
+	Headers   map[string]string
+	UserID    int
+	RequestID string
+	PanicType string
+	Value     interface{}
+	Stack     []byte
+}
+
+// requestInfoContextKey is the key under which RecoveryMiddleware stores the requestInfo of a request.
+const requestInfoContextKey contextKey = "requestInfo"
+
+// requestInfo collects what the middlewares inside RecoveryMiddleware learn about a request.
+//
+// Those middlewares pass their context values down with r.WithContext, so the request seen by the deferred recover never carries them. RecoveryMiddleware therefore stores a requestInfo in the context first, and RequestIDMiddleware and AuthMiddleware fill it in through recordRequestID and recordUserID.
+type requestInfo struct {
+	mu        sync.Mutex
+	requestID string
+	userID    int
+}
+
+// recordRequestID stores requestID in the requestInfo of ctx, if any.
+func recordRequestID(ctx context.Context, requestID string) {
+	if info, ok := ctx.Value(requestInfoContextKey).(*requestInfo); ok {
+		info.mu.Lock()
+		info.requestID = requestID
+		info.mu.Unlock()
+	}
+}
+
+// recordUserID stores userID in the requestInfo of ctx, if any.
+func recordUserID(ctx context.Context, userID int) {
+	if info, ok := ctx.Value(requestInfoContextKey).(*requestInfo); ok {
+		info.mu.Lock()
+		info.userID = userID
+		info.mu.Unlock()
+	}
+}
+
+// Reporter receives recovered panics so they can be forwarded to an external error tracker (e.g. Sentry).
+type Reporter interface {
+	// Report is called once per recovered panic, after the panic has been logged.
+	Report(panicContext PanicContext)
+}
+
+// RecoveryOption defines functional options for RecoveryMiddleware.
+type RecoveryOption func(*recoveryOptions)
+
+// recoveryOptions holds the optional collaborators of RecoveryMiddleware.
+type recoveryOptions struct {
+	reporter Reporter
+}
+
+// WithPanicReporter forwards every recovered panic to reporter after it has been logged.
+func WithPanicReporter(reporter Reporter) RecoveryOption {
+	return func(o *recoveryOptions) {
+		o.reporter = reporter
+	}
+}
+
+// RecoveryMiddleware returns a middleware that recovers from panics raised by the next handler.
+//
+// For every recovered panic it builds a PanicContext, logs it at error level to logger (slog.Default() when nil) with the panic_type, method, path, user_id, request_id, panic and stack attributes, forwards it to the reporter set with WithPanicReporter, if any, and responds with a JSON 500 Internal Server Error.
+// Panics with http.ErrAbortHandler are intentional aborts: they are neither logged nor reported, and are re-raised so net/http can drop the connection as usual.
+func RecoveryMiddleware(logger *slog.Logger, options ...RecoveryOption) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	var opts recoveryOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			info := &requestInfo{}
+			r = r.WithContext(context.WithValue(r.Context(), requestInfoContextKey, info))
+
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				panicContext := newPanicContext(r, info, recovered, debug.Stack())
+				logger.LogAttrs(r.Context(), slog.LevelError, "panic recovered",
+					slog.String("panic_type", panicContext.PanicType),
+					slog.String("method", panicContext.Method),
+					slog.String("path", panicContext.Path),
+					slog.Int("user_id", panicContext.UserID),
+					slog.String("request_id", panicContext.RequestID),
+					slog.String("panic", fmt.Sprint(panicContext.Value)),
+					slog.String("stack", string(panicContext.Stack)),
+				)
+
+				if opts.reporter != nil {
+					opts.reporter.Report(panicContext)
+				}
+
+				httpUtil.HandleError(w, errors.NewInternalError(errors.ErrInternalServer))
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// newPanicContext collects the request details attached to a recovered panic.
+// The user and request IDs come from info, or from the context of r when RecoveryMiddleware runs inside the middlewares that set them.
+func newPanicContext(r *http.Request, info *requestInfo, recovered interface{}, stack []byte) PanicContext {
+	headers := make(map[string]string, len(r.Header))
+	for name, values := range r.Header {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			headers[name] = "[REDACTED]"
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	info.mu.Lock()
+	userID, requestID := info.userID, info.requestID
+	info.mu.Unlock()
+	if userID == 0 {
+		userID, _ = r.Context().Value(userIDContextKey).(int)
+	}
+	if requestID == "" {
+		requestID = GetRequestID(r.Context())
+	}
+
+	return PanicContext{
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Query:     r.URL.RawQuery,
+		Headers:   headers,
+		UserID:    userID,
+		RequestID: requestID,
+		PanicType: panicType(recovered),
+		Value:     recovered,
+		Stack:     stack,
+	}
+}
+
+// panicType classifies the value passed to panic.
+// runtime.Error is checked before error because every runtime error also implements the error interface.
+func panicType(recovered interface{}) string {
+	switch recovered.(type) {
+	case runtime.Error:
+		return "runtime.Error"
+	case error:
+		return "error"
+	case string:
+		return "string"
+	default:
+		return fmt.Sprintf("unknown (%T)", recovered)
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordingReporter struct {
+	reports []PanicContext
+}
+
+func (r *recordingReporter) Report(panicContext PanicContext) {
+	r.reports = append(r.reports, panicContext)
+}
+
+func TestRecoveryMiddlewareRespondsWithJSON500(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := &recordingReporter{}
+	handler := RecoveryMiddleware(
+		slog.New(slog.NewTextHandler(&buf, nil)),
+		WithPanicReporter(reporter),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/comments", nil)
+	req.Header.Set("Authorization", "Bearer secret-jwt")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Status Expected: %d, Got: %d", http.StatusInternalServerError, rec.Code)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Errorf("Body Expected: valid JSON, Got: %q (%v)", rec.Body.String(), err)
+	}
+
+	logged := buf.String()
+	if !strings.Contains(logged, "panic=boom") || !strings.Contains(logged, "panic_type=string") {
+		t.Errorf("Unexpected panic log record: %s", logged)
+	}
+	if strings.Contains(logged, "secret-jwt") {
+		t.Errorf("Panic log record leaks the Authorization header: %s", logged)
+	}
+	if len(reporter.reports) != 1 || reporter.reports[0].Headers["Authorization"] != "[REDACTED]" {
+		t.Errorf("Unexpected reports: %+v", reporter.reports)
+	}
+}
@@ -0,0 +1,72 @@
+// Package middleware provides HTTP middleware utilities as part of the application's infrastructure layer.
+// This file contains RequestIDMiddleware, which attaches a request ID and a W3C trace context to every request so log lines can be correlated.
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/tracing"
+)
+
+// RequestIDHeader is the header carrying the request ID, read from the request and echoed on the response.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied request IDs; longer ones are replaced by a generated ID.
+const maxRequestIDLength = 128
+
+// RequestIDContextKey is the key under which RequestIDMiddleware stores the request ID in the request context.
+const RequestIDContextKey contextKey = "requestID"
+
+// GetRequestID returns the request ID stored in ctx by RequestIDMiddleware, or an empty string when there is none.
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDContextKey).(string)
+	return requestID
+}
+
+// RequestIDMiddleware assigns every request a correlation ID and a trace context.
+
+// The request ID is taken from the X-Request-ID header when the client (or a proxy) sent a valid one, and is otherwise a new random UUID v4. It is stored in the request context under RequestIDContextKey, recorded for RecoveryMiddleware and set as the X-Request-ID response header.
+// The middleware also reads the W3C traceparent header, or starts a new trace when it is absent or invalid, and stores the resulting trace and span IDs in the request context; the query-logging database wrapper reads them through tracing.TraceID.
+func RequestIDMiddleware() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+			if !isValidRequestID(requestID) {
+				requestID = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+			recordRequestID(r.Context(), requestID)
+
+			ctx := context.WithValue(tracing.ExtractTraceContext(r), RequestIDContextKey, requestID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// isValidRequestID reports whether a client-supplied request ID may be reused: it must be non-empty, at most maxRequestIDLength long and made of printable ASCII, so it cannot forge log lines.
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random UUID version 4 in its canonical textual form.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms; fall back to the nil UUID rather than panicking.
+		return "00000000-0000-0000-0000-000000000000"
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
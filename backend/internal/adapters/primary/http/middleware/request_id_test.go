@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var uuidV4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "propagates the client ID", incoming: "abc-123", keep: true},
+		{name: "generates an ID when absent", incoming: ""},
+		{name: "replaces an ID with control characters", incoming: "abc\nforged"},
+		{name: "replaces an overlong ID", incoming: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = GetRequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get(RequestIDHeader); got != seen {
+				t.Errorf("Response header Expected: %q, Got: %q", seen, got)
+			}
+			if tt.keep {
+				if seen != tt.incoming {
+					t.Errorf("Request ID Expected: %q, Got: %q", tt.incoming, seen)
+				}
+			} else if !uuidV4Pattern.MatchString(seen) {
+				t.Errorf("Request ID Expected: a UUID v4, Got: %q", seen)
+			}
+		})
+	}
+}
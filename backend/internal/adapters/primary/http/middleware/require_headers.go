@@ -0,0 +1,48 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains a middleware that rejects requests missing required header values, such as a JSON Content-Type.
+package middleware
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// RequireHeaders returns a middleware that checks each header in headers against a required value prefix, compared case-insensitively.
+// For example {"Content-Type": "application/json"} accepts "application/json; charset=utf-8".
+
+// A Content-Type violation is answered with 415 Unsupported Media Type, any other violation with 400 Bad Request. Headers are checked in alphabetical order, so the reported error is deterministic.
+func RequireHeaders(headers map[string]string) Middleware {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, name := range names {
+				required := headers[name]
+				if strings.HasPrefix(strings.ToLower(r.Header.Get(name)), strings.ToLower(required)) {
+					continue
+				}
+
+				if http.CanonicalHeaderKey(name) == "Content-Type" {
+					httpUtil.HandleError(w, errors.NewUnsupportedMediaTypeError(errors.ErrUnsupportedMediaType))
+				} else {
+					httpUtil.HandleError(w, errors.NewBadRequestError(errors.ErrMissingHeader))
+				}
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireJSONMiddleware returns a RequireHeaders middleware accepting only request bodies declared as application/json.
+func RequireJSONMiddleware() Middleware {
+	return RequireHeaders(map[string]string{"Content-Type": "application/json"})
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireHeaders(t *testing.T) {
+	handler := RequireHeaders(map[string]string{
+		"Content-Type": "application/json",
+		"X-Client":     "web",
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name        string
+		contentType string
+		client      string
+		want        int
+	}{
+		{"all headers match", "application/json", "web", http.StatusNoContent},
+		{"case-insensitive prefix", "Application/JSON; charset=utf-8", "WEB-app", http.StatusNoContent},
+		{"wrong content type", "text/plain", "web", http.StatusUnsupportedMediaType},
+		{"missing content type", "", "web", http.StatusUnsupportedMediaType},
+		{"missing other header", "application/json", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/login", nil)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.client != "" {
+				req.Header.Set("X-Client", tt.client)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains RoleMiddleware, which restricts routes to users with a given role.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// RoleMiddleware returns a middleware that only lets requests through when the authenticated user has requiredRole, e.g. models.RoleAdmin.
+
+// It reads the role stored by AuthMiddleware (see GetRole), so it must run after it. Requests without a role, such as those made with tokens issued before roles existed, or with another role are rejected with 403 Forbidden.
+func RoleMiddleware(requiredRole string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, ok := GetRole(r.Context())
+			if !ok || role != requiredRole {
+				httpUtil.HandleError(w, errors.NewForbiddenError(errors.ErrForbidden))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+)
+
+func TestRoleMiddleware(t *testing.T) {
+	handler := RoleMiddleware(models.RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name   string
+		claims *models.Claims
+		want   int
+	}{
+		{"admin", &models.Claims{UserId: 1, Role: models.RoleAdmin}, http.StatusNoContent},
+		{"regular user", &models.Claims{UserId: 2, Role: models.RoleUser}, http.StatusForbidden},
+		{"token without role", &models.Claims{UserId: 3}, http.StatusForbidden},
+		{"unauthenticated", nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+			if tt.claims != nil {
+				req = req.WithContext(withClaims(context.Background(), tt.claims))
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
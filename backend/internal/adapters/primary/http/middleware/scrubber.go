@@ -0,0 +1,100 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains a middleware that prepares a copy of the request URL and headers with sensitive values redacted, for use in request logs.
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// redactedValue replaces sensitive values in log output.
+const redactedValue = "[REDACTED]"
+
+// sensitiveHeaders lists the request headers whose values are never logged.
+var sensitiveHeaders = []string{"Authorization", "Cookie"}
+
+// scrubbedRequestContextKey is the key under which the scrubbed request is stored in the request context.
+const scrubbedRequestContextKey contextKey = "scrubbedRequest"
+
+// ScrubbedRequest is the loggable form of a request: its URL and headers with sensitive values redacted.
+type ScrubbedRequest struct {
+	// URL is the request path followed by the query string, with sensitive parameter values replaced by [REDACTED].
+	URL string
+
+	// Header is a copy of the request headers with the Authorization and Cookie values replaced by [REDACTED].
+	Header http.Header
+}
+
+// GetScrubbedRequest returns the scrubbed request stored by SensitiveFieldScrubber, if any.
+func GetScrubbedRequest(ctx context.Context) (ScrubbedRequest, bool) {
+	scrubbed, ok := ctx.Value(scrubbedRequestContextKey).(ScrubbedRequest)
+	return scrubbed, ok
+}
+
+// SensitiveFieldScrubber returns a middleware that stores a ScrubbedRequest in the request context for LoggingMiddleware.
+
+// Query parameters named in sensitiveParams (matched case-insensitively) keep their name but have their values replaced by [REDACTED], as do the Authorization and Cookie headers. The request itself is not modified. It must wrap LoggingMiddleware, i.e. be registered before it.
+func SensitiveFieldScrubber(sensitiveParams []string) Middleware {
+	sensitive := make(map[string]bool, len(sensitiveParams))
+	for _, param := range sensitiveParams {
+		sensitive[strings.ToLower(param)] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scrubbed := ScrubbedRequest{
+				URL:    scrubURL(r.URL, sensitive),
+				Header: scrubHeader(r.Header),
+			}
+			ctx := context.WithValue(r.Context(), scrubbedRequestContextKey, scrubbed)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// scrubURL returns the path and query of u, with the values of sensitive parameters redacted and parameters sorted by name.
+func scrubURL(u *url.URL, sensitive map[string]bool) string {
+	query := u.Query()
+	if len(query) == 0 {
+		return u.Path
+	}
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(u.Path)
+	b.WriteByte('?')
+	for i, key := range keys {
+		for j, value := range query[key] {
+			if i > 0 || j > 0 {
+				b.WriteByte('&')
+			}
+			b.WriteString(url.QueryEscape(key))
+			b.WriteByte('=')
+			if sensitive[strings.ToLower(key)] {
+				b.WriteString(redactedValue)
+			} else {
+				b.WriteString(url.QueryEscape(value))
+			}
+		}
+	}
+	return b.String()
+}
+
+// scrubHeader returns a copy of header with the values of sensitiveHeaders redacted.
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := scrubbed[name]; ok {
+			scrubbed[name] = []string{redactedValue}
+		}
+	}
+	return scrubbed
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSensitiveFieldScrubberRedactsLoggedRequest(t *testing.T) {
+	var buf bytes.Buffer
+	handler := Chain(
+		SensitiveFieldScrubber([]string{"token", "api_key"}),
+		NewLoggingMiddleware(slog.New(slog.NewTextHandler(&buf, nil))),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/comments?page=2&Token=abc123&api_key=k1", nil)
+	req.Header.Set("Authorization", "Bearer secret-jwt")
+	req.AddCookie(&http.Cookie{Name: "token", Value: "cookie-jwt"})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	line := buf.String()
+	for _, secret := range []string{"abc123", "k1", "secret-jwt", "cookie-jwt"} {
+		if strings.Contains(line, secret) {
+			t.Errorf("log line leaks %q: %s", secret, line)
+		}
+	}
+	if !strings.Contains(line, "/comments?Token=[REDACTED]&api_key=[REDACTED]&page=2") {
+		t.Errorf("log line is missing the scrubbed URL: %s", line)
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	tests := []struct {
+		name         string
+		isProduction bool
+		wantHSTS     string
+	}{
+		{name: "development", isProduction: false, wantHSTS: ""},
+		{name: "production", isProduction: true, wantHSTS: "max-age=31536000; includeSubDomains"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := SecurityHeadersMiddleware(DefaultSecurityHeadersConfig(tt.isProduction))(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			expected := map[string]string{
+				"X-Frame-Options":           "DENY",
+				"X-Content-Type-Options":    "nosniff",
+				"Referrer-Policy":           "strict-origin-when-cross-origin",
+				"Strict-Transport-Security": tt.wantHSTS,
+			}
+			for name, want := range expected {
+				if got := rec.Header().Get(name); got != want {
+					t.Errorf("%s Expected: %q, Got: %q", name, want, got)
+				}
+			}
+		})
+	}
+}
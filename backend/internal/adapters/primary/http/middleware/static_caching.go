@@ -0,0 +1,60 @@
+// Package middleware provides HTTP middleware utilities.
+// This file contains the StaticCachingMiddleware, which sets Cache-Control on static assets depending on whether their file name is fingerprinted.
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+)
+
+const (
+	// immutableCacheControl lets browsers and CDNs keep fingerprinted assets for a year without revalidating.
+	immutableCacheControl = "public, immutable, max-age=31536000"
+	// revalidateCacheControl forces browsers to revalidate assets whose URL does not change with their content.
+	revalidateCacheControl = "no-cache, must-revalidate"
+)
+
+// fingerprintPattern matches file names carrying a content hash before the extension, e.g. "app.3f9a1c2e.js".
+var fingerprintPattern = regexp.MustCompile(`\.[a-f0-9]{8,}\.[a-z]+$`)
+
+// StaticCachingMiddleware returns a middleware that sets Cache-Control on static asset responses.
+// Fingerprinted files are cached as immutable for a year, every other file must be revalidated. Error responses are never cached as immutable, so a fingerprinted URL requested before it is deployed does not stick in caches.
+func StaticCachingMiddleware() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !fingerprintPattern.MatchString(r.URL.Path) {
+				w.Header().Set("Cache-Control", revalidateCacheControl)
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(&staticCachingWriter{ResponseWriter: w}, r)
+		})
+	}
+}
+
+// staticCachingWriter sets the immutable Cache-Control header on successful responses and the revalidating one on errors.
+type staticCachingWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader picks the Cache-Control value from the status code before writing the header.
+func (w *staticCachingWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if statusCode < http.StatusBadRequest {
+			w.Header().Set("Cache-Control", immutableCacheControl)
+		} else {
+			w.Header().Set("Cache-Control", revalidateCacheControl)
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write sends an implicit 200 OK header before the first chunk of the body.
+func (w *staticCachingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStaticCachingMiddleware(t *testing.T) {
+	handler := StaticCachingMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/js/missing.0123abcd.js" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("body"))
+	}))
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"fingerprinted file", "/js/app.3f9a1c2e.js", immutableCacheControl},
+		{"long fingerprint", "/css/site.0123456789abcdef.css", immutableCacheControl},
+		{"plain file", "/css/site.css", revalidateCacheControl},
+		{"short hash", "/assets/logo.abc123.png", revalidateCacheControl},
+		{"missing fingerprinted file", "/js/missing.0123abcd.js", revalidateCacheControl},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if got := rec.Header().Get("Cache-Control"); got != tt.want {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,52 +4,57 @@
 package middleware
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/tracing"
 )
 
 /// TimingConfig contains configuration options for the timing middleware.
 // It defines the duration threshold beyond which a performance warning is issued,
-// and a function to log the performance metrics.
+// and how the performance metrics are logged.
 type TimingConfig struct {
 	// WarningThreshold is the duration (time.Duration) beyond which a performance
 	// warning is recorded. For example, if set to 500ms, any request taking longer
 	// than 500ms will trigger a warning in the logs.
 	WarningThreshold time.Duration
-	// LogFunc is a function used to log performance information. It receives the HTTP method,
+	// LogFunc, when set, replaces the structured logging of the middleware. It receives the HTTP method,
 	// request path, the duration it took to process the request, and a boolean flag indicating
 	// whether the duration exceeded the WarningThreshold (true if a warning should be logged).
 	LogFunc func(method, path string, duration time.Duration, warning bool)
+
+	// logger receives the timing records when LogFunc is nil; slog.Default() is used when it is nil too.
+	logger *slog.Logger
 }
 
 // DefaultTimingConfig creates and returns a default configuration for the timing middleware.
 
-// By default, it sets a warning threshold of 500ms and defines a logging function that uses the standard log package to output performance information. If the duration exceeds the threshold, the log entry is prefixed with "[PERF WARNING]"; otherwise, it is prefixed with "[PERF]".
+// By default, it sets a warning threshold of 500ms and logs through slog.Default(): requests over the threshold at warn level, the others at info level.
 func DefaultTimingConfig() *TimingConfig {
 	return &TimingConfig{
-		WarningThreshold: 500 * time.Millisecond,  // 500ms threshold
-		LogFunc: func(method, path string, duration time.Duration, warning bool) {
-			if warning {
-				log.Printf("[PERF WARNING] %s %s - %s", method, path, duration)
-			} else {
-				log.Printf("[PERF] %s %s - %s", method, path, duration)
-			}
-		},
+		WarningThreshold: 500 * time.Millisecond, // 500ms threshold
 	}
 }
 
+// WithLogger sets the logger the timing records are written to and returns the config, for chaining.
+func (c *TimingConfig) WithLogger(logger *slog.Logger) *TimingConfig {
+	c.logger = logger
+	return c
+}
+
 // TimingMiddleware returns a middleware that measures the response time of HTTP requests.
 
-// This middleware records the start time before executing the next handler in the chain, calculates the duration once the response has been served, and invokes the LogFunc defined in the provided TimingConfig. It logs a performance warning if the duration exceeds the configured WarningThreshold.
+// This middleware records the start time before executing the next handler in the chain and calculates the duration once the response has been served. Unless a LogFunc is configured, it then logs a record with the method, path, status, duration_ms, request_id and trace_id attributes, at warn level when the duration exceeds the configured WarningThreshold.
 func TimingMiddleware(config *TimingConfig) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Record the start time before processing the request.
 			start := time.Now()
 
-			// Execute the next handler in the chain.
-			next.ServeHTTP(w, r)
+			// Execute the next handler in the chain, capturing the status code.
+			rw := NewResponseWriter(w)
+			next.ServeHTTP(rw, r)
 
 			// Calculate the elapsed time after processing the request.
 			duration := time.Since(start)
@@ -57,8 +62,27 @@ func TimingMiddleware(config *TimingConfig) Middleware {
 			// Determine whether the duration exceeds the warning threshold.
 			warning := duration > config.WarningThreshold
 
-			// Log the timing information using the provided logging function.
-			config.LogFunc(r.Method, r.URL.Path, duration, warning)
+			if config.LogFunc != nil {
+				config.LogFunc(r.Method, r.URL.Path, duration, warning)
+				return
+			}
+
+			logger := config.logger
+			if logger == nil {
+				logger = slog.Default()
+			}
+			level, msg := slog.LevelInfo, "request timing"
+			if warning {
+				level, msg = slog.LevelWarn, "slow request"
+			}
+			logger.LogAttrs(r.Context(), level, msg,
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", rw.statusCode),
+				slog.Float64("duration_ms", durationMillis(duration)),
+				slog.String("request_id", GetRequestID(r.Context())),
+				slog.String("trace_id", tracing.TraceID(r.Context())),
+			)
 		})
 	}
 }
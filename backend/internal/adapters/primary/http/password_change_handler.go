@@ -0,0 +1,54 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the PasswordChangeHandler, which lets authenticated users change their password.
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// PasswordChangeHandler serves PUT /users/me/password.
+type PasswordChangeHandler struct {
+	passwordChangeService input.PasswordChangeService
+}
+
+// NewPasswordChangeHandler creates a new instance of PasswordChangeHandler.
+func NewPasswordChangeHandler(passwordChangeService input.PasswordChangeService) *PasswordChangeHandler {
+	return &PasswordChangeHandler{
+		passwordChangeService: passwordChangeService,
+	}
+}
+
+// changePasswordRequest is the JSON body accepted by PUT /users/me/password.
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// Handle changes the authenticated user's password and responds with HTTP 204 (No Content).
+// It responds with 400 for malformed JSON, 401 when the current password is wrong and 422 when the new password breaks the password rules.
+func (h *PasswordChangeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.GetUserIdContextKey()).(int)
+	if !ok {
+		httpUtil.WriteError(w, errors.NewAuthError(errors.ErrUnauthorized))
+		return
+	}
+
+	var request changePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
+		return
+	}
+
+	if err := h.passwordChangeService.ChangePassword(userID, request.CurrentPassword, request.NewPassword); err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
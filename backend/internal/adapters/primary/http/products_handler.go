@@ -0,0 +1,60 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the ProductsHandler, which serves the public watch catalogue.
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+	"github.com/gorilla/mux"
+)
+
+// ProductsHandler serves GET /products and GET /products/{id}.
+
+// Both endpoints are public, so visitors can browse the catalogue without logging in.
+type ProductsHandler struct {
+	productService input.ProductGetService
+}
+
+// NewProductsHandler creates a new instance of ProductsHandler.
+func NewProductsHandler(productService input.ProductGetService) *ProductsHandler {
+	return &ProductsHandler{
+		productService: productService,
+	}
+}
+
+// Handle returns every product as a JSON array with an HTTP 200 (OK) status, or 500 if the catalogue cannot be read.
+func (h *ProductsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	products, err := h.productService.AllProducts()
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewInternalError("Error getting products"))
+		return
+	}
+
+	httpUtil.SendJSONResponse(w, http.StatusOK, products)
+}
+
+// HandleByID returns the product identified by the {id} path variable as JSON with an HTTP 200 (OK) status.
+// It responds with 404 if no product has this ID and 500 if the product cannot be read.
+func (h *ProductsHandler) HandleByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrProductNotFound))
+		return
+	}
+
+	product, err := h.productService.ProductByID(id)
+	if errors.IsNotFound(err) {
+		httpUtil.WriteError(w, err)
+		return
+	}
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewInternalError("Error getting product"))
+		return
+	}
+
+	httpUtil.SendJSONResponse(w, http.StatusOK, product)
+}
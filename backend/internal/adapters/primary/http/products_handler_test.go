@@ -0,0 +1,86 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	"github.com/gorilla/mux"
+)
+
+// catalogueService serves a fixed list of products.
+type catalogueService struct {
+	products []models.Product
+}
+
+func (s *catalogueService) AllProducts() ([]models.Product, error) {
+	return s.products, nil
+}
+
+func (s *catalogueService) ProductByID(id int) (*models.Product, error) {
+	for i := range s.products {
+		if s.products[i].ID == id {
+			return &s.products[i], nil
+		}
+	}
+	return nil, errors.NewNotFoundError(errors.ErrProductNotFound)
+}
+
+func newCatalogueHandler() *ProductsHandler {
+	return NewProductsHandler(&catalogueService{products: []models.Product{
+		{ID: 1, Name: "Speedmaster", Brand: "Omega", Price: 6400, Stock: 2},
+		{ID: 2, Name: "Submariner", Brand: "Rolex", Price: 9100, Stock: 1},
+	}})
+}
+
+func TestProductsHandlerListsProducts(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newCatalogueHandler().Handle(rec, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, Got %d", http.StatusOK, rec.Code)
+	}
+	var products []models.Product
+	if err := json.NewDecoder(rec.Body).Decode(&products); err != nil {
+		t.Fatalf("Invalid JSON body: %v", err)
+	}
+	if len(products) != 2 || products[1].Brand != "Rolex" {
+		t.Errorf("Unexpected products: %+v", products)
+	}
+}
+
+func TestProductsHandlerByID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want int
+	}{
+		{"existing product", "2", http.StatusOK},
+		{"missing product", "42", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/products/"+tt.id, nil), map[string]string{"id": tt.id})
+			rec := httptest.NewRecorder()
+			newCatalogueHandler().HandleByID(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("Expected status %d, Got %d", tt.want, rec.Code)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			var product models.Product
+			if err := json.NewDecoder(rec.Body).Decode(&product); err != nil {
+				t.Fatalf("Invalid JSON body: %v", err)
+			}
+			if product.Name != "Submariner" {
+				t.Errorf("Expected Submariner, Got %q", product.Name)
+			}
+		})
+	}
+}
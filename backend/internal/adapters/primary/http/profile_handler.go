@@ -0,0 +1,73 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the ProfileHandler, which serves and updates the authenticated user's profile.
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// ProfileHandler handles GET and PATCH requests on /auth/profile, and GET /users/me.
+
+// It acts as an adapter between HTTP requests and the UserProfileService. Both endpoints require authentication.
+type ProfileHandler struct {
+	profileService input.UserProfileService
+}
+
+// NewProfileHandler creates a new instance of ProfileHandler.
+func NewProfileHandler(profileService input.UserProfileService) *ProfileHandler {
+	return &ProfileHandler{
+		profileService: profileService,
+	}
+}
+
+// updateProfileRequest is the JSON body accepted by PATCH /auth/profile.
+type updateProfileRequest struct {
+	DisplayName string `json:"displayName"`
+}
+
+// HandleGet returns the authenticated user's profile (ID, username, display name and join date) as JSON with an HTTP 200 (OK) status.
+func (h *ProfileHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.GetUserIdContextKey()).(int)
+	if !ok {
+		httpUtil.WriteError(w, errors.NewAuthError(errors.ErrUnauthorized))
+		return
+	}
+
+	profile, err := h.profileService.GetProfile(userID)
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+
+	httpUtil.SendJSONResponse(w, http.StatusOK, profile)
+}
+
+// HandleUpdate changes the authenticated user's display name and returns the updated profile.
+// It responds with 400 for malformed JSON and 422 when the display name breaks the naming rules.
+func (h *ProfileHandler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.GetUserIdContextKey()).(int)
+	if !ok {
+		httpUtil.WriteError(w, errors.NewAuthError(errors.ErrUnauthorized))
+		return
+	}
+
+	var request updateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
+		return
+	}
+
+	profile, err := h.profileService.UpdateDisplayName(userID, request.DisplayName)
+	if err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+
+	httpUtil.SendJSONResponse(w, http.StatusOK, profile)
+}
@@ -19,45 +19,50 @@ import (
 // It serves as an adapter between HTTP requests and the core business logic for registering users, utilizing the UserServiceRegister interface.
 type RegisterHandler struct {
 	userServiceRegister input.UserServiceRegister
+	authCookieName      string
+	refreshTokens       models.RefreshTokenConfig
 }
 
 // NewRegisterHandler creates a new instance of RegisterHandler.
 
-// It receives an implementation of the UserServiceRegister interface that encapsulates the business logic for user registration.
-func NewRegisterHandler(userServiceRegister input.UserServiceRegister) *RegisterHandler {
+// It receives an implementation of the UserServiceRegister interface that encapsulates the business logic for user registration, the name of the cookie the token is stored in, and the refresh token settings.
+func NewRegisterHandler(userServiceRegister input.UserServiceRegister, authCookieName string, refreshTokens models.RefreshTokenConfig) *RegisterHandler {
 	return &RegisterHandler{
 		userServiceRegister: userServiceRegister,
+		authCookieName:      authCookieName,
+		refreshTokens:       refreshTokens,
 	}
 }
 
 // Handle processes HTTP registration requests.
 
-// It validates that the request method is POST and decodes the incoming JSON payload into an Account model. After invoking the registration service to create a new user account, it sets an authentication cookie (using secure settings if in production) and sends a JSON response indicating a successful registration.
+// It validates that the request method is POST and decodes the incoming JSON payload into an Account model. After invoking the registration service to create a new user account, it sets an authentication cookie, and a refresh cookie when refresh tokens are enabled (using secure settings if in production), and sends a JSON response indicating a successful registration.
 // In case of errors, it responds with appropriate HTTP error messages.
 func (h *RegisterHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	// Ensure the HTTP method is POST.
 	if r.Method != http.MethodPost {
-		httpUtil.HandleError(w, errors.NewBadRequestError(errors.ErrMethodNotAllowed))
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrMethodNotAllowed))
 		return
 	}
 
 	// Decode the JSON request body into an Account instance.
 	var account models.Account
 	if err := json.NewDecoder(r.Body).Decode(&account); err != nil {
-		httpUtil.HandleError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
+		httpUtil.WriteError(w, errors.NewBadRequestError(errors.ErrInvalidRequest))
 		return
 	}
 
 	// Attempt to register the user and generate an authentication token.
 	token, err := h.userServiceRegister.Register(account)
 	if err != nil {
-		httpUtil.HandleError(w, err)
+		httpUtil.WriteError(w, err)
 		return
 	}
 
 	// Determine if the environment is production to set secure cookie flags.
 	isProduction := os.Getenv("ENV") == "production"
-	cookies.SetAuthCookie(w, token, isProduction)
+	cookies.SetAuthCookie(w, h.authCookieName, token, isProduction)
+	setRefreshCookie(w, h.refreshTokens, token, isProduction)
 
 	// Send a JSON response indicating successful registration.
 	httpUtil.SendJSONResponse(w, http.StatusOK, map[string]string{
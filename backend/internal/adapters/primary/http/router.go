@@ -3,13 +3,22 @@
 package http
 
 import (
+	"expvar"
+	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/config"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/metrics"
 	ratelimiter "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/rate_limiter"
 	"github.com/gorilla/mux"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // RouterConfiguration defines the interface for configuring routes in the application.
@@ -23,42 +32,121 @@ type RouterConfiguration interface {
 // Fields:
 //   - IPExtractor: extracts client IP from *http.Request* for rate limiting.
 //   - RateLimiter: handles request rate limiting based on extracted IP.
+//   - CSRFTokenRateLimiter: looser rate limiter applied to GET /auth/csrf-token.
 //   - LoginHandler: processes user login requests.
 //   - RegisterHandler: processes user registration requests.
 //   - CommentsGetHandler: handles retrieval of comments.
 //   - CommentsAddHandler: handles creation of new comments.
+//   - CommentDeleteHandler: deletes comments on behalf of their authors.
+//   - CommentUpdateHandler: edits comments on behalf of their authors.
+//   - ProductsHandler: serves the public watch catalogue.
 //   - MainPageHandler: serves the application's main page.
 //   - StaticFileHandler: serves static assets like CSS/JS/images.
 //   - MiddlewareManager: orchestrates application of global and route-specific middleware.
+//   - IdempotencyRepository: stores responses replayed for retried POST requests.
+//   - AuthOptions: public paths and auth cookie name used by the authentication middleware.
+//   - VersionHandler: reports build metadata of the running binary.
+//   - ProfileHandler: reads and updates the authenticated user's profile.
+//   - PasswordChangeHandler: changes the authenticated user's password.
+//   - UserDeletionHandler: soft-deletes the authenticated user's account and ends the session.
+//   - CSRFTokenHandler: issues CSRF tokens to single-page applications.
+//   - WhoAmIHandler: reports the effective user and impersonator of the session.
+//   - LogoutHandler: revokes the session's tokens and clears their cookies.
+//   - AdminDashboardHandler: reports the health indicators of the instance; nil disables GET /admin/dashboard.
+//   - AdminConfigHandler: lets administrators inspect and edit the running configuration.
+//   - AdminUsersHandler: lists registered accounts for administrators.
+//   - AdminImpersonateHandler: lets administrators act as another user.
+//   - AdminAuditHandler: lists recorded impersonations for administrators.
+//   - AdminCommentDeleteHandler: deletes any comment for moderation by administrators.
+//   - AdminCommentPinHandler: pins and unpins comments as homepage testimonials for administrators.
+//   - AdminSLAHandler: reports daily latency percentiles and error rates per route; nil disables GET /admin/metrics/sla.
+//   - HealthHandler: reports database liveness to load balancers; nil disables GET /health.
+//   - IsProduction: restricts operational endpoints such as /version to localhost.
+//   - RouteFlags: feature flags used to disable individual routes; nil disables the check.
+//   - HotReloadRouteFlags: checks RouteFlags on every request instead of once at startup.
+//   - DeduplicationTTL: how long collapsed responses of public GET endpoints are replayed; zero disables deduplication.
+//   - ResponseBufferBytes: size up to which API responses are buffered to send a Content-Length header.
+//   - SwaggerUIDir: directory of the Swagger UI served under /docs/; empty disables the documentation routes.
+//   - IsDebugMode: the documentation routes are only registered in debug mode.
+//   - SPAMode: unknown navigational paths are answered with the main page instead of 404.
+//   - CORSConfig: CORS policy whose configured methods are merged with the registered ones by ComputeCORSMethods.
 type RouterConfig struct {
-	IPExtractor        ratelimiter.IPExtractor
-	RateLimiter        ratelimiter.RateLimiterHandler
-	LoginHandler       *LoginHandler
-	RegisterHandler    *RegisterHandler
-	CommentsGetHandler *CommentsGetHandler
-	CommentsAddHandler *CommentsAddHandler
-	MainPageHandler    *MainPageHandler
-	StaticFileHandler  *StaticFileHandler
-	MiddlewareManager  *middleware.MiddlewareManager
+	IPExtractor               ratelimiter.IPExtractor
+	RateLimiter               ratelimiter.RateLimiterHandler
+	CSRFTokenRateLimiter      ratelimiter.RateLimiterHandler
+	LoginHandler              *LoginHandler
+	RegisterHandler           *RegisterHandler
+	CommentsGetHandler        *CommentsGetHandler
+	CommentsAddHandler        *CommentsAddHandler
+	CommentDeleteHandler      *CommentDeleteHandler
+	CommentUpdateHandler      *CommentUpdateHandler
+	ProductsHandler           *ProductsHandler
+	MainPageHandler           *MainPageHandler
+	StaticFileHandler         *StaticFileHandler
+	MiddlewareManager         *middleware.MiddlewareManager
+	IdempotencyRepository     output.IdempotencyRepository
+	AuthOptions               *middleware.AuthOptions
+	VersionHandler            *VersionHandler
+	ProfileHandler            *ProfileHandler
+	PasswordChangeHandler     *PasswordChangeHandler
+	UserDeletionHandler       *UserDeletionHandler
+	CSRFTokenHandler          *CSRFTokenHandler
+	WhoAmIHandler             *WhoAmIHandler
+	LogoutHandler             *LogoutHandler
+	AdminDashboardHandler     *AdminDashboardHandler
+	AdminConfigHandler        *AdminConfigHandler
+	AdminUsersHandler         *AdminUsersHandler
+	AdminImpersonateHandler   *AdminImpersonateHandler
+	AdminAuditHandler         *AdminAuditHandler
+	AdminCommentDeleteHandler *AdminCommentDeleteHandler
+	AdminCommentPinHandler    *AdminCommentPinHandler
+	AdminSLAHandler           *AdminSLAHandler
+	HealthHandler             *HealthHandler
+	IsProduction              bool
+	RouteFlags                middleware.RouteFlags
+	HotReloadRouteFlags       bool
+	DeduplicationTTL          time.Duration
+	ResponseBufferBytes       int64
+	SwaggerUIDir              string
+	IsDebugMode               bool
+	SPAMode                   bool
+	CORSConfig                *middleware.CORSConfig
+
+	// router is the router routes were last registered on by SetupRoutes.
+	router *mux.Router
 }
 
 // SetupRoutes registers all application endpoints on the given router and applies route-specific middleware for authentication and rate limiting.
 // Routes include:
-//   - Static files (CSS, JS, images)
-//   - Public endpoints: GET /, POST /register, POST /login
-//   - Protected endpoints: GET /comments, POST /comments/newComments
+//   - Static files (CSS, JS, images) and the asset version manifest (GET /static/manifest.json)
+//   - API documentation (GET /docs/, GET /docs/openapi.json) in debug mode when a Swagger UI directory is configured, localhost only in production
+//   - Unthrottled public endpoint: GET /health
+//   - Public endpoints: GET /, POST /register, POST /login, POST /logout, GET /comments/histogram, GET /comments/pinned, GET /products, GET /products/{id}, GET /auth/csrf-token, GET /version, GET /debug/vars and GET /metrics (localhost only in production)
+//   - Protected endpoints: GET /comments, POST /comments/newComments, PUT /comments/{id}, DELETE /comments/{id}, GET /auth/profile, PATCH /auth/profile, GET /auth/whoami, GET /users/me, PUT /users/me/password, DELETE /users/me
+//   - Admin endpoints, restricted to users with the admin role: GET /admin/dashboard (also localhost only in production), GET /admin/config, PATCH /admin/config, GET /admin/users, POST /admin/users/{id}/impersonate, GET /admin/audit/impersonations, DELETE /admin/comments/{id}, POST /admin/comments/{id}/pin, POST /admin/comments/{id}/unpin, GET /admin/metrics/sla
+//   - Every mutating request, including POST /login and POST /register, requires a valid CSRF token (see middleware.CSRFMiddleware, registered globally by NewRouter, and middleware.DefaultCSRFExcludedPaths)
+//   - POST /login, POST /register, POST /comments/newComments, PUT /comments/{id}, PUT /users/me/password, PATCH /admin/config and POST /admin/users/{id}/impersonate reject bodies not sent as application/json (415)
+
+// In SPA mode, GET and HEAD requests to unknown paths without a file extension are answered with the main page (see MainPageHandler.HandleSPAFallback); other unknown paths get a JSON 404.
 
-// Each route is wrapped with authentication and rate limiting via the MiddlewareManager.Apply method.
+// Each route is wrapped with authentication and rate limiting via the MiddlewareManager.Apply method. GET /comments is additionally deduplicated, so identical concurrent requests share one database query. Every route except the main page and the static and documentation files buffers responses of up to ResponseBufferBytes so they carry a Content-Length header. Routes disabled through feature flags answer 503 Service Unavailable.
 
 // Parameters:
 //   - router: *mux.Router instance to configure routes on.
 func (c *RouterConfig) SetupRoutes(router *mux.Router) {
+	c.router = router
+
 	// 1. Register static file serving routes
 	c.StaticFileHandler.RegisterRoutes(router)
 
 	// 2. Prepare middleware for rate limiting and authentication
 	rateLimitMW := middleware.RateLimitMiddleware(c.IPExtractor, c.RateLimiter)
-	authMW := middleware.AuthMiddleware(middleware.DefaultAuthOptions())
+	authMW := middleware.AuthMiddleware(c.AuthOptions)
+	idempotencyMW := middleware.IdempotencyMiddleware(c.IdempotencyRepository)
+	dedupMW := middleware.DeduplicationMiddleware(c.DeduplicationTTL)
+	requireJSONMW := middleware.RequireJSONMiddleware()
+	contentLengthMW := middleware.ContentLengthMiddleware(c.ResponseBufferBytes)
+	adminMW := middleware.RoleMiddleware(models.RoleAdmin)
 
 	// 3. Public routes
 	router.Handle("/", c.MiddlewareManager.Apply(
@@ -68,24 +156,253 @@ func (c *RouterConfig) SetupRoutes(router *mux.Router) {
 
 	router.Handle("/register", c.MiddlewareManager.Apply(
 		http.HandlerFunc(c.RegisterHandler.Handle),
-		authMW, rateLimitMW,
+		contentLengthMW, authMW, rateLimitMW, requireJSONMW,
 	)).Methods("POST")
 
 	router.Handle("/login", c.MiddlewareManager.Apply(
 		http.HandlerFunc(c.LoginHandler.Handle),
-		authMW, rateLimitMW,
+		contentLengthMW, authMW, rateLimitMW, requireJSONMW,
+	)).Methods("POST")
+
+	router.Handle("/logout", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.LogoutHandler.Handle),
+		contentLengthMW, rateLimitMW,
 	)).Methods("POST")
 
 	router.Handle("/comments", c.MiddlewareManager.Apply(
 		http.HandlerFunc(c.CommentsGetHandler.Handle),
-		authMW, rateLimitMW,
+		contentLengthMW, authMW, rateLimitMW, dedupMW,
+	)).Methods("GET")
+
+	// The health check must answer even when the prober's IP is rate limited.
+	if c.HealthHandler != nil {
+		router.Handle("/health", c.MiddlewareManager.Apply(
+			http.HandlerFunc(c.HealthHandler.Handle),
+			contentLengthMW,
+		)).Methods("GET")
+	}
+
+	// Operational endpoints are restricted to localhost in production
+	operationalMiddlewares := []middleware.Middleware{rateLimitMW}
+	if c.IsProduction {
+		operationalMiddlewares = append(operationalMiddlewares, middleware.LocalhostOnlyMiddleware(c.IPExtractor))
+	}
+	router.Handle("/version", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.VersionHandler.Handle),
+		append([]middleware.Middleware{contentLengthMW}, operationalMiddlewares...)...,
+	)).Methods("GET")
+
+	router.Handle("/debug/vars", c.MiddlewareManager.Apply(
+		expvar.Handler(),
+		operationalMiddlewares...,
+	)).Methods("GET")
+
+	router.Handle("/metrics", c.MiddlewareManager.Apply(
+		promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}),
+		operationalMiddlewares...,
+	)).Methods("GET")
+
+	if c.SwaggerUIDir != "" && c.IsDebugMode {
+		c.StaticFileHandler.RegisterDocsRoute(router, c.SwaggerUIDir, operationalMiddlewares...)
+	}
+
+	router.Handle("/comments/histogram", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.CommentsGetHandler.HandleHistogram),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("GET")
+
+	router.Handle("/comments/pinned", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.CommentsGetHandler.HandlePinned),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("GET")
+
+	router.Handle("/products", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.ProductsHandler.Handle),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("GET")
+
+	router.Handle("/products/{id:[0-9]+}", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.ProductsHandler.HandleByID),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("GET")
+
+	router.Handle("/auth/csrf-token", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.CSRFTokenHandler.Handle),
+		contentLengthMW, authMW, middleware.RateLimitMiddleware(c.IPExtractor, c.CSRFTokenRateLimiter),
 	)).Methods("GET")
 
 	// 4. Protected routes
 	router.Handle("/comments/newComments", c.MiddlewareManager.Apply(
 		http.HandlerFunc(c.CommentsAddHandler.Handle),
-		authMW, rateLimitMW,
+		contentLengthMW, authMW, rateLimitMW, requireJSONMW, idempotencyMW,
 	)).Methods("POST")
+
+	router.Handle("/comments/{id:[0-9]+}", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.CommentUpdateHandler.Handle),
+		contentLengthMW, authMW, rateLimitMW, requireJSONMW,
+	)).Methods("PUT")
+
+	router.Handle("/comments/{id:[0-9]+}", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.CommentDeleteHandler.Handle),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("DELETE")
+
+	router.Handle("/auth/profile", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.ProfileHandler.HandleGet),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("GET")
+
+	router.Handle("/auth/profile", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.ProfileHandler.HandleUpdate),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("PATCH")
+
+	router.Handle("/users/me", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.ProfileHandler.HandleGet),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("GET")
+
+	router.Handle("/users/me", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.UserDeletionHandler.Handle),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("DELETE")
+
+	router.Handle("/users/me/password", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.PasswordChangeHandler.Handle),
+		contentLengthMW, authMW, rateLimitMW, requireJSONMW,
+	)).Methods("PUT")
+
+	router.Handle("/auth/whoami", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.WhoAmIHandler.Handle),
+		contentLengthMW, authMW, rateLimitMW,
+	)).Methods("GET")
+
+	// 5. Admin routes
+	if c.AdminDashboardHandler != nil {
+		router.Handle("/admin/dashboard", c.MiddlewareManager.Apply(
+			http.HandlerFunc(c.AdminDashboardHandler.Handle),
+			append([]middleware.Middleware{contentLengthMW, authMW, adminMW}, operationalMiddlewares...)...,
+		)).Methods("GET")
+	}
+
+	router.Handle("/admin/config", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.AdminConfigHandler.HandleGet),
+		contentLengthMW, authMW, adminMW, rateLimitMW,
+	)).Methods("GET")
+
+	router.Handle("/admin/config", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.AdminConfigHandler.HandlePatch),
+		contentLengthMW, authMW, adminMW, rateLimitMW, requireJSONMW,
+	)).Methods("PATCH")
+
+	router.Handle("/admin/users", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.AdminUsersHandler.Handle),
+		contentLengthMW, authMW, adminMW, rateLimitMW,
+	)).Methods("GET")
+
+	router.Handle("/admin/users/{id:[0-9]+}/impersonate", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.AdminImpersonateHandler.Handle),
+		contentLengthMW, authMW, adminMW, rateLimitMW, requireJSONMW,
+	)).Methods("POST")
+
+	router.Handle("/admin/audit/impersonations", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.AdminAuditHandler.HandleImpersonations),
+		contentLengthMW, authMW, adminMW, rateLimitMW,
+	)).Methods("GET")
+
+	router.Handle("/admin/comments/{id:[0-9]+}", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.AdminCommentDeleteHandler.Handle),
+		contentLengthMW, authMW, adminMW, rateLimitMW,
+	)).Methods("DELETE")
+
+	router.Handle("/admin/comments/{id:[0-9]+}/pin", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.AdminCommentPinHandler.HandlePin),
+		contentLengthMW, authMW, adminMW, rateLimitMW,
+	)).Methods("POST")
+
+	router.Handle("/admin/comments/{id:[0-9]+}/unpin", c.MiddlewareManager.Apply(
+		http.HandlerFunc(c.AdminCommentPinHandler.HandleUnpin),
+		contentLengthMW, authMW, adminMW, rateLimitMW,
+	)).Methods("POST")
+
+	if c.AdminSLAHandler != nil {
+		router.Handle("/admin/metrics/sla", c.MiddlewareManager.Apply(
+			http.HandlerFunc(c.AdminSLAHandler.Handle),
+			contentLengthMW, authMW, adminMW, rateLimitMW,
+		)).Methods("GET")
+	}
+
+	// 6. Disable routes turned off through feature flags
+	c.applyRouteFlags(router)
+
+	// 7. The router does not run its middlewares on NotFoundHandler, so the SPA fallback gets the global ones explicitly.
+	if c.SPAMode {
+		router.NotFoundHandler = c.MiddlewareManager.ApplyGlobal(
+			http.HandlerFunc(c.MainPageHandler.HandleSPAFallback),
+			authMW, rateLimitMW,
+		)
+	}
+}
+
+// applyRouteFlags replaces the handler of every registered route listed in the disabled routes feature flag.
+// With HotReloadRouteFlags, every route is instead guarded by a per-request check, so flags changed at runtime take effect immediately.
+func (c *RouterConfig) applyRouteFlags(router *mux.Router) {
+	if c.RouteFlags == nil {
+		return
+	}
+
+	router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		handler := route.GetHandler()
+		if err != nil || handler == nil {
+			return nil
+		}
+
+		switch {
+		case c.HotReloadRouteFlags:
+			route.Handler(middleware.RouteDisabledMiddleware(path, c.RouteFlags)(handler))
+		case c.RouteFlags.IsRouteDisabled(path):
+			route.Handler(middleware.RouteDisabledHandler())
+		}
+		return nil
+	})
+}
+
+// ComputeCORSMethods returns the methods to announce in CORS preflight responses: the configured CORSConfig.AllowedMethods merged with every method registered on the router by SetupRoutes.
+// The result holds no duplicates, keeps the configured methods first and always includes OPTIONS, so a route added with a new method never fails its preflight.
+func (c *RouterConfig) ComputeCORSMethods() []string {
+	var methods []string
+	seen := make(map[string]bool)
+	add := func(method string) {
+		method = strings.ToUpper(method)
+		if !seen[method] {
+			seen[method] = true
+			methods = append(methods, method)
+		}
+	}
+
+	if c.CORSConfig != nil {
+		for _, method := range c.CORSConfig.AllowedMethods {
+			add(method)
+		}
+	}
+
+	if c.router != nil {
+		c.router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+			// Routes registered without Methods match any method and return an error here.
+			routeMethods, err := route.GetMethods()
+			if err != nil {
+				return nil
+			}
+			for _, method := range routeMethods {
+				add(method)
+			}
+			return nil
+		})
+	}
+
+	add(http.MethodOptions)
+	return methods
 }
 
 // NewRouter constructs and returns a *mux.Router configured with all application routes, handlers, and global middleware.
@@ -93,69 +410,205 @@ func (c *RouterConfig) SetupRoutes(router *mux.Router) {
 //  1. Instantiate handler objects for login, registration, comments, etc.
 //  2. Set up the MainPageHandler with the static directory path.
 //  3. Create and configure a MiddlewareManager, adding global middleware
-//     for logging, timing, and CORS.
+//     for panic recovery, request ID and trace context propagation, log scrubbing, logging, timing, CORS, security headers (with HSTS in production or when TLS is enabled), a 1 MB request body limit, geographic filtering, content negotiation and CSRF protection.
 //  4. Build a RouterConfig with dependencies and call SetupRoutes.
+//  5. Announce every registered method in CORS preflight responses (see ComputeCORSMethods).
 
 // Parameters:
+//   - appConfig: application configuration (CORS origins, geographic filter lists, sensitive query parameters).
 //   - userServiceLogin: service for authenticating users on login.
 //   - userServiceRegister: service for registering new users.
 //   - commentGetService: service for fetching existing comments.
 //   - commentAddService: service for adding new comments.
+//   - commentDeleteService: service for deleting comments by their authors, and any comment by administrators.
+//   - commentUpdateService: service for editing comments by their authors.
+//   - commentPinService: service for pinning comments as homepage testimonials, for administrators.
+//   - productGetService: service for browsing the watch catalogue.
+//   - userProfileService: service for reading and updating user profiles, also used to look up the users impersonated by administrators.
+//   - passwordChangeService: service for changing the authenticated user's password.
+//   - userDeletionService: service for soft-deleting the authenticated user's account.
+//   - userListService: service listing registered accounts for administrators.
 //   - rateHandler: rate limiting handler middleware for DoS protection.
+//   - csrfTokenRateHandler: looser rate limiting handler for the CSRF token endpoint.
 //   - staticFileService: adapter for serving static files from disk.
+//   - geoDB: GeoLite2 country database; nil disables geographic filtering.
+//   - idempotencyRepo: storage for responses replayed on retried POST requests.
+//   - requestLogRepo: storage for per-request records used by SLA reports; only written when logging.sla_log_enabled is set.
+//   - auditRepo: audit log recording impersonations, listed on GET /admin/audit/impersonations.
+//   - slaReportService: builds the reports served on GET /admin/metrics/sla; nil disables the endpoint.
+//   - dashboardCollector: health indicators served on GET /admin/dashboard; nil disables the endpoint.
+//   - tokenRevocationService: revokes access tokens on logout, and rejects revoked tokens in the authentication middleware.
+//   - healthDB: database pinged by GET /health, usually the application's *sqlx.DB; nil disables the endpoint.
 
 // Returns:
 //   - *mux.Router: fully configured router ready to be passed to http.ListenAndServe.
 func NewRouter(
+	appConfig *config.AppConfig,
 	userServiceLogin input.UserServiceLogin,
 	userServiceRegister input.UserServiceRegister,
 	commentGetService input.CommentGetService,
 	commentAddService input.CommentAddService,
+	commentDeleteService input.CommentDeleteService,
+	commentUpdateService input.CommentUpdateService,
+	commentPinService input.CommentPinService,
+	productGetService input.ProductGetService,
+	userProfileService input.UserProfileService,
+	passwordChangeService input.PasswordChangeService,
+	userDeletionService input.UserDeletionService,
+	userListService input.UserListService,
 	rateHandler ratelimiter.RateLimiterHandler,
+	csrfTokenRateHandler ratelimiter.RateLimiterHandler,
 	staticFileService output.StaticFilePort,
+	geoDB *maxminddb.Reader,
+	idempotencyRepo output.IdempotencyRepository,
+	requestLogRepo output.RequestLogRepository,
+	auditRepo output.AuditRepository,
+	slaReportService input.SLAReportService,
+	dashboardCollector *metrics.DashboardCollector,
+	tokenRevocationService input.TokenRevocationService,
+	healthDB DatabasePinger,
 ) *mux.Router {
 	// 1. Initialize a new router
 	router := mux.NewRouter()
 
 	// 2. Instantiate HTTP handlers with injected domain services
-	loginHandler := NewLoginHandler(userServiceLogin)
-	registerHandler := NewRegisterHandler(userServiceRegister)
+	loginHandler := NewLoginHandler(userServiceLogin, appConfig.GetAuthCookieName(), appConfig.GetRefreshTokenConfig())
+	registerHandler := NewRegisterHandler(userServiceRegister, appConfig.GetAuthCookieName(), appConfig.GetRefreshTokenConfig())
 	commentsGetHandler := NewCommentsGetHandler(commentGetService)
 	commentsAddHandler := NewCommentAddsHandler(commentAddService)
+	commentDeleteHandler := NewCommentDeleteHandler(commentDeleteService)
+	commentUpdateHandler := NewCommentUpdateHandler(commentUpdateService)
+	productsHandler := NewProductsHandler(productGetService)
 	mainPageHandler := NewMainPageHandler()
 	staticFileHandler := NewStaticFileHandler(staticFileService)
+	versionHandler := NewVersionHandler(appConfig.GetPort())
+	profileHandler := NewProfileHandler(userProfileService)
+	passwordChangeHandler := NewPasswordChangeHandler(passwordChangeService)
+	csrfSecret := []byte(appConfig.GetCSRFSecret())
+	csrfTokenHandler := NewCSRFTokenHandler(appConfig.IsProduction(), csrfSecret)
+	whoAmIHandler := NewWhoAmIHandler()
+	logoutHandler := NewLogoutHandler(tokenRevocationService, appConfig.GetAuthCookieName(), appConfig.GetRefreshTokenConfig())
+	userDeletionHandler := NewUserDeletionHandler(userDeletionService, tokenRevocationService, appConfig.GetAuthCookieName(), appConfig.GetRefreshTokenConfig())
+	var adminDashboardHandler *AdminDashboardHandler
+	if dashboardCollector != nil {
+		adminDashboardHandler = NewAdminDashboardHandler(dashboardCollector)
+	}
+	adminConfigHandler := NewAdminConfigHandler(appConfig)
+	adminUsersHandler := NewAdminUsersHandler(userListService)
+	adminImpersonateHandler := NewAdminImpersonateHandler(userProfileService, auditRepo, appConfig.GetAuthCookieName(), appConfig.IsProduction())
+	adminAuditHandler := NewAdminAuditHandler(auditRepo)
+	adminCommentDeleteHandler := NewAdminCommentDeleteHandler(commentDeleteService)
+	adminCommentPinHandler := NewAdminCommentPinHandler(commentPinService)
+	var adminSLAHandler *AdminSLAHandler
+	if slaReportService != nil {
+		adminSLAHandler = NewAdminSLAHandler(slaReportService)
+	}
+	var healthHandler *HealthHandler
+	if healthDB != nil {
+		healthHandler = NewHealthHandler(healthDB)
+	}
 
-	// 3. Configure main page handler with static directory
+	// 3. Configure main page handler with static directory and asset versioning, and the SPA fallback of static files
 	mainPageHandler.SetStaticDir(staticFileService.GetStaticDir())
+	mainPageHandler.SetStaticFileService(staticFileService)
+	if appConfig.GetSPAMode() {
+		staticFileHandler.WithSPAFallback(http.HandlerFunc(mainPageHandler.HandleSPAFallback))
+	}
 
 	// 4. Create and configure MiddlewareManager
 	middlewareManager := middleware.NewMiddlewareManager()
 	timingConfig := middleware.DefaultTimingConfig()
 	timingConfig.WarningThreshold = 200 * 1000 * 1000 // 200 milliseconds
 
+	authOptions := middleware.DefaultAuthOptions(appConfig)
+	authOptions.RevokedTokens = tokenRevocationService
+
 	corsConfig := middleware.DefaultCORSConfig()
-	// corsCfg.AllowedOrigins = []string{"https://example.com"} // customize as needed
+	corsConfig.AllowedOrigins = appConfig.GetCORSAllowedOrigins()
+	corsConfig.ExposedHeaders = append(corsConfig.ExposedHeaders, middleware.RequestIDHeader, middleware.CSRFHeaderName)
 
-	// Add global middleware: logging, timing, CORS
-	middlewareManager.AddGlobal(middleware.LoggingMiddleware)
+	// With TLS enabled the application owns HTTPS, so HSTS is sent in every environment and made eligible for preloading.
+	securityHeadersConfig := middleware.DefaultSecurityHeadersConfig(appConfig.IsProduction())
+	if appConfig.IsTLSEnabled() {
+		securityHeadersConfig.HSTS = middleware.DefaultHSTSConfig()
+	}
+
+	geoFilterMW := middleware.GeoFilterMiddleware(
+		geoDB,
+		appConfig.GetGeoBlockedCountries(),
+		middleware.WithCountryAllowlist(appConfig.GetGeoAllowedCountries()),
+	)
+
+	// Add global middleware: panic recovery (outermost), request ID and trace context, log scrubbing, logging, timing, CORS, security headers, body size limit, geographic filtering, content negotiation, CSRF protection
+	middlewareManager.AddGlobal(middleware.RecoveryMiddleware(slog.Default()))
+	middlewareManager.AddGlobal(middleware.RequestIDMiddleware())
+	middlewareManager.AddGlobal(middleware.SensitiveFieldScrubber(appConfig.GetSensitiveQueryParams()))
+	if appConfig.GetSLALogEnabled() && requestLogRepo != nil {
+		middlewareManager.AddGlobal(middleware.RequestLogMiddleware(requestLogRepo))
+	} else {
+		middlewareManager.AddGlobal(middleware.LoggingMiddleware)
+	}
 	middlewareManager.AddGlobal(middleware.TimingMiddleware(timingConfig))
 	middlewareManager.AddGlobal(middleware.CORSMiddleware(corsConfig))
+	middlewareManager.AddGlobal(middleware.SecurityHeadersMiddleware(securityHeadersConfig))
+	middlewareManager.AddGlobal(middleware.DefaultBodyLimitMiddleware)
+	middlewareManager.AddGlobal(geoFilterMW)
+	middlewareManager.AddGlobal(middleware.ContentNegotiationMiddleware())
+	middlewareManager.AddGlobal(middleware.CSRFMiddleware(
+		csrfSecret,
+		middleware.WithCSRFExcludedPaths(middleware.DefaultCSRFExcludedPaths()),
+		middleware.WithCSRFSecureCookie(appConfig.IsProduction()),
+	))
 	middlewareManager.ApplyToRouter(router)
 
 	// 5. Build RouterConfig with dependencies
 	config := &RouterConfig{
-		IPExtractor:        &ratelimiter.DefaultIPExtractor{},
-		RateLimiter:        rateHandler,
-		LoginHandler:       loginHandler,
-		RegisterHandler:    registerHandler,
-		CommentsGetHandler: commentsGetHandler,
-		CommentsAddHandler: commentsAddHandler,
-		MainPageHandler:    mainPageHandler,
-		StaticFileHandler:  staticFileHandler,
-		MiddlewareManager:  middlewareManager,
+		IPExtractor:               &ratelimiter.DefaultIPExtractor{},
+		RateLimiter:               rateHandler,
+		CSRFTokenRateLimiter:      csrfTokenRateHandler,
+		LoginHandler:              loginHandler,
+		RegisterHandler:           registerHandler,
+		CommentsGetHandler:        commentsGetHandler,
+		CommentsAddHandler:        commentsAddHandler,
+		CommentDeleteHandler:      commentDeleteHandler,
+		CommentUpdateHandler:      commentUpdateHandler,
+		ProductsHandler:           productsHandler,
+		MainPageHandler:           mainPageHandler,
+		StaticFileHandler:         staticFileHandler,
+		MiddlewareManager:         middlewareManager,
+		IdempotencyRepository:     idempotencyRepo,
+		AuthOptions:               authOptions,
+		VersionHandler:            versionHandler,
+		ProfileHandler:            profileHandler,
+		PasswordChangeHandler:     passwordChangeHandler,
+		UserDeletionHandler:       userDeletionHandler,
+		CSRFTokenHandler:          csrfTokenHandler,
+		WhoAmIHandler:             whoAmIHandler,
+		LogoutHandler:             logoutHandler,
+		AdminDashboardHandler:     adminDashboardHandler,
+		AdminConfigHandler:        adminConfigHandler,
+		AdminUsersHandler:         adminUsersHandler,
+		AdminImpersonateHandler:   adminImpersonateHandler,
+		AdminAuditHandler:         adminAuditHandler,
+		AdminCommentDeleteHandler: adminCommentDeleteHandler,
+		AdminCommentPinHandler:    adminCommentPinHandler,
+		AdminSLAHandler:           adminSLAHandler,
+		HealthHandler:             healthHandler,
+		IsProduction:              appConfig.IsProduction(),
+		RouteFlags:                appConfig,
+		HotReloadRouteFlags:       appConfig.IsHotReloadEnabled(),
+		DeduplicationTTL:          appConfig.GetDeduplicationTTL(),
+		ResponseBufferBytes:       appConfig.GetResponseBufferBytes(),
+		SwaggerUIDir:              appConfig.GetSwaggerUIDir(),
+		IsDebugMode:               appConfig.IsDebugMode(),
+		SPAMode:                   appConfig.GetSPAMode(),
+		CORSConfig:                corsConfig,
 	}
 
 	// 6. Register routes on router
 	config.SetupRoutes(router)
+
+	// 7. The CORS middleware reads its config on every request, so updating it here covers all routes.
+	corsConfig.AllowedMethods = config.ComputeCORSMethods()
 	return router
 }
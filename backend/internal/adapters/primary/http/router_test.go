@@ -0,0 +1,105 @@
+package http
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/static"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/config"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	ratelimiter "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/rate_limiter"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/gorilla/mux"
+)
+
+func TestComputeCORSMethodsIncludesRegisteredMethods(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/comments", noop).Methods("GET")
+	router.HandleFunc("/comments/{id}", noop).Methods("DELETE")
+	router.HandleFunc("/auth/profile", noop).Methods("GET", "PATCH")
+
+	config := &RouterConfig{
+		CORSConfig: &middleware.CORSConfig{AllowedMethods: []string{"GET", "post"}},
+		router:     router,
+	}
+
+	got := config.ComputeCORSMethods()
+	want := []string{"GET", "POST", "DELETE", "PATCH", "OPTIONS"}
+	if len(got) != len(want) {
+		t.Fatalf("ComputeCORSMethods() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ComputeCORSMethods() = %v, want %v", got, want)
+		}
+	}
+}
+
+// panickingProfileService panics on every call, standing in for a handler bug.
+type panickingProfileService struct{}
+
+func (panickingProfileService) GetProfile(userID int) (models.UserProfile, error) {
+	panic("profile lookup failed")
+}
+
+func (panickingProfileService) UpdateDisplayName(userID int, displayName string) (models.UserProfile, error) {
+	panic("profile update failed")
+}
+
+func TestNewRouterLogsPanicsWithUserAndRequestID(t *testing.T) {
+	var logged bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logged, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	securityAuth.SetDefaultJWTService("0123456789abcdef0123456789abcdef", securityAuth.DefaultClockSkewTolerance)
+	token, err := securityAuth.GenerateJWT(7, "alice", models.RoleUser)
+	if err != nil {
+		t.Fatalf("GenerateJWT failed: %v", err)
+	}
+
+	appConfig := config.NewAppConfig()
+	newRateLimiter := func() ratelimiter.RateLimiterHandler {
+		manager := ratelimiter.NewRateLimiterManager()
+		manager.SetDefaultLimiterConfig(appConfig.GetRateLimitConfig())
+		return ratelimiter.NewRateLimiterWithManager(manager)
+	}
+	router := NewRouter(
+		appConfig,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+		panickingProfileService{},
+		nil, nil, nil,
+		newRateLimiter(),
+		newRateLimiter(),
+		static.NewStaticFileAdapter(t.TempDir()),
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/profile", nil)
+	req.Header.Set(middleware.RequestIDHeader, "panic-test-request")
+	req.AddCookie(&http.Cookie{Name: appConfig.GetAuthCookieName(), Value: token})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status %d, Got %d", http.StatusInternalServerError, rec.Code)
+	}
+	var record string
+	for _, line := range strings.Split(logged.String(), "\n") {
+		if strings.Contains(line, "panic recovered") {
+			record = line
+		}
+	}
+	for _, want := range []string{"user_id=7", "request_id=panic-test-request"} {
+		if !strings.Contains(record, want) {
+			t.Errorf("Expected the panic log record to contain %s, Got: %s", want, record)
+		}
+	}
+}
@@ -5,10 +5,15 @@ package http
 
 import (
 	"net/http"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
 	"github.com/gorilla/mux"
 )
 
@@ -19,6 +24,7 @@ import (
 type StaticFileHandler struct {
 	staticFileService output.StaticFilePort
 	allowedExtensions map[string]bool
+	spaFallback       http.Handler
 }
 
 
@@ -49,31 +55,42 @@ func NewStaticFileHandler(staticFileService output.StaticFilePort) *StaticFileHa
 	}
 }
 
+// WithSPAFallback makes HandleStaticFile pass requests for paths without a file extension to fallback (typically MainPageHandler.HandleSPAFallback) instead of rejecting them.
+// It returns the handler so the call can be chained after NewStaticFileHandler.
+func (h *StaticFileHandler) WithSPAFallback(fallback http.Handler) *StaticFileHandler {
+	h.spaFallback = fallback
+	return h
+}
+
 // RegisterRoutes configures the routes for serving static files.
 
-// It registers specific directory routes for common static asset folders (e.g. "/css/", "/js/", "/assets/") and defines a route for serving individual static files.
+// It registers specific directory routes for common static asset folders (e.g. "/css/", "/js/", "/assets/"), the asset version manifest, and a route for serving individual static files.
 func (h *StaticFileHandler) RegisterRoutes(router *mux.Router) {
 	// Register static directories using defined prefixes.
 	h.registerStaticDir(router, "/css/", "css")
 	h.registerStaticDir(router, "/js/", "js")
 	h.registerStaticDir(router, "/assets/", "assets")
 
+	// Register the version manifest before the catch-all static file route.
+	router.HandleFunc("/static/manifest.json", h.HandleManifest).Methods("GET")
+
 	// Register route for individual static files.
 	router.HandleFunc("/static/{*}", h.HandleStaticFile)
 }
 
 // registerStaticDir registers a route that serves files from a specified static directory.
 
-// It creates a file handler using the static file service, and sets up a route with the given prefix that serves files from the specified directory.
+// It creates a file handler using the static file service, and sets up a route with the given prefix that serves files from the specified directory. Missing files and directories are answered with JSON problem details (see jsonStaticErrors).
+// Cache-Control is set by StaticCachingMiddleware, so fingerprinted files are cached as immutable.
 func (h *StaticFileHandler) registerStaticDir(router *mux.Router, prefix, dir string) {
 	handler := h.staticFileService.GetFileHandler(prefix, dir)
-	router.PathPrefix(prefix).Handler(handler)
+	router.PathPrefix(prefix).Handler(middleware.StaticCachingMiddleware()(jsonStaticErrors(handler)))
 }
 
 // HandleStaticFile handles HTTP requests for individual static files.
 
 // It extracts the requested file path, validates the file extension against the allowed list, and checks if the file path is valid via the static file service. If the file passes validation, it sets the appropriate Content-Type header and serves the file.
-// If the file is not allowed or not found, it responds with an error.
+// If the file is not allowed or not found, it responds with a JSON problem detail. With an SPA fallback configured, paths without an extension are navigational requests and are passed to the fallback instead.
 func (h *StaticFileHandler) HandleStaticFile(w http.ResponseWriter, r *http.Request) {
 	// Extract the file path from the URL variables.
 	vars := mux.Vars(r)
@@ -81,14 +98,18 @@ func (h *StaticFileHandler) HandleStaticFile(w http.ResponseWriter, r *http.Requ
 
 	// Validate the file extension.
 	ext := strings.ToLower(filepath.Ext(path))
+	if ext == "" && h.spaFallback != nil {
+		h.spaFallback.ServeHTTP(w, r)
+		return
+	}
 	if _, ok := h.allowedExtensions[ext]; !ok {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+		httpUtil.WriteError(w, errors.NewForbiddenError(errors.ErrForbidden))
 		return
 	}
 
 	// Ensure the requested path is valid.
 	if !h.staticFileService.IsValidPath(path) {
-		http.NotFound(w, r)
+		httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrStaticAssetNotFound))
 		return
 	}
 
@@ -98,5 +119,88 @@ func (h *StaticFileHandler) HandleStaticFile(w http.ResponseWriter, r *http.Requ
 
 	// Retrieve the file handler and serve the file.
 	handler := h.staticFileService.GetFileHandler("/static/", "")
-	handler.ServeHTTP(w, r)
+	jsonStaticErrors(handler).ServeHTTP(w, r)
+}
+
+// RegisterDocsRoute serves the Swagger UI distribution found in swaggerUIDir under /docs/, wrapped in the given middlewares.
+
+// GET /docs/ serves the UI's index.html and GET /docs/openapi.json the spec bundled in the same directory; /docs redirects to /docs/. Directories are never listed, and missing files are answered with JSON problem details.
+func (h *StaticFileHandler) RegisterDocsRoute(router *mux.Router, swaggerUIDir string, middlewares ...middleware.Middleware) {
+	router.Handle("/docs", http.RedirectHandler("/docs/", http.StatusMovedPermanently)).Methods("GET")
+
+	docs := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/docs/")
+		if name == "" {
+			name = "index.html"
+		}
+
+		// path.Clean on a rooted path drops any ".." segments, keeping the file inside swaggerUIDir.
+		fullPath := filepath.Join(swaggerUIDir, filepath.FromSlash(path.Clean("/"+name)))
+		info, err := os.Stat(fullPath)
+		if err != nil || info.IsDir() {
+			httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrStaticAssetNotFound))
+			return
+		}
+		http.ServeFile(w, r, fullPath)
+	})
+	router.PathPrefix("/docs/").Handler(middleware.Chain(middlewares...)(docs)).Methods("GET")
+}
+
+// HandleManifest serves GET /static/manifest.json.
+
+// It responds with a JSON object mapping the URL path of every servable static file (those with an allowed extension) to its content-hash versioned URL. The manifest must be revalidated on every use, since it changes whenever an asset does.
+func (h *StaticFileHandler) HandleManifest(w http.ResponseWriter, r *http.Request) {
+	manifest, err := h.staticFileService.VersionManifest()
+	if err != nil {
+		httpUtil.WriteError(w, errors.NewInternalError(errors.ErrInternalServer).WithError(err))
+		return
+	}
+
+	for path := range manifest {
+		if !h.allowedExtensions[strings.ToLower(filepath.Ext(path))] {
+			delete(manifest, path)
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	httpUtil.SendJSONResponse(w, http.StatusOK, manifest)
+}
+
+// jsonStaticErrors wraps a file server so that its plain-text 404 and 403 responses are replaced by JSON problem details sent with httpUtil.WriteError.
+func jsonStaticErrors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		interceptor := &staticErrorInterceptor{ResponseWriter: w}
+		next.ServeHTTP(interceptor, r)
+
+		switch interceptor.status {
+		case http.StatusNotFound:
+			httpUtil.WriteError(w, errors.NewNotFoundError(errors.ErrStaticAssetNotFound))
+		case http.StatusForbidden:
+			httpUtil.WriteError(w, errors.NewForbiddenError(errors.ErrForbidden))
+		}
+	})
+}
+
+// staticErrorInterceptor is a ResponseWriter that swallows 404 and 403 responses, including their body, so jsonStaticErrors can replace them.
+// Any other response is passed through unchanged.
+type staticErrorInterceptor struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records 404 and 403 status codes instead of sending them.
+func (i *staticErrorInterceptor) WriteHeader(code int) {
+	if code == http.StatusNotFound || code == http.StatusForbidden {
+		i.status = code
+		return
+	}
+	i.ResponseWriter.WriteHeader(code)
+}
+
+// Write discards the body of an intercepted response.
+func (i *staticErrorInterceptor) Write(b []byte) (int, error) {
+	if i.status != 0 {
+		return len(b), nil
+	}
+	return i.ResponseWriter.Write(b)
 }
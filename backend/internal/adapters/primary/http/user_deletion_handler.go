@@ -0,0 +1,51 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the UserDeletionHandler, which lets users delete their own account.
+package http
+
+import (
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// UserDeletionHandler serves DELETE /users/me.
+type UserDeletionHandler struct {
+	userDeletionService    input.UserDeletionService
+	tokenRevocationService input.TokenRevocationService
+	authCookieName         string
+	refreshTokens          models.RefreshTokenConfig
+}
+
+// NewUserDeletionHandler creates a new instance of UserDeletionHandler.
+
+// Besides the deletion service, it receives what it needs to end the session like LogoutHandler: the token revocation service, the name of the authentication cookie and the refresh token settings.
+func NewUserDeletionHandler(userDeletionService input.UserDeletionService, tokenRevocationService input.TokenRevocationService, authCookieName string, refreshTokens models.RefreshTokenConfig) *UserDeletionHandler {
+	return &UserDeletionHandler{
+		userDeletionService:    userDeletionService,
+		tokenRevocationService: tokenRevocationService,
+		authCookieName:         authCookieName,
+		refreshTokens:          refreshTokens,
+	}
+}
+
+// Handle soft-deletes the authenticated user's account, revokes the session's tokens, clears their cookies and responds with HTTP 204 (No Content).
+// It responds with 404 if the account no longer exists.
+func (h *UserDeletionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.GetUserIdContextKey()).(int)
+	if !ok {
+		httpUtil.WriteError(w, errors.NewAuthError(errors.ErrUnauthorized))
+		return
+	}
+
+	if err := h.userDeletionService.DeleteAccount(userID); err != nil {
+		httpUtil.WriteError(w, err)
+		return
+	}
+
+	endSession(w, r, h.tokenRevocationService, h.authCookieName, h.refreshTokens)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// stubUserDeletionService deletes only the account with existingID.
+type stubUserDeletionService struct {
+	existingID int
+	deleted    []int
+}
+
+func (s *stubUserDeletionService) DeleteAccount(userID int) error {
+	if userID != s.existingID {
+		return errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	s.deleted = append(s.deleted, userID)
+	return nil
+}
+
+func TestUserDeletionHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      int
+		wantStatus  int
+		wantRevoked bool
+	}{
+		{name: "deleted", userID: 7, wantStatus: http.StatusNoContent, wantRevoked: true},
+		{name: "already deleted", userID: 8, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &stubUserDeletionService{existingID: 7}
+			revocation := &recordingRevocationService{}
+			handler := NewUserDeletionHandler(service, revocation, "token", models.RefreshTokenConfig{})
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/me", nil)
+			req.AddCookie(&http.Cookie{Name: "token", Value: "session-token"})
+			req = req.WithContext(context.WithValue(req.Context(), middleware.GetUserIdContextKey(), tt.userID))
+			rec := httptest.NewRecorder()
+			handler.Handle(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, Got %d", tt.wantStatus, rec.Code)
+			}
+			if revoked := len(revocation.revoked) == 1; revoked != tt.wantRevoked {
+				t.Errorf("Expected token revoked: %v, Got %v", tt.wantRevoked, revocation.revoked)
+			}
+			if cleared := len(rec.Result().Cookies()) == 1; cleared != tt.wantRevoked {
+				t.Errorf("Expected cookie cleared: %v, Got %v", tt.wantRevoked, rec.Result().Cookies())
+			}
+		})
+	}
+}
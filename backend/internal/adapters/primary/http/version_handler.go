@@ -0,0 +1,29 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the VersionHandler, which reports the build metadata of the running binary.
+package http
+
+import (
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/buildinfo"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// VersionHandler serves GET /version.
+type VersionHandler struct {
+	port string
+}
+
+// NewVersionHandler creates a new instance of VersionHandler.
+
+// It receives the configured server port so it can be reported alongside the build metadata.
+func NewVersionHandler(port string) *VersionHandler {
+	return &VersionHandler{
+		port: port,
+	}
+}
+
+// Handle returns the version, commit, build time, Go version, platform and port as JSON with an HTTP 200 (OK) status.
+func (h *VersionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	httpUtil.SendJSONResponse(w, http.StatusOK, buildinfo.Get(h.port))
+}
@@ -0,0 +1,40 @@
+// Package http implements HTTP handlers for the sale-watches application.
+// This file contains the WhoAmIHandler, which reports the identity behind the current session.
+package http
+
+import (
+	"net/http"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http/middleware"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+// WhoAmIHandler serves GET /auth/whoami.
+type WhoAmIHandler struct{}
+
+// NewWhoAmIHandler creates a new instance of WhoAmIHandler.
+func NewWhoAmIHandler() *WhoAmIHandler {
+	return &WhoAmIHandler{}
+}
+
+// whoAmIResponse is the JSON body returned by GET /auth/whoami.
+type whoAmIResponse struct {
+	UserID         int `json:"user_id"`
+	ImpersonatedBy int `json:"impersonated_by,omitempty"`
+}
+
+// Handle returns the effective user of the session and, when the session is an impersonation, the ID of the impersonating user, e.g. {"user_id": 42, "impersonated_by": 1}.
+func (h *WhoAmIHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.GetUserIdContextKey()).(int)
+	if !ok {
+		httpUtil.WriteError(w, errors.NewAuthError(errors.ErrUnauthorized))
+		return
+	}
+
+	response := whoAmIResponse{UserID: userID}
+	if impersonatorID, ok := middleware.GetImpersonatedBy(r.Context()); ok {
+		response.ImpersonatedBy = impersonatorID
+	}
+	httpUtil.SendJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,43 @@
+// Package repository provides SQL-based implementations of output ports for data persistence.
+// This file contains SQLAppSettingsRepository, which implements AppSettingsRepository on the app_settings table.
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// SQLAppSettingsRepository implements output.AppSettingsRepository using a SQL database.
+
+// It expects an app_settings table with the columns SettingKey (primary key) and BoolValue.
+type SQLAppSettingsRepository struct {
+	dbUtil.BaseRepository[bool]
+}
+
+// NewSQLAppSettingsRepository creates a new SQLAppSettingsRepository.
+// It fatally logs and exits if the provided db is nil, indicating a critical configuration error.
+func NewSQLAppSettingsRepository(db dbUtil.Queryer) output.AppSettingsRepository {
+	if db == nil {
+		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
+	}
+
+	return &SQLAppSettingsRepository{
+		BaseRepository: dbUtil.NewBaseRepository[bool](db, errors.ErrSettingNotFound, errors.ErrDatabaseInsert),
+	}
+}
+
+// GetBool reads the BoolValue of the setting, returning false when the key is not stored.
+func (r *SQLAppSettingsRepository) GetBool(key string) (bool, error) {
+	value, err := r.FindOne(context.Background(), "SELECT BoolValue FROM app_settings WHERE SettingKey = ?", key)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return value, nil
+}
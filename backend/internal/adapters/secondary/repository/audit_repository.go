@@ -0,0 +1,68 @@
+// Package repository provides SQL-based implementations of output ports for data persistence.
+// This file contains SQLAuditRepository, which implements AuditRepository on the audit_log table.
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// SQLAuditRepository implements output.AuditRepository using a SQL database.
+
+// It expects an audit_log table with the columns ID (auto-increment), Action, ActorID, UserID, Reason and CreatedAt (DATETIME); see migrations/014_audit_log.up.sql.
+type SQLAuditRepository struct {
+	dbUtil.BaseRepository[models.AuditEntry]
+}
+
+// NewSQLAuditRepository creates a new SQLAuditRepository.
+// It fatally logs and exits if the provided db is nil, indicating a critical configuration error.
+func NewSQLAuditRepository(db dbUtil.Queryer) output.AuditRepository {
+	if db == nil {
+		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
+	}
+
+	return &SQLAuditRepository{
+		BaseRepository: dbUtil.NewBaseRepository[models.AuditEntry](db, errors.ErrAuditEntryNotFound, errors.ErrDatabaseInsert),
+	}
+}
+
+// Record inserts the entry, storing CreatedAt in UTC.
+func (r *SQLAuditRepository) Record(entry models.AuditEntry) error {
+	const query = `INSERT INTO audit_log (Action, ActorID, UserID, Reason, CreatedAt) VALUES (?, ?, ?, ?, ?)`
+
+	_, err := r.Exec(context.Background(), query, entry.Action, entry.ActorID, entry.UserID, entry.Reason, entry.CreatedAt.UTC())
+	return err
+}
+
+// List selects the matching entries ordered by CreatedAt, then ID, descending. Only the conditions set in filter are added to the WHERE clause.
+func (r *SQLAuditRepository) List(filter models.AuditFilter) ([]models.AuditEntry, error) {
+	query := `SELECT ID, Action, ActorID, UserID, Reason, CreatedAt FROM audit_log WHERE Action = ?`
+	args := []interface{}{filter.Action}
+	if filter.UserID != 0 {
+		query += ` AND UserID = ?`
+		args = append(args, filter.UserID)
+	}
+	if !filter.From.IsZero() {
+		query += ` AND CreatedAt >= ?`
+		args = append(args, filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		query += ` AND CreatedAt < ?`
+		args = append(args, filter.To.UTC())
+	}
+	query += ` ORDER BY CreatedAt DESC, ID DESC`
+
+	entries, err := r.FindMany(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []models.AuditEntry{}
+	}
+	return entries, nil
+}
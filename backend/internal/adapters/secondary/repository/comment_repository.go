@@ -3,72 +3,99 @@
 package repository
 
 import (
+	"context"
 	"log"
 
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
 	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
-	"github.com/jmoiron/sqlx"
 )
 
 // SqlCommentRepository implements output.CommentRepository using a SQL database.
-// It uses sqlx for database interactions and expects a valid *sqlx.DB connection.
+// It uses sqlx for database interactions and expects a valid dbUtil.Queryer (a *sqlx.DB or a query-logging wrapper around it).
 //
 // Fields:
-//   - db: *sqlx.DB instance for executing queries.
+//   - BaseRepository: runs comment queries and reports a missing comment as ErrCommentNotFound.
 type SqlCommentRepository struct {
-	db *sqlx.DB
+	dbUtil.BaseRepository[models.Comment]
 }
 
 // NewSqlCommentRepository creates a new SqlCommentRepository.
 // It fatally logs and exits if the provided db is nil, indicating a critical configuration error.
 
 // Parameters:
-//   - db: *sqlx.DB connection to the comments database.
+//   - db: dbUtil.Queryer connected to the comments database.
 
 // Returns:
 //   - output.CommentRepository: initialized repository instance.
-func NewSqlCommentRepository(db *sqlx.DB) output.CommentRepository {
+func NewSqlCommentRepository(db dbUtil.Queryer) output.CommentRepository {
 	if db == nil {
 		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
 	}
 
 	return &SqlCommentRepository{
-		db: db,
+		BaseRepository: dbUtil.NewBaseRepository[models.Comment](db, errors.ErrCommentNotFound, errors.ErrCommentCreation),
 	}
 }
 
-// GetComments retrieves all comments from the database, ordered by date descending.
-// It performs a JOIN with the user_registration table to include the commenter's username.
-
-// Returns:
-//   - []models.Comment: slice of Comment models containing ID, Date, Content, UserID, UserName, and Rating.
-//   - error: non-nil if the query fails, wrapped as an InternalError.
-func(r *SqlCommentRepository) GetComments() ([]models.Comment, error) {
-	var comment []models.Comment
-	// Define SQL query to select comments and join with user table.
-	const sqlQuery = `
+// selectCommentsBase selects comments joined with the user table, without filtering or ordering.
+// Anonymous comments (UserID 0) match no user and are named models.AnonymousUserName.
+const selectCommentsBase = `
 	SELECT 
 		c.ID,
 		c.Date,
 		c.Content,
 		c.UserID,
-		u.UserName AS UserName,
-		c.Rating
+		COALESCE(p.DisplayName, u.UserName, '` + models.AnonymousUserName + `') AS UserName,
+		c.Rating,
+		c.Pinned
 	FROM comments c
-	JOIN user_registration u
+	LEFT JOIN user_registration u
 		ON c.UserID = u.UserID
-		ORDER BY c.Date DESC
+	LEFT JOIN user_profiles p
+		ON p.UserID = u.UserID
+	`
+
+// selectCommentsQuery selects all comments, pinned ones first, then newest first.
+const selectCommentsQuery = selectCommentsBase + `
+		ORDER BY c.Pinned DESC, c.Date DESC
 	`
 
-	// Execute the query and scan results into comments slice.
-	err := r.db.Select(&comment, sqlQuery)
+// GetComments retrieves all comments from the database, pinned comments first, then ordered by date descending.
+// It performs a LEFT JOIN with the user_registration and user_profiles tables to include the commenter's display name (falling back to the login username, or to "Anonymous" for anonymous comments).
+
+// Returns:
+//   - []models.Comment: slice of Comment models containing ID, Date, Content, UserID, UserName, and Rating.
+//   - error: non-nil if the query fails, wrapped as an InternalError.
+func(r *SqlCommentRepository) GetComments() ([]models.Comment, error) {
+	return r.FindMany(context.Background(), selectCommentsQuery)
+} 
+
+// GetCommentsPaginated retrieves one page of comments, pinned comments first, then ordered by date descending, together with the total number of comments.
+// The page is selected with LIMIT/OFFSET; a page beyond the last one returns no comments but still reports the total.
+
+// Parameters:
+//   - page: 1-based page index.
+//   - pageSize: maximum number of comments per page.
+
+// Returns:
+//   - []models.Comment: comments of the requested page.
+//   - int: number of comments across all pages.
+//   - error: non-nil if either query fails, wrapped as an InternalError.
+func (r *SqlCommentRepository) GetCommentsPaginated(page, pageSize int) ([]models.Comment, int, error) {
+	var total int
+	if err := r.Get(&total, "SELECT COUNT(*) FROM comments"); err != nil {
+		return nil, 0, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+	}
+
+	offset := (page - 1) * pageSize
+	comments, err := r.FindMany(context.Background(), selectCommentsQuery+" LIMIT ? OFFSET ?", pageSize, offset)
 	if err != nil {
-		// Wrap low-level DB error in a domain-friendly InternalError.
-		return nil, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+		return nil, 0, err
 	}
-	return comment, nil
-} 
+	return comments, total, nil
+}
 
 // SaveComment inserts a new comment into the database with the current timestamp.
 // It uses parameterized queries to prevent SQL injection.
@@ -85,10 +112,191 @@ func(r *SqlCommentRepository) SaveComment(userID int, content string, rating int
 	VALUES (?, ?, ?, NOW())`
 
 	// Execute the insert query with provided parameters.
-	_, err := r.db.Exec(query, userID, content, rating)
+	_, err := r.Exec(context.Background(), query, userID, content, rating)
+	return err
+}
+
+// ratingCount is a single row of the rating histogram query.
+type ratingCount struct {
+	Rating int `db:"Rating"`
+	Count  int `db:"Count"`
+}
+
+// GetRatingHistogram counts comments per rating with a GROUP BY query.
+
+// Returns:
+//   - map[int]int: number of comments for each rating present in the table.
+//   - error: non-nil if the query fails, wrapped as an InternalError.
+func (r *SqlCommentRepository) GetRatingHistogram() (map[int]int, error) {
+	var rows []ratingCount
+	const query = `SELECT Rating, COUNT(*) AS Count FROM comments GROUP BY Rating`
+
+	if err := r.Select(&rows, query); err != nil {
+		return nil, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+	}
+
+	histogram := make(map[int]int, len(rows))
+	for _, row := range rows {
+		histogram[row.Rating] = row.Count
+	}
+	return histogram, nil
+}
+
+// GetPinned retrieves the pinned comments, most recently pinned first.
+
+// Returns:
+//   - []models.Comment: the pinned comments.
+//   - error: non-nil if the query fails, wrapped as an InternalError.
+func (r *SqlCommentRepository) GetPinned() ([]models.Comment, error) {
+	return r.FindMany(context.Background(), selectCommentsBase+" WHERE c.Pinned = TRUE ORDER BY c.PinnedAt DESC")
+}
+
+// Pin marks a comment as pinned, unless models.MaxPinnedComments comments are already pinned.
+// The limit is checked in the UPDATE itself, so concurrent pins cannot exceed it. When no row changes, the comment is looked up to tell a missing comment (NotFoundError) from an already pinned one (no-op) and a full pin list (ConflictError).
+
+// Parameters:
+//   - commentID: ID of the comment to pin.
+
+// Returns:
+//   - error: NotFoundError, ConflictError, or an InternalError if a query fails.
+func (r *SqlCommentRepository) Pin(commentID int) error {
+	// The pinned count is read through a derived table, since MySQL cannot select from the table being updated.
+	const query = `UPDATE comments SET Pinned = TRUE, PinnedAt = NOW()
+	WHERE ID = ? AND Pinned = FALSE
+		AND (SELECT COUNT(*) FROM (SELECT ID FROM comments WHERE Pinned = TRUE) AS pinned) < ?`
+
+	result, err := r.Exec(context.Background(), query, commentID, models.MaxPinnedComments)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewInternalError(errors.ErrCommentUpdate).WithError(err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	comment, err := r.FindOne(context.Background(), "SELECT Pinned FROM comments WHERE ID = ?", commentID)
+	switch {
+	case err != nil:
+		return err
+	case comment.Pinned:
+		return nil
+	default:
+		return errors.NewConflictError(errors.ErrMaxPinnedComments)
+	}
+}
+
+// Unpin clears the pinned mark of a comment. Unpinning a comment that is not pinned is a no-op.
+
+// Parameters:
+//   - commentID: ID of the comment to unpin.
+
+// Returns:
+//   - error: NotFoundError if the comment does not exist, or an InternalError if a query fails.
+func (r *SqlCommentRepository) Unpin(commentID int) error {
+	if _, err := r.FindOne(context.Background(), "SELECT ID FROM comments WHERE ID = ?", commentID); err != nil {
+		return err
+	}
+
+	_, err := r.Exec(context.Background(), "UPDATE comments SET Pinned = FALSE, PinnedAt = NULL WHERE ID = ?", commentID)
+	return err
+}
+
+// DeleteComment deletes a comment of the requesting user.
+// Ownership is part of the DELETE's WHERE clause, so the database enforces it. When no row is deleted, the comment is looked up to tell a missing comment (NotFoundError) from another user's (ForbiddenError).
+
+// Parameters:
+//   - commentID: ID of the comment to delete.
+//   - requestingUserID: ID of the authenticated user.
+
+// Returns:
+//   - error: NotFoundError, ForbiddenError, or an InternalError if a query fails.
+func (r *SqlCommentRepository) DeleteComment(commentID, requestingUserID int) error {
+	result, err := r.Exec(context.Background(), "DELETE FROM comments WHERE ID = ? AND UserID = ?", commentID, requestingUserID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewInternalError(errors.ErrCommentDelete).WithError(err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	if _, err := r.FindOne(context.Background(), "SELECT ID FROM comments WHERE ID = ?", commentID); err != nil {
+		return err
+	}
+	return errors.NewForbiddenError(errors.ErrCommentNotOwned)
+}
+
+// ForceDeleteComment deletes a comment without checking who wrote it, as administrators do when moderating content.
+
+// Parameters:
+//   - commentID: ID of the comment to delete.
+
+// Returns:
+//   - error: NotFoundError if no comment was deleted, or an InternalError if the statement fails.
+func (r *SqlCommentRepository) ForceDeleteComment(commentID int) error {
+	result, err := r.Exec(context.Background(), "DELETE FROM comments WHERE ID = ?", commentID)
 	if err != nil {
-		// Return a generic InternalError on failure.
-		return errors.NewInternalError("Error querying the database")
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewInternalError(errors.ErrCommentDelete).WithError(err)
+	}
+	if rows == 0 {
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// GetComment retrieves a single comment with the same columns as GetComments.
+
+// Parameters:
+//   - commentID: ID of the comment.
+
+// Returns:
+//   - models.Comment: the comment.
+//   - error: NotFoundError if no comment has this ID, or an InternalError if the query fails.
+func (r *SqlCommentRepository) GetComment(commentID int) (models.Comment, error) {
+	return r.FindOne(context.Background(), selectCommentsBase+" WHERE c.ID = ?", commentID)
+}
+
+// UpdateComment sets the content and rating of a comment of the user.
+// Ownership is part of the UPDATE's WHERE clause, so the database enforces it. When no row changes, the comment is looked up to tell a missing comment (NotFoundError) and another user's (ForbiddenError) from an edit that kept the same values, which MySQL does not count as affected.
+
+// Parameters:
+//   - commentID: ID of the comment to edit.
+//   - userID: ID of the authenticated user.
+//   - content: new text content.
+//   - rating: new numerical rating.
+
+// Returns:
+//   - error: NotFoundError, ForbiddenError, or an InternalError if a query fails.
+func (r *SqlCommentRepository) UpdateComment(commentID, userID int, content string, rating int) error {
+	result, err := r.Exec(context.Background(), "UPDATE comments SET Content = ?, Rating = ? WHERE ID = ? AND UserID = ?", content, rating, commentID, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewInternalError(errors.ErrCommentUpdate).WithError(err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	comment, err := r.FindOne(context.Background(), "SELECT ID, UserID FROM comments WHERE ID = ?", commentID)
+	switch {
+	case err != nil:
+		return err
+	case comment.UserID != userID:
+		return errors.NewForbiddenError(errors.ErrCommentNotOwned)
+	default:
+		return nil
+	}
+}
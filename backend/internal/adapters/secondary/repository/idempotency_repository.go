@@ -0,0 +1,65 @@
+// Package repository provides SQL-based implementations of output ports for data persistence.
+// This file contains SQLIdempotencyRepository, which implements IdempotencyRepository on the idempotency_keys table.
+package repository
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// SQLIdempotencyRepository implements output.IdempotencyRepository using a SQL database.
+
+// It expects an idempotency_keys table with the columns IdempotencyKey (primary key), StatusCode, Body (BLOB) and ExpiresAt (DATETIME); see migrations/012_idempotency_keys.up.sql.
+type SQLIdempotencyRepository struct {
+	db dbUtil.Queryer
+}
+
+// NewSQLIdempotencyRepository creates a new SQLIdempotencyRepository.
+// It fatally logs and exits if the provided db is nil, indicating a critical configuration error.
+func NewSQLIdempotencyRepository(db dbUtil.Queryer) output.IdempotencyRepository {
+	if db == nil {
+		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
+	}
+
+	return &SQLIdempotencyRepository{
+		db: db,
+	}
+}
+
+// Check returns the non-expired record stored for key, or (nil, nil) if there is none.
+// SQL errors are wrapped as internal errors.
+func (r *SQLIdempotencyRepository) Check(key string) (*models.IdempotencyRecord, error) {
+	var record models.IdempotencyRecord
+	const query = `SELECT IdempotencyKey, StatusCode, Body, ExpiresAt
+	FROM idempotency_keys
+	WHERE IdempotencyKey = ? AND ExpiresAt > NOW()`
+
+	err := r.db.Get(&record, query, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+	}
+	return &record, nil
+}
+
+// Store records the response for key, replacing any expired record with the same key.
+// SQL errors are wrapped as internal errors.
+func (r *SQLIdempotencyRepository) Store(key string, statusCode int, body []byte, ttl time.Duration) error {
+	const query = `INSERT INTO idempotency_keys (IdempotencyKey, StatusCode, Body, ExpiresAt)
+	VALUES (?, ?, ?, DATE_ADD(NOW(), INTERVAL ? SECOND))
+	ON DUPLICATE KEY UPDATE StatusCode = VALUES(StatusCode), Body = VALUES(Body), ExpiresAt = VALUES(ExpiresAt)`
+
+	_, err := r.db.Exec(query, key, statusCode, body, int(ttl.Seconds()))
+	if err != nil {
+		return errors.NewInternalError(errors.ErrDatabaseInsert).WithError(err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Package repository provides SQL-based implementations of output ports for data persistence.
+// This file contains MemoryTokenRepository, an in-process TokenRepository for tokens that only need to be tracked until they expire.
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+)
+
+// memoryToken is the record of a token kept by MemoryTokenRepository.
+type memoryToken struct {
+	userID    int
+	expiresAt time.Time
+	revoked   bool
+}
+
+// MemoryTokenRepository implements output.TokenRepository in memory.
+
+// Records are dropped once their token expires, since an expired token is rejected anyway. They are lost on restart and not shared between instances.
+type MemoryTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[string]memoryToken
+	now    func() time.Time
+}
+
+// NewMemoryTokenRepository creates an empty MemoryTokenRepository.
+func NewMemoryTokenRepository() output.TokenRepository {
+	return &MemoryTokenRepository{
+		tokens: make(map[string]memoryToken),
+		now:    time.Now,
+	}
+}
+
+// Save records the token as active, and drops the records of expired tokens.
+func (r *MemoryTokenRepository) Save(tokenID string, userID int, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for id, token := range r.tokens {
+		if !token.expiresAt.After(now) {
+			delete(r.tokens, id)
+		}
+	}
+	r.tokens[tokenID] = memoryToken{userID: userID, expiresAt: expiresAt}
+	return nil
+}
+
+// Revoke marks the token as revoked if it is recorded, unexpired and still active.
+func (r *MemoryTokenRepository) Revoke(tokenID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[tokenID]
+	if !ok || token.revoked || !token.expiresAt.After(r.now()) {
+		return false, nil
+	}
+	token.revoked = true
+	r.tokens[tokenID] = token
+	return true, nil
+}
+
+// IsRevoked reports whether the token was revoked.
+func (r *MemoryTokenRepository) IsRevoked(tokenID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tokens[tokenID].revoked, nil
+}
+
+// RevokeAllForUser marks every recorded token of the user as revoked.
+func (r *MemoryTokenRepository) RevokeAllForUser(userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.userID == userID {
+			token.revoked = true
+			r.tokens[id] = token
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Package repository provides SQL-based implementations of output ports for data persistence.
+// This file contains SQLPKCERepository, which implements PKCERepository on the pkce_challenges table.
+package repository
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/pkce"
+)
+
+// SQLPKCERepository implements output.PKCERepository using a SQL database.
+
+// It expects a pkce_challenges table with the columns State (primary key), Challenge and ExpiresAt (DATETIME); see migrations/013_pkce_challenges.up.sql.
+type SQLPKCERepository struct {
+	db dbUtil.Queryer
+}
+
+// NewSQLPKCERepository creates a new SQLPKCERepository.
+// It fatally logs and exits if the provided db is nil, indicating a critical configuration error.
+func NewSQLPKCERepository(db dbUtil.Queryer) output.PKCERepository {
+	if db == nil {
+		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
+	}
+
+	return &SQLPKCERepository{
+		db: db,
+	}
+}
+
+// SaveChallenge stores the challenge for state, replacing any previous challenge with the same state.
+// SQL errors are wrapped as internal errors.
+func (r *SQLPKCERepository) SaveChallenge(state, challenge string, ttl time.Duration) error {
+	const query = `INSERT INTO pkce_challenges (State, Challenge, ExpiresAt)
+	VALUES (?, ?, DATE_ADD(NOW(), INTERVAL ? SECOND))
+	ON DUPLICATE KEY UPDATE Challenge = VALUES(Challenge), ExpiresAt = VALUES(ExpiresAt)`
+
+	_, err := r.db.Exec(query, state, challenge, int(ttl.Seconds()))
+	if err != nil {
+		return errors.NewInternalError(errors.ErrDatabaseInsert).WithError(err)
+	}
+	return nil
+}
+
+// ValidateChallenge loads the non-expired challenge stored for state, deletes it so it cannot be replayed (a concurrent redemption that loses the delete fails), and checks verifier against it with pkce.VerifyCodeChallenge.
+// It returns (false, nil) when no challenge exists for state. SQL errors are wrapped as internal errors.
+func (r *SQLPKCERepository) ValidateChallenge(state, verifier string) (bool, error) {
+	var challenge string
+	err := r.db.Get(&challenge, "SELECT Challenge FROM pkce_challenges WHERE State = ? AND ExpiresAt > NOW()", state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+	}
+
+	// Only the request that actually deletes the row may redeem it, so concurrent redemptions cannot both succeed.
+	result, err := r.db.Exec("DELETE FROM pkce_challenges WHERE State = ? AND Challenge = ?", state, challenge)
+	if err != nil {
+		return false, errors.NewInternalError(errors.ErrDatabaseDelete).WithError(err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.NewInternalError(errors.ErrDatabaseDelete).WithError(err)
+	}
+	if deleted == 0 {
+		return false, nil
+	}
+
+	return pkce.VerifyCodeChallenge(verifier, challenge), nil
+}
@@ -0,0 +1,96 @@
+// Package repository provides SQL-based implementations of output ports for data persistence.
+// This file contains SqlProductRepository, which implements ProductRepository on the products table.
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// SqlProductRepository implements output.ProductRepository using a SQL database.
+
+// It expects a products table with the columns of models.Product (see migrations/008_products.up.sql).
+type SqlProductRepository struct {
+	dbUtil.BaseRepository[models.Product]
+}
+
+// NewSqlProductRepository creates a new SqlProductRepository.
+// It fatally logs and exits if the provided db is nil, indicating a critical configuration error.
+func NewSqlProductRepository(db dbUtil.Queryer) output.ProductRepository {
+	if db == nil {
+		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
+	}
+
+	return &SqlProductRepository{
+		BaseRepository: dbUtil.NewBaseRepository[models.Product](db, errors.ErrProductNotFound, errors.ErrProductCreation),
+	}
+}
+
+// selectProductsQuery selects every column of the products table.
+const selectProductsQuery = `SELECT ID, Name, Brand, Price, Stock, ImageURL, Description, CreatedAt FROM products`
+
+// GetProducts retrieves all products, newest first.
+func (r *SqlProductRepository) GetProducts() ([]models.Product, error) {
+	return r.FindMany(context.Background(), selectProductsQuery+" ORDER BY CreatedAt DESC, ID DESC")
+}
+
+// GetProductByID retrieves the product with the given ID, or a NotFoundError.
+func (r *SqlProductRepository) GetProductByID(id int) (*models.Product, error) {
+	product, err := r.FindOne(context.Background(), selectProductsQuery+" WHERE ID = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// SaveProduct inserts the product with the current timestamp; p.ID and p.CreatedAt are ignored.
+func (r *SqlProductRepository) SaveProduct(p models.Product) error {
+	const query = `INSERT INTO products (Name, Brand, Price, Stock, ImageURL, Description, CreatedAt)
+	VALUES (?, ?, ?, ?, ?, ?, NOW())`
+
+	_, err := r.Exec(context.Background(), query, p.Name, p.Brand, p.Price, p.Stock, p.ImageURL, p.Description)
+	return err
+}
+
+// UpdateProduct overwrites the editable columns of the product with p.ID.
+// When no row changes, the product is looked up to tell a missing product (NotFoundError) from an update that kept the same values, which MySQL does not count as affected.
+func (r *SqlProductRepository) UpdateProduct(p models.Product) error {
+	const query = `UPDATE products SET Name = ?, Brand = ?, Price = ?, Stock = ?, ImageURL = ?, Description = ?
+	WHERE ID = ?`
+
+	result, err := r.Exec(context.Background(), query, p.Name, p.Brand, p.Price, p.Stock, p.ImageURL, p.Description, p.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewInternalError(errors.ErrProductUpdate).WithError(err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	_, err = r.FindOne(context.Background(), "SELECT ID FROM products WHERE ID = ?", p.ID)
+	return err
+}
+
+// DeleteProduct deletes the product with the given ID, returning a NotFoundError if there is none.
+func (r *SqlProductRepository) DeleteProduct(id int) error {
+	result, err := r.Exec(context.Background(), "DELETE FROM products WHERE ID = ?", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewInternalError(errors.ErrProductDelete).WithError(err)
+	}
+	if rows == 0 {
+		return errors.NewNotFoundError(errors.ErrProductNotFound)
+	}
+	return nil
+}
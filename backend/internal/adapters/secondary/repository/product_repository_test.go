@@ -0,0 +1,177 @@
+package repository_test
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	"github.com/jmoiron/sqlx"
+)
+
+var productColumns = []string{"ID", "Name", "Brand", "Price", "Stock", "ImageURL", "Description", "CreatedAt"}
+
+func newMockProductRepository(t *testing.T) (output.ProductRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+		db.Close()
+	})
+	return repository.NewSqlProductRepository(sqlx.NewDb(db, "sqlmock")), mock
+}
+
+func TestGetProductByID(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rows     *sqlmock.Rows
+		queryErr error
+		want     *models.Product
+		isError  func(error) bool
+	}{
+		{
+			name: "found",
+			rows: sqlmock.NewRows(productColumns).
+				AddRow(3, "Seamaster", "Omega", 5200.5, 4, "/img/seamaster.jpg", "Diver's watch", createdAt),
+			want: &models.Product{ID: 3, Name: "Seamaster", Brand: "Omega", Price: 5200.5, Stock: 4,
+				ImageURL: "/img/seamaster.jpg", Description: "Diver's watch", CreatedAt: createdAt},
+		},
+		{name: "missing", queryErr: sql.ErrNoRows, isError: errors.IsNotFound},
+		{name: "query failure", queryErr: sql.ErrConnDone, isError: errors.IsInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockProductRepository(t)
+			query := mock.ExpectQuery(regexp.QuoteMeta("FROM products WHERE ID = ?")).WithArgs(3)
+			if tt.queryErr != nil {
+				query.WillReturnError(tt.queryErr)
+			} else {
+				query.WillReturnRows(tt.rows)
+			}
+
+			product, err := repo.GetProductByID(3)
+			if tt.isError != nil {
+				if !tt.isError(err) {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetProductByID failed: %v", err)
+			}
+			if *product != *tt.want {
+				t.Errorf("Expected %+v, Got %+v", *tt.want, *product)
+			}
+		})
+	}
+}
+
+func TestGetProducts(t *testing.T) {
+	repo, mock := newMockProductRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM products ORDER BY CreatedAt DESC")).
+		WillReturnRows(sqlmock.NewRows(productColumns).
+			AddRow(2, "Submariner", "Rolex", 9100, 1, "", "", time.Now()).
+			AddRow(1, "Speedmaster", "Omega", 6400, 2, "", "", time.Now()))
+
+	products, err := repo.GetProducts()
+	if err != nil {
+		t.Fatalf("GetProducts failed: %v", err)
+	}
+	if len(products) != 2 || products[0].Name != "Submariner" || products[1].Brand != "Omega" {
+		t.Errorf("Unexpected products: %+v", products)
+	}
+}
+
+func TestSaveProduct(t *testing.T) {
+	repo, mock := newMockProductRepository(t)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO products")).
+		WithArgs("Santos", "Cartier", 7350.0, 3, "/img/santos.jpg", "Square case").
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	err := repo.SaveProduct(models.Product{ID: 99, Name: "Santos", Brand: "Cartier", Price: 7350, Stock: 3,
+		ImageURL: "/img/santos.jpg", Description: "Square case"})
+	if err != nil {
+		t.Fatalf("SaveProduct failed: %v", err)
+	}
+}
+
+func TestUpdateProduct(t *testing.T) {
+	product := models.Product{ID: 3, Name: "Seamaster", Brand: "Omega", Price: 4990, Stock: 2}
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		lookupRows   *sqlmock.Rows
+		isError      func(error) bool
+	}{
+		{name: "updated", rowsAffected: 1},
+		{name: "same values", rowsAffected: 0, lookupRows: sqlmock.NewRows([]string{"ID"}).AddRow(3)},
+		{name: "missing", rowsAffected: 0, lookupRows: sqlmock.NewRows([]string{"ID"}), isError: errors.IsNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockProductRepository(t)
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET")).
+				WithArgs(product.Name, product.Brand, product.Price, product.Stock, product.ImageURL, product.Description, product.ID).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			if tt.lookupRows != nil {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT ID FROM products WHERE ID = ?")).WithArgs(product.ID).
+					WillReturnRows(tt.lookupRows)
+			}
+
+			err := repo.UpdateProduct(product)
+			if tt.isError != nil {
+				if !tt.isError(err) {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateProduct failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteProduct(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		isError      func(error) bool
+	}{
+		{name: "deleted", rowsAffected: 1},
+		{name: "missing", rowsAffected: 0, isError: errors.IsNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockProductRepository(t)
+			mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE ID = ?")).WithArgs(3).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			err := repo.DeleteProduct(3)
+			if tt.isError != nil {
+				if !tt.isError(err) {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeleteProduct failed: %v", err)
+			}
+		})
+	}
+}
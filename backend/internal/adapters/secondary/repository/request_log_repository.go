@@ -0,0 +1,68 @@
+// Package repository provides SQL-based implementations of output ports for data persistence.
+// This file contains SQLRequestLogRepository, which implements RequestLogRepository on the http_request_log table.
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// SQLRequestLogRepository implements output.RequestLogRepository using a SQL database.
+
+// It expects an http_request_log table with the columns Method, Route, Status, DurationMs and CreatedAt.
+// Single durations are read through durations, a second BaseRepository scanning into float64.
+type SQLRequestLogRepository struct {
+	dbUtil.BaseRepository[models.RouteRequestStats]
+	durations dbUtil.BaseRepository[float64]
+}
+
+// NewSQLRequestLogRepository creates a new SQLRequestLogRepository.
+// It fatally logs and exits if the provided db is nil, indicating a critical configuration error.
+func NewSQLRequestLogRepository(db dbUtil.Queryer) output.RequestLogRepository {
+	if db == nil {
+		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
+	}
+
+	return &SQLRequestLogRepository{
+		BaseRepository: dbUtil.NewBaseRepository[models.RouteRequestStats](db, errors.ErrRequestLogNotFound, errors.ErrDatabaseInsert),
+		durations:      dbUtil.NewBaseRepository[float64](db, errors.ErrRequestLogNotFound, errors.ErrDatabaseInsert),
+	}
+}
+
+// Save inserts the record, storing its duration in milliseconds.
+func (r *SQLRequestLogRepository) Save(record models.RequestLogRecord) error {
+	const query = `INSERT INTO http_request_log (Method, Route, Status, DurationMs, CreatedAt)
+	VALUES (?, ?, ?, ?, ?)`
+
+	durationMs := float64(record.Duration) / float64(time.Millisecond)
+	_, err := r.Exec(context.Background(), query, record.Method, record.Route, record.Status, durationMs, record.Timestamp.UTC())
+	return err
+}
+
+// GetRouteStats counts the requests and server errors of every route in [from, to).
+func (r *SQLRequestLogRepository) GetRouteStats(from, to time.Time) ([]models.RouteRequestStats, error) {
+	const query = `SELECT Route, COUNT(*) AS Total, SUM(Status >= 500) AS Errors
+	FROM http_request_log
+	WHERE CreatedAt >= ? AND CreatedAt < ?
+	GROUP BY Route
+	ORDER BY Route`
+
+	return r.FindMany(context.Background(), query, from.UTC(), to.UTC())
+}
+
+// GetDurationAt selects the duration at the given rank with ORDER BY DurationMs LIMIT 1 OFFSET n.
+// It returns a NotFoundError when the offset is past the last request.
+func (r *SQLRequestLogRepository) GetDurationAt(route string, from, to time.Time, offset int) (float64, error) {
+	const query = `SELECT DurationMs FROM http_request_log
+	WHERE Route = ? AND CreatedAt >= ? AND CreatedAt < ?
+	ORDER BY DurationMs
+	LIMIT 1 OFFSET ?`
+
+	return r.durations.FindOne(context.Background(), query, route, from.UTC(), to.UTC(), offset)
+}
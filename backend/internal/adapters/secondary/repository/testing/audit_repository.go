@@ -0,0 +1,77 @@
+// Package testing provides in-memory implementations of the output ports for unit tests.
+// This file contains InMemoryAuditRepository, which implements AuditRepository without a database.
+package testing
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+)
+
+// InMemoryAuditRepository implements output.AuditRepository with a slice of entries guarded by a sync.RWMutex.
+
+// Like SQLAuditRepository, it lists the matching entries most recent first, treating zero filter fields as unset.
+// It is safe for concurrent use.
+type InMemoryAuditRepository struct {
+	mu      sync.RWMutex
+	entries []models.AuditEntry
+	nextID  int64
+}
+
+// InMemoryAuditOption defines functional options for NewInMemoryAuditRepository.
+type InMemoryAuditOption func(*InMemoryAuditRepository)
+
+// WithAuditEntries seeds the repository with entries, recorded in order.
+func WithAuditEntries(entries []models.AuditEntry) InMemoryAuditOption {
+	return func(r *InMemoryAuditRepository) {
+		for _, entry := range entries {
+			_ = r.Record(entry)
+		}
+	}
+}
+
+// NewInMemoryAuditRepository creates an empty InMemoryAuditRepository, then applies options in order.
+func NewInMemoryAuditRepository(options ...InMemoryAuditOption) *InMemoryAuditRepository {
+	r := &InMemoryAuditRepository{nextID: 1}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Record stores the entry with the next free ID.
+func (r *InMemoryAuditRepository) Record(entry models.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = r.nextID
+	r.nextID++
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// List returns the entries matching filter, ordered by CreatedAt, then ID, descending.
+func (r *InMemoryAuditRepository) List(filter models.AuditFilter) ([]models.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matching := []models.AuditEntry{}
+	for _, entry := range r.entries {
+		switch {
+		case entry.Action != filter.Action,
+			filter.UserID != 0 && entry.UserID != filter.UserID,
+			!filter.From.IsZero() && entry.CreatedAt.Before(filter.From),
+			!filter.To.IsZero() && !entry.CreatedAt.Before(filter.To):
+			continue
+		}
+		matching = append(matching, entry)
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		if !matching[i].CreatedAt.Equal(matching[j].CreatedAt) {
+			return matching[i].CreatedAt.After(matching[j].CreatedAt)
+		}
+		return matching[i].ID > matching[j].ID
+	})
+	return matching, nil
+}
@@ -0,0 +1,287 @@
+// Package testing provides in-memory implementations of the output ports for unit tests.
+// This file contains InMemoryCommentRepository, which implements CommentRepository without a database.
+package testing
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// commentDateLayout is the layout in which MySQL returns the comments.Date DATETIME column as a string.
+const commentDateLayout = time.DateTime
+
+// InMemoryCommentRepository implements output.CommentRepository with a slice of comments guarded by a sync.RWMutex.
+
+// It follows the contract of SqlCommentRepository: listings return pinned comments first, then the newest first, authors alone may edit or delete their comments, and at most models.MaxPinnedComments comments are pinned at once.
+// There is no user storage, so comments keep the UserName they were seeded with; saved comments get models.AnonymousUserName when anonymous and no name otherwise.
+// It is safe for concurrent use.
+type InMemoryCommentRepository struct {
+	mu       sync.RWMutex
+	comments []storedComment
+	nextID   int
+	// pinSeq orders pins like the PinnedAt column, so GetPinned lists the most recently pinned comment first.
+	pinSeq int
+}
+
+// storedComment is a comment together with the position of its pin, 0 when not pinned.
+type storedComment struct {
+	models.Comment
+	pinnedAt int
+}
+
+// InMemoryCommentOption defines functional options for NewInMemoryCommentRepository.
+type InMemoryCommentOption func(*InMemoryCommentRepository)
+
+// WithComments seeds the repository with comments, pinned in the given order when Pinned is set.
+// Comments without an ID get the next free one and comments without a date are dated now, in commentDateLayout.
+func WithComments(comments []models.Comment) InMemoryCommentOption {
+	return func(r *InMemoryCommentRepository) {
+		for _, comment := range comments {
+			r.insert(comment)
+		}
+	}
+}
+
+// NewInMemoryCommentRepository creates an empty InMemoryCommentRepository, then applies options in order.
+func NewInMemoryCommentRepository(options ...InMemoryCommentOption) *InMemoryCommentRepository {
+	r := &InMemoryCommentRepository{nextID: 1}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// GetComments returns every comment, pinned ones first, then ordered by date descending.
+func (r *InMemoryCommentRepository) GetComments() ([]models.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sorted(), nil
+}
+
+// GetCommentsPaginated returns one page of the comments listed by GetComments, and the number of comments.
+// A page beyond the last one returns no comments but still reports the total.
+func (r *InMemoryCommentRepository) GetCommentsPaginated(page, pageSize int) ([]models.Comment, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := r.sorted()
+	start := (page - 1) * pageSize
+	if start < 0 || start >= len(all) {
+		return nil, len(all), nil
+	}
+	end := min(start+pageSize, len(all))
+	return all[start:end], len(all), nil
+}
+
+// SaveComment stores a new comment with the next ID, dated now.
+func (r *InMemoryCommentRepository) SaveComment(userID int, content string, rating int) error {
+	comment := models.Comment{UserID: userID, Content: content, Rating: rating}
+	if userID == models.AnonymousUserID {
+		comment.UserName = models.AnonymousUserName
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.insertLocked(comment)
+	return nil
+}
+
+// GetRatingHistogram counts the comments of each rating; ratings without comments are absent.
+func (r *InMemoryCommentRepository) GetRatingHistogram() (map[int]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	histogram := make(map[int]int)
+	for _, stored := range r.comments {
+		histogram[stored.Rating]++
+	}
+	return histogram, nil
+}
+
+// GetPinned returns the pinned comments, most recently pinned first.
+func (r *InMemoryCommentRepository) GetPinned() ([]models.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var pinned []storedComment
+	for _, stored := range r.comments {
+		if stored.Pinned {
+			pinned = append(pinned, stored)
+		}
+	}
+	sort.Slice(pinned, func(i, j int) bool { return pinned[i].pinnedAt > pinned[j].pinnedAt })
+
+	comments := make([]models.Comment, 0, len(pinned))
+	for _, stored := range pinned {
+		comments = append(comments, stored.Comment)
+	}
+	return comments, nil
+}
+
+// Pin marks a comment as pinned. Pinning an already pinned comment is a no-op.
+// It returns a NotFoundError if the comment does not exist, and a ConflictError if models.MaxPinnedComments comments are already pinned.
+func (r *InMemoryCommentRepository) Pin(commentID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.indexOf(commentID)
+	switch {
+	case !ok:
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	case r.comments[i].Pinned:
+		return nil
+	case r.pinnedCount() >= models.MaxPinnedComments:
+		return errors.NewConflictError(errors.ErrMaxPinnedComments)
+	}
+	r.pinLocked(i)
+	return nil
+}
+
+// Unpin clears the pinned mark of a comment, or returns a NotFoundError if it does not exist.
+func (r *InMemoryCommentRepository) Unpin(commentID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.indexOf(commentID)
+	if !ok {
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	}
+	r.comments[i].Pinned, r.comments[i].pinnedAt = false, 0
+	return nil
+}
+
+// DeleteComment deletes a comment of requestingUserID.
+// It returns a NotFoundError if the comment does not exist, and a ForbiddenError if another user wrote it.
+func (r *InMemoryCommentRepository) DeleteComment(commentID, requestingUserID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.indexOf(commentID)
+	switch {
+	case !ok:
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	case r.comments[i].UserID != requestingUserID:
+		return errors.NewForbiddenError(errors.ErrCommentNotOwned)
+	}
+	r.comments = append(r.comments[:i], r.comments[i+1:]...)
+	return nil
+}
+
+// ForceDeleteComment deletes a comment whoever wrote it, or returns a NotFoundError if it does not exist.
+func (r *InMemoryCommentRepository) ForceDeleteComment(commentID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.indexOf(commentID)
+	if !ok {
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	}
+	r.comments = append(r.comments[:i], r.comments[i+1:]...)
+	return nil
+}
+
+// GetComment returns the comment with the given ID, or a NotFoundError.
+func (r *InMemoryCommentRepository) GetComment(commentID int) (models.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i, ok := r.indexOf(commentID)
+	if !ok {
+		return models.Comment{}, errors.NewNotFoundError(errors.ErrCommentNotFound)
+	}
+	return r.comments[i].Comment, nil
+}
+
+// UpdateComment sets the content and rating of a comment of userID.
+// It returns a NotFoundError if the comment does not exist, and a ForbiddenError if another user wrote it.
+func (r *InMemoryCommentRepository) UpdateComment(commentID, userID int, content string, rating int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.indexOf(commentID)
+	switch {
+	case !ok:
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	case r.comments[i].UserID != userID:
+		return errors.NewForbiddenError(errors.ErrCommentNotOwned)
+	}
+	r.comments[i].Content, r.comments[i].Rating = content, rating
+	return nil
+}
+
+// sorted returns a copy of the comments, pinned ones first, then by date descending; comments posted in the same second are listed newest ID first. r.mu must be held.
+func (r *InMemoryCommentRepository) sorted() []models.Comment {
+	comments := make([]models.Comment, 0, len(r.comments))
+	for _, stored := range r.comments {
+		comments = append(comments, stored.Comment)
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		a, b := comments[i], comments[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		if a.Date != b.Date {
+			return a.Date > b.Date
+		}
+		return a.ID > b.ID
+	})
+	return comments
+}
+
+// indexOf returns the position of the comment with the given ID in r.comments. r.mu must be held.
+func (r *InMemoryCommentRepository) indexOf(commentID int) (int, bool) {
+	for i, stored := range r.comments {
+		if stored.ID == commentID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// pinnedCount returns the number of pinned comments. r.mu must be held.
+func (r *InMemoryCommentRepository) pinnedCount() int {
+	count := 0
+	for _, stored := range r.comments {
+		if stored.Pinned {
+			count++
+		}
+	}
+	return count
+}
+
+// pinLocked pins the comment at position i as the most recent pin. r.mu must be held.
+func (r *InMemoryCommentRepository) pinLocked(i int) {
+	r.pinSeq++
+	r.comments[i].Pinned, r.comments[i].pinnedAt = true, r.pinSeq
+}
+
+// insert stores comment, filling in its defaults as described in WithComments.
+func (r *InMemoryCommentRepository) insert(comment models.Comment) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.insertLocked(comment)
+}
+
+// insertLocked implements insert; r.mu must be held.
+func (r *InMemoryCommentRepository) insertLocked(comment models.Comment) {
+	if comment.ID == 0 {
+		comment.ID = r.nextID
+	}
+	if comment.ID >= r.nextID {
+		r.nextID = comment.ID + 1
+	}
+	if comment.Date == "" {
+		comment.Date = time.Now().Format(commentDateLayout)
+	}
+
+	pinned := comment.Pinned
+	comment.Pinned = false
+	r.comments = append(r.comments, storedComment{Comment: comment})
+	if pinned {
+		r.pinLocked(len(r.comments) - 1)
+	}
+}
@@ -0,0 +1,132 @@
+package testing_test
+
+import (
+	"testing"
+	"time"
+
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+func commentIDs(comments []models.Comment) []int {
+	ids := make([]int, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	return ids
+}
+
+func equalIDs(got, want []int) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestInMemoryGetCommentsOrdersByDateDesc(t *testing.T) {
+	repo := repotesting.NewInMemoryCommentRepository(repotesting.WithComments([]models.Comment{
+		{ID: 1, Date: "2024-03-01 09:00:00", UserID: 7, Content: "Oldest", Rating: 3},
+		{ID: 2, Date: "2024-05-01 09:00:00", UserID: 7, Content: "Newest", Rating: 5},
+		{ID: 3, Date: "2024-04-01 09:00:00", UserID: 8, Content: "Middle", Rating: 4},
+	}))
+
+	comments, err := repo.GetComments()
+	if err != nil {
+		t.Fatalf("GetComments failed: %v", err)
+	}
+	if got := commentIDs(comments); !equalIDs(got, []int{2, 3, 1}) {
+		t.Errorf("Expected comments ordered by date descending [2 3 1], Got %v", got)
+	}
+
+	// Like ORDER BY c.Pinned DESC, c.Date DESC, pinned comments come first.
+	if err := repo.Pin(1); err != nil {
+		t.Fatalf("Pin failed: %v", err)
+	}
+	page, total, err := repo.GetCommentsPaginated(1, 2)
+	if err != nil || total != 3 {
+		t.Fatalf("GetCommentsPaginated() total = %d, %v; want 3, nil", total, err)
+	}
+	if got := commentIDs(page); !equalIDs(got, []int{1, 2}) {
+		t.Errorf("Expected the first page [1 2], Got %v", got)
+	}
+}
+
+func TestInMemorySaveCommentAssignsIDAndTimestamp(t *testing.T) {
+	repo := repotesting.NewInMemoryCommentRepository(repotesting.WithComments([]models.Comment{
+		{ID: 4, Date: "2024-03-01 09:00:00", UserID: 7, Content: "Seeded", Rating: 3},
+	}))
+
+	before := time.Now().Truncate(time.Second)
+	if err := repo.SaveComment(models.AnonymousUserID, "Lovely strap", 5); err != nil {
+		t.Fatalf("SaveComment failed: %v", err)
+	}
+	after := time.Now()
+
+	comment, err := repo.GetComment(5)
+	if err != nil {
+		t.Fatalf("Expected the saved comment to get the next ID 5, Got: %v", err)
+	}
+	date, err := time.ParseInLocation(time.DateTime, comment.Date, time.Local)
+	if err != nil || date.Before(before) || date.After(after) {
+		t.Errorf("Expected a DATETIME timestamp of now, Got %q (%v)", comment.Date, err)
+	}
+	if comment.UserName != models.AnonymousUserName || comment.Content != "Lovely strap" || comment.Rating != 5 {
+		t.Errorf("Unexpected comment: %+v", comment)
+	}
+
+	comments, _ := repo.GetComments()
+	if got := commentIDs(comments); !equalIDs(got, []int{5, 4}) {
+		t.Errorf("Expected the new comment first [5 4], Got %v", got)
+	}
+}
+
+func TestInMemoryCommentOwnership(t *testing.T) {
+	repo := repotesting.NewInMemoryCommentRepository(repotesting.WithComments([]models.Comment{
+		{ID: 1, UserID: 7, Content: "Great watch", Rating: 4},
+	}))
+
+	if err := repo.UpdateComment(1, 8, "Edited", 1); !errors.IsForbidden(err) {
+		t.Errorf("Expected a ForbiddenError when editing another user's comment, Got: %v", err)
+	}
+	if err := repo.DeleteComment(1, 8); !errors.IsForbidden(err) {
+		t.Errorf("Expected a ForbiddenError when deleting another user's comment, Got: %v", err)
+	}
+	if err := repo.DeleteComment(99, 7); !errors.IsNotFound(err) {
+		t.Errorf("Expected a NotFoundError for a missing comment, Got: %v", err)
+	}
+	if err := repo.ForceDeleteComment(1); err != nil {
+		t.Fatalf("ForceDeleteComment failed: %v", err)
+	}
+	if _, err := repo.GetComment(1); !errors.IsNotFound(err) {
+		t.Errorf("Expected the comment to be deleted, Got: %v", err)
+	}
+}
+
+func TestInMemoryPinLimit(t *testing.T) {
+	var seeded []models.Comment
+	for i := 0; i <= models.MaxPinnedComments; i++ {
+		seeded = append(seeded, models.Comment{UserID: 7, Content: "Pinned", Rating: 5, Pinned: i < models.MaxPinnedComments})
+	}
+	repo := repotesting.NewInMemoryCommentRepository(repotesting.WithComments(seeded))
+
+	if err := repo.Pin(models.MaxPinnedComments + 1); !errors.IsConflict(err) {
+		t.Errorf("Expected a ConflictError beyond %d pinned comments, Got: %v", models.MaxPinnedComments, err)
+	}
+	if err := repo.Unpin(1); err != nil {
+		t.Fatalf("Unpin failed: %v", err)
+	}
+	if err := repo.Pin(models.MaxPinnedComments + 1); err != nil {
+		t.Fatalf("Pin failed: %v", err)
+	}
+
+	pinned, _ := repo.GetPinned()
+	if len(pinned) != models.MaxPinnedComments || pinned[0].ID != models.MaxPinnedComments+1 {
+		t.Errorf("Expected the most recently pinned comment first, Got %v", commentIDs(pinned))
+	}
+}
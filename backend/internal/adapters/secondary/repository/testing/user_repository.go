@@ -0,0 +1,234 @@
+// Package testing provides in-memory implementations of the output ports for unit tests, so services can be tested without a database.
+// Each implementation follows the contract of its SQL counterpart in the repository package, including the errors it returns.
+package testing
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+// InMemoryUserRepository implements output.UserRepository with a sync.Map of models.User keyed by user ID.
+
+// Like SQLUserRepository, it compares usernames without regard to case, hashes passwords on SaveUser, and soft-deletes users: deleted accounts are ignored by every lookup but keep their username reserved.
+// It is safe for concurrent use.
+type InMemoryUserRepository struct {
+	users  sync.Map // int → models.User
+	hasher securityAuth.Hasher
+
+	// mu serializes writes, so the username uniqueness check and the insert of SaveUser are atomic, and guards nextID.
+	mu     sync.Mutex
+	nextID int
+}
+
+// InMemoryUserOption defines functional options for NewInMemoryUserRepository.
+type InMemoryUserOption func(*InMemoryUserRepository)
+
+// WithUsers seeds the repository with users. Password must hold a hash, as stored by SaveUser.
+// Users without an ID get the next free one, users without a role get models.RoleUser, and users without a creation time are created now.
+func WithUsers(users []models.User) InMemoryUserOption {
+	return func(r *InMemoryUserRepository) {
+		for _, user := range users {
+			r.insert(user)
+		}
+	}
+}
+
+// WithHasher replaces the securityAuth.BcryptHasher used by SaveUser.
+func WithHasher(hasher securityAuth.Hasher) InMemoryUserOption {
+	return func(r *InMemoryUserRepository) {
+		r.hasher = hasher
+	}
+}
+
+// NewInMemoryUserRepository creates an empty InMemoryUserRepository, then applies options in order.
+// It returns the concrete type so tests can call Reset; it satisfies output.UserRepository.
+func NewInMemoryUserRepository(options ...InMemoryUserOption) *InMemoryUserRepository {
+	r := &InMemoryUserRepository{
+		hasher: securityAuth.BcryptHasher{},
+		nextID: 1,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Reset removes every user, seeded ones included, and restarts IDs at 1.
+func (r *InMemoryUserRepository) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users.Range(func(key, _ any) bool {
+		r.users.Delete(key)
+		return true
+	})
+	r.nextID = 1
+}
+
+// UserExists reports whether an active user has the given username, ignoring case.
+func (r *InMemoryUserRepository) UserExists(username string) (bool, error) {
+	_, ok := r.findByName(username, false)
+	return ok, nil
+}
+
+// GetHashPassword returns the password hash of the active user with the given username, ignoring case, or a NotFoundError.
+func (r *InMemoryUserRepository) GetHashPassword(username string) (string, error) {
+	user, ok := r.findByName(username, false)
+	if !ok {
+		return "", errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	return user.Password, nil
+}
+
+// GetHashPasswordByID returns the password hash of the active user with the given ID, or a NotFoundError.
+func (r *InMemoryUserRepository) GetHashPasswordByID(userID int) (string, error) {
+	user, ok := r.findByID(userID)
+	if !ok {
+		return "", errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	return user.Password, nil
+}
+
+// SaveUser hashes password and stores a new user with models.RoleUser.
+// Like the UNIQUE index on User_Registration, it returns a ConflictError when the username is taken in any casing, even by a deleted account. A hashing failure is returned as an InternalError.
+func (r *InMemoryUserRepository) SaveUser(username, password string) error {
+	hash, err := r.hasher.Hash([]byte(password))
+	if err != nil {
+		return errors.NewInternalError(errors.ErrDatabaseInsert).WithError(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, taken := r.findByName(username, true); taken {
+		return errors.NewConflictError(errors.ErrUserAlreadyExists)
+	}
+	r.insertLocked(models.User{UserName: username, Password: hash})
+	return nil
+}
+
+// GetID returns the ID of the active user with the given username, ignoring case, or a NotFoundError.
+func (r *InMemoryUserRepository) GetID(username string) (int, error) {
+	user, ok := r.findByName(username, false)
+	if !ok {
+		return 0, errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	return user.ID, nil
+}
+
+// GetRole returns the role of the active user with the given ID, or a NotFoundError.
+func (r *InMemoryUserRepository) GetRole(userID int) (string, error) {
+	user, ok := r.findByID(userID)
+	if !ok {
+		return "", errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	return user.Role, nil
+}
+
+// ListUsers returns one page of active users ordered by ID, and the number of active users.
+// There is no profile storage, so DisplayName is always the username.
+func (r *InMemoryUserRepository) ListUsers(page, pageSize int) ([]models.UserProfile, int, error) {
+	var active []models.User
+	r.users.Range(func(_, value any) bool {
+		if user := value.(models.User); user.DeletedAt == nil {
+			active = append(active, user)
+		}
+		return true
+	})
+	sort.Slice(active, func(i, j int) bool { return active[i].ID < active[j].ID })
+
+	var profiles []models.UserProfile
+	for i := (page - 1) * pageSize; i >= 0 && i < len(active) && len(profiles) < pageSize; i++ {
+		user := active[i]
+		profiles = append(profiles, models.UserProfile{
+			ID:          user.ID,
+			UserName:    user.UserName,
+			DisplayName: user.UserName,
+			CreatedAt:   user.CreatedAt,
+			Role:        user.Role,
+		})
+	}
+	return profiles, len(active), nil
+}
+
+// UpdatePassword replaces the password hash of the user with the given ID, or returns a NotFoundError.
+// Like the SQL UPDATE, it also applies to deleted accounts.
+func (r *InMemoryUserRepository) UpdatePassword(userID int, hash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.users.Load(userID)
+	if !ok {
+		return errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	user := value.(models.User)
+	user.Password = hash
+	r.users.Store(userID, user)
+	return nil
+}
+
+// DeleteUser soft-deletes the user with the given ID, or returns a NotFoundError if it does not exist or was already deleted.
+func (r *InMemoryUserRepository) DeleteUser(userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.findByID(userID)
+	if !ok {
+		return errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	deletedAt := time.Now()
+	user.DeletedAt = &deletedAt
+	r.users.Store(userID, user)
+	return nil
+}
+
+// findByName returns the user whose username equals username, ignoring case. Deleted accounts only match when includeDeleted is set.
+func (r *InMemoryUserRepository) findByName(username string, includeDeleted bool) (models.User, bool) {
+	var found models.User
+	var ok bool
+	r.users.Range(func(_, value any) bool {
+		user := value.(models.User)
+		if strings.EqualFold(user.UserName, username) && (includeDeleted || user.DeletedAt == nil) {
+			found, ok = user, true
+			return false
+		}
+		return true
+	})
+	return found, ok
+}
+
+// findByID returns the active user with the given ID.
+func (r *InMemoryUserRepository) findByID(userID int) (models.User, bool) {
+	value, ok := r.users.Load(userID)
+	if !ok {
+		return models.User{}, false
+	}
+	user := value.(models.User)
+	return user, user.DeletedAt == nil
+}
+
+// insert stores user, filling in its defaults as described in WithUsers.
+func (r *InMemoryUserRepository) insert(user models.User) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.insertLocked(user)
+}
+
+// insertLocked implements insert; r.mu must be held.
+func (r *InMemoryUserRepository) insertLocked(user models.User) {
+	if user.ID == 0 {
+		user.ID = r.nextID
+	}
+	if user.ID >= r.nextID {
+		r.nextID = user.ID + 1
+	}
+	if user.Role == "" {
+		user.Role = models.RoleUser
+	}
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+	r.users.Store(user.ID, user)
+}
@@ -0,0 +1,113 @@
+package testing_test
+
+import (
+	"testing"
+
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+func newSeededRepository() *repotesting.InMemoryUserRepository {
+	return repotesting.NewInMemoryUserRepository(repotesting.WithUsers([]models.User{
+		{ID: 3, UserName: "Alice", Password: "alice-hash", Role: models.RoleAdmin},
+		{UserName: "bob", Password: "bob-hash"},
+	}))
+}
+
+func TestInMemoryUserExists(t *testing.T) {
+	repo := newSeededRepository()
+
+	for username, want := range map[string]bool{"Alice": true, "alice": true, "BOB": true, "carol": false} {
+		if got, err := repo.UserExists(username); err != nil || got != want {
+			t.Errorf("UserExists(%q) = %v, %v; want %v, nil", username, got, err, want)
+		}
+	}
+}
+
+func TestInMemoryGetHashPassword(t *testing.T) {
+	repo := newSeededRepository()
+
+	if hash, err := repo.GetHashPassword("ALICE"); err != nil || hash != "alice-hash" {
+		t.Errorf("GetHashPassword(ALICE) = %q, %v; want alice-hash, nil", hash, err)
+	}
+	if _, err := repo.GetHashPassword("carol"); !errors.IsNotFound(err) {
+		t.Errorf("Expected a NotFoundError for an unknown user, Got: %v", err)
+	}
+}
+
+func TestInMemoryGetID(t *testing.T) {
+	repo := newSeededRepository()
+
+	// Seeded users without an ID are numbered after the highest seeded ID.
+	for username, want := range map[string]int{"alice": 3, "Bob": 4} {
+		if id, err := repo.GetID(username); err != nil || id != want {
+			t.Errorf("GetID(%q) = %d, %v; want %d, nil", username, id, err, want)
+		}
+	}
+	if _, err := repo.GetID("carol"); !errors.IsNotFound(err) {
+		t.Errorf("Expected a NotFoundError for an unknown user, Got: %v", err)
+	}
+}
+
+func TestInMemorySaveUser(t *testing.T) {
+	repo := newSeededRepository()
+
+	if err := repo.SaveUser("Carol", "Str0ng!Password"); err != nil {
+		t.Fatalf("SaveUser failed: %v", err)
+	}
+	id, err := repo.GetID("carol")
+	if err != nil || id != 5 {
+		t.Fatalf("GetID(carol) = %d, %v; want 5, nil", id, err)
+	}
+	hash, _ := repo.GetHashPassword("carol")
+	if err := securityAuth.VerifyPassword(hash, []byte("Str0ng!Password")); err != nil {
+		t.Errorf("Expected the password to be stored hashed, Got %q: %v", hash, err)
+	}
+	if role, _ := repo.GetRole(id); role != models.RoleUser {
+		t.Errorf("Expected new users to get the %q role, Got %q", models.RoleUser, role)
+	}
+
+	if err := repo.SaveUser("ALICE", "Str0ng!Password"); !errors.IsConflict(err) {
+		t.Errorf("Expected a ConflictError for a username taken in another casing, Got: %v", err)
+	}
+}
+
+func TestInMemoryDeletedUsersAreIgnored(t *testing.T) {
+	repo := newSeededRepository()
+
+	if err := repo.DeleteUser(3); err != nil {
+		t.Fatalf("DeleteUser failed: %v", err)
+	}
+	if exists, _ := repo.UserExists("alice"); exists {
+		t.Error("Expected a deleted user not to exist")
+	}
+	if _, err := repo.GetHashPasswordByID(3); !errors.IsNotFound(err) {
+		t.Errorf("Expected a NotFoundError for a deleted user, Got: %v", err)
+	}
+	if err := repo.DeleteUser(3); !errors.IsNotFound(err) {
+		t.Errorf("Expected a NotFoundError when deleting twice, Got: %v", err)
+	}
+	if err := repo.SaveUser("alice", "Str0ng!Password"); !errors.IsConflict(err) {
+		t.Errorf("Expected the username of a deleted user to stay reserved, Got: %v", err)
+	}
+	if users, total, _ := repo.ListUsers(1, 10); total != 1 || len(users) != 1 || users[0].UserName != "bob" {
+		t.Errorf("ListUsers() = %+v of %d; want only bob", users, total)
+	}
+}
+
+func TestInMemoryReset(t *testing.T) {
+	repo := newSeededRepository()
+	repo.Reset()
+
+	if exists, _ := repo.UserExists("alice"); exists {
+		t.Error("Expected Reset to remove seeded users")
+	}
+	if err := repo.SaveUser("dave", "Str0ng!Password"); err != nil {
+		t.Fatalf("SaveUser failed: %v", err)
+	}
+	if id, _ := repo.GetID("dave"); id != 1 {
+		t.Errorf("Expected IDs to restart at 1 after Reset, Got %d", id)
+	}
+}
@@ -0,0 +1,77 @@
+// Package repository provides SQL-based implementations of output ports for data persistence.
+// This file contains SQLTokenRepository, which implements TokenRepository on the refresh_tokens table.
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// SQLTokenRepository implements output.TokenRepository using a SQL database.
+
+// It expects a refresh_tokens table with the columns TokenID (primary key), UserID, ExpiresAt and RevokedAt (nullable DATETIME).
+type SQLTokenRepository struct {
+	dbUtil.BaseRepository[models.RefreshToken]
+}
+
+// NewSQLTokenRepository creates a new SQLTokenRepository.
+// It fatally logs and exits if the provided db is nil, indicating a critical configuration error.
+func NewSQLTokenRepository(db dbUtil.Queryer) output.TokenRepository {
+	if db == nil {
+		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
+	}
+
+	return &SQLTokenRepository{
+		BaseRepository: dbUtil.NewBaseRepository[models.RefreshToken](db, errors.ErrTokenValidation, errors.ErrTokenGeneration),
+	}
+}
+
+// Save inserts the token as active.
+func (r *SQLTokenRepository) Save(tokenID string, userID int, expiresAt time.Time) error {
+	const query = `INSERT INTO refresh_tokens (TokenID, UserID, ExpiresAt) VALUES (?, ?, ?)`
+
+	_, err := r.Exec(context.Background(), query, tokenID, userID, expiresAt.UTC())
+	return err
+}
+
+// Revoke sets RevokedAt on the token if it is still active.
+// The check and the update are one statement, so two concurrent refreshes with the same token cannot both succeed.
+func (r *SQLTokenRepository) Revoke(tokenID string) (bool, error) {
+	const query = `UPDATE refresh_tokens SET RevokedAt = NOW() WHERE TokenID = ? AND RevokedAt IS NULL`
+
+	result, err := r.Exec(context.Background(), query, tokenID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.NewInternalError(errors.ErrDatabaseUpdate).WithError(err)
+	}
+	return rows > 0, nil
+}
+
+// RevokeAllForUser sets RevokedAt on every active token of the user.
+func (r *SQLTokenRepository) RevokeAllForUser(userID int) error {
+	const query = `UPDATE refresh_tokens SET RevokedAt = NOW() WHERE UserID = ? AND RevokedAt IS NULL`
+
+	_, err := r.Exec(context.Background(), query, userID)
+	return err
+}
+
+// IsRevoked reports whether the token has a RevokedAt; unknown tokens are not revoked.
+func (r *SQLTokenRepository) IsRevoked(tokenID string) (bool, error) {
+	token, err := r.FindOne(context.Background(), "SELECT TokenID, UserID, ExpiresAt, RevokedAt FROM refresh_tokens WHERE TokenID = ?", tokenID)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return token.RevokedAt != nil, nil
+}
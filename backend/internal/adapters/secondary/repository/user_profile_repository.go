@@ -0,0 +1,54 @@
+// Package repository provides SQL-based implementations of output ports for data persistence.
+// This file contains SQLUserProfileRepository, which implements UserProfileRepository on the user_profiles table (1:1 with User_Registration).
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// SQLUserProfileRepository implements output.UserProfileRepository using a SQL database.
+
+// It expects a user_profiles table with UserID (primary key, referencing User_Registration.UserID) and DisplayName columns (see migrations/011_user_profiles.up.sql).
+type SQLUserProfileRepository struct {
+	dbUtil.BaseRepository[models.UserProfile]
+}
+
+// NewSQLUserProfileRepository creates a new SQLUserProfileRepository.
+// It fatally logs and exits if the provided db is nil, indicating a critical configuration error.
+func NewSQLUserProfileRepository(db dbUtil.Queryer) output.UserProfileRepository {
+	if db == nil {
+		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
+	}
+
+	return &SQLUserProfileRepository{
+		BaseRepository: dbUtil.NewBaseRepository[models.UserProfile](db, errors.ErrUserNotFound, errors.ErrUserAlreadyExists),
+	}
+}
+
+// GetProfile returns the user's profile, using the login username as display name when no profile row exists.
+// It returns a NotFoundError if the user does not exist, or an InternalError if the query fails.
+func (r *SQLUserProfileRepository) GetProfile(userID int) (models.UserProfile, error) {
+	const query = `SELECT u.UserID, u.UserName, COALESCE(p.DisplayName, u.UserName) AS DisplayName, u.CreatedAt, u.Role
+	FROM User_Registration u
+	LEFT JOIN user_profiles p ON p.UserID = u.UserID
+	WHERE u.UserID = ? AND u.DeletedAt IS NULL`
+
+	return r.FindOne(context.Background(), query, userID)
+}
+
+// UpdateDisplayName inserts or updates the user's profile row with the new display name.
+// It returns an InternalError if the statement fails.
+func (r *SQLUserProfileRepository) UpdateDisplayName(userID int, displayName string) error {
+	const query = `INSERT INTO user_profiles (UserID, DisplayName)
+	VALUES (?, ?)
+	ON DUPLICATE KEY UPDATE DisplayName = VALUES(DisplayName)`
+
+	_, err := r.Exec(context.Background(), query, userID, displayName)
+	return err
+}
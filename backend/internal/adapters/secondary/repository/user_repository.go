@@ -2,28 +2,45 @@
 package repository
 
 import (
-	"database/sql"
+	"context"
 	"log"
+	"strings"
 
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	dbUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/db"
 	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
 	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
-	"github.com/jmoiron/sqlx"
 )
 
 // SQLUserRepository implements the UserRepository interface using a SQL database.
 
-// It requires a *sqlx.DB for database operations, a Generator for creating salts, and a Hasher for hashing passwords.
+// It requires a dbUtil.Queryer (a *sqlx.DB or a query-logging wrapper around it) for database operations and a Hasher (which generates its own salts) for hashing passwords.
+// Queries run through the embedded dbUtil.BaseRepository, which reports a missing user as ErrUserNotFound and a duplicate username as ErrUserAlreadyExists.
+// Accounts soft-deleted by DeleteUser (DeletedAt set) are ignored by every lookup, so they can no longer sign in.
 type SQLUserRepository struct {
-	db            *sqlx.DB
-	hasher        securityAuth.Hasher
+	dbUtil.BaseRepository[userRow]
+	hasher securityAuth.Hasher
+}
+
+// userRow holds the User_Registration columns read by SQLUserRepository. Queries select only the columns they need.
+type userRow struct {
+	ID       int    `db:"UserID"`
+	Password string `db:"Password"`
+	Role     string `db:"Role"`
+}
+
+// userNameNorm computes the value of the generated UserNameNorm column (LOWER(UserName)) for username.
+// Lookups compare against the indexed column directly; wrapping UserName in LOWER() would prevent MySQL from using the index.
+func userNameNorm(username string) string {
+	return strings.ToLower(username)
 }
 
 // NewSQLUserRepository creates a new SQLUserRepository instance.
 
 // It logs a fatal error if any dependency is nil, ensuring that the repository always has a valid database connection, salt generator, and hasher.
 // Returns an output.UserRepository ready for use.
-func NewSQLUserRepository(db *sqlx.DB, hasher securityAuth.Hasher) output.UserRepository {
+func NewSQLUserRepository(db dbUtil.Queryer, hasher securityAuth.Hasher) output.UserRepository {
 	if db == nil {
 		log.Fatal(errors.NewInternalError(errors.ErrDatabaseConnection).Error())
 	}
@@ -35,63 +52,60 @@ func NewSQLUserRepository(db *sqlx.DB, hasher securityAuth.Hasher) output.UserRe
 	log.Println("NewSQLUserRepository() is running successfully")
 
 	return &SQLUserRepository{
-		db:            db,
-		hasher:        hasher,
+		BaseRepository: dbUtil.NewBaseRepository[userRow](db, errors.ErrUserNotFound, errors.ErrUserAlreadyExists),
+		hasher:         hasher,
 	}
 }
 
-// UserExists checks whether a user with the given username exists in the database.
+// UserExists checks whether a user with the given username exists in the database, ignoring case.
 
 // It returns true if a matching record is found, or false otherwise.
 // Any SQL errors are wrapped as internal errors.
 func (r *SQLUserRepository) UserExists(username string) (bool, error) {
-	var exists bool
-	query := "SELECT EXISTS(SELECT 1 FROM User_Registration WHERE UserName = ?)"
-	err := r.db.QueryRow(query, username).Scan(&exists)
-	if err != nil {
-		return false, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+	_, err := r.FindOne(context.Background(), "SELECT UserID FROM User_Registration WHERE UserNameNorm = ? AND DeletedAt IS NULL", userNameNorm(username))
+	if errors.IsNotFound(err) {
+		return false, nil
 	}
-	return exists, nil
+	return err == nil, err
 }
 
-// GetHashPassword retrieves the hashed password for the specified username.
+// GetHashPassword retrieves the hashed password for the specified username, ignoring case.
 
 // If no record is found, returns a NotFoundError. Other SQL errors are wrapped as internal errors.
 func (r *SQLUserRepository) GetHashPassword(username string) (string, error) {
-	var hashPassword string
-	query := "SELECT Password FROM User_Registration WHERE UserName = ?"
-	err := r.db.QueryRow(query, username).Scan(&hashPassword)
+	user, err := r.FindOne(context.Background(), "SELECT Password FROM User_Registration WHERE UserNameNorm = ? AND DeletedAt IS NULL", userNameNorm(username))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", errors.NewNotFoundError(errors.ErrUserNotFound)
-		}
-		return "", errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+		return "", err
 	}
-	return hashPassword, nil
+	return user.Password, nil
 }
 
+// GetHashPasswordByID retrieves the hashed password of the user with the given ID.
+// If no record is found, returns a NotFoundError. Other SQL errors are wrapped as internal errors.
+func (r *SQLUserRepository) GetHashPasswordByID(userID int) (string, error) {
+	user, err := r.FindOne(context.Background(), "SELECT Password FROM User_Registration WHERE UserID = ? AND DeletedAt IS NULL", userID)
+	if err != nil {
+		return "", err
+	}
+	return user.Password, nil
+}
 
 // SaveUser inserts a new user into the database with a salted and hashed password.
 
-// It generates a new salt, combines it with the plain password, hashes the result, and executes an INSERT statement. Any generation, hashing, or SQL errors are wrapped as internal errors.
+// It generates a new salt, combines it with the plain password, hashes the result, and executes an INSERT statement. The normalized UserNameNorm column is generated by MySQL from UserName and cannot be written explicitly. A duplicate-key violation (a concurrent registration of the same username, in any casing) is returned as a ConflictError; any other generation, hashing, or SQL errors are wrapped as internal errors.
 func (r *SQLUserRepository) SaveUser(username, password string) error {
 
 	hash, err := r.hasher.Hash([]byte(password))
-	log.Println("hash", hash)
 	if err != nil {
 		return errors.NewInternalError(errors.ErrDatabaseInsert).WithError(err)
 	}
 
 	// Insert the new user record
-	_, err = r.db.Exec("INSERT INTO User_Registration (UserName, Password) VALUES (?, ?)", username, hash)
-	log.Println("err", err)
-	if err != nil {
-		return errors.NewInternalError(errors.ErrDatabaseInsert).WithError(err)
-	}
-	return nil
+	_, err = r.Exec(context.Background(), "INSERT INTO User_Registration (UserName, Password) VALUES (?, ?)", username, hash)
+	return err
 }
 
-// GetID retrieves the unique user ID for a given username from the database.
+// GetID retrieves the unique user ID for a given username from the database, ignoring case.
 // It returns a NotFoundError if no matching user is found, or an InternalError if any other database error occurs.
 
 // Parameters:
@@ -101,19 +115,87 @@ func (r *SQLUserRepository) SaveUser(username, password string) error {
 //   - int: the UserID corresponding to the provided username.
 //   - error: non-nil if the user is not found or a database error occurs.
 func (r *SQLUserRepository) GetID(username string) (int, error) {
-	var id int
-	query := "SELECT UserID FROM User_Registration WHERE UserName = ?"
+	user, err := r.FindOne(context.Background(), "SELECT UserID FROM User_Registration WHERE UserNameNorm = ? AND DeletedAt IS NULL", userNameNorm(username))
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// GetRole retrieves the role of the user with the given ID.
+// It returns a NotFoundError if no matching user is found, or an InternalError if any other database error occurs.
+func (r *SQLUserRepository) GetRole(userID int) (string, error) {
+	user, err := r.FindOne(context.Background(), "SELECT Role FROM User_Registration WHERE UserID = ? AND DeletedAt IS NULL", userID)
+	if err != nil {
+		return "", err
+	}
+	return user.Role, nil
+}
+
+// ListUsers retrieves one page of users ordered by UserID, together with the total number of users. Soft-deleted accounts are excluded.
+// The page is selected with LIMIT/OFFSET; a page beyond the last one returns no users but still reports the total. Only profile columns are selected, never the password hash.
+
+// Parameters:
+//   - page: 1-based page index.
+//   - pageSize: maximum number of users per page.
+
+// Returns:
+//   - []models.UserProfile: users of the requested page.
+//   - int: number of users across all pages.
+//   - error: non-nil if either query fails, wrapped as an InternalError.
+func (r *SQLUserRepository) ListUsers(page, pageSize int) ([]models.UserProfile, int, error) {
+	var total int
+	if err := r.Get(&total, "SELECT COUNT(*) FROM User_Registration WHERE DeletedAt IS NULL"); err != nil {
+		return nil, 0, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+	}
+
+	const query = `SELECT u.UserID, u.UserName, COALESCE(p.DisplayName, u.UserName) AS DisplayName, u.CreatedAt, u.Role
+	FROM User_Registration u
+	LEFT JOIN user_profiles p ON p.UserID = u.UserID
+	WHERE u.DeletedAt IS NULL
+	ORDER BY u.UserID
+	LIMIT ? OFFSET ?`
+
+	var users []models.UserProfile
+	offset := (page - 1) * pageSize
+	if err := r.SelectContext(context.Background(), &users, query, pageSize, offset); err != nil {
+		return nil, 0, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+	}
+	return users, total, nil
+}
+
+// DeleteUser soft-deletes the user with the given ID by setting its DeletedAt column.
+// It returns a NotFoundError if the user does not exist or was already deleted, or an InternalError if the update fails.
+func (r *SQLUserRepository) DeleteUser(userID int) error {
+	result, err := r.Exec(context.Background(), "UPDATE User_Registration SET DeletedAt = NOW() WHERE UserID = ? AND DeletedAt IS NULL", userID)
+	if err != nil {
+		return err
+	}
 
-	// Execute the query and scan the single result into id.
-	err := r.db.QueryRow(query, username).Scan(&id)
+	rows, err := result.RowsAffected()
 	if err != nil {
-		// No matching row indicates user does not exist.
-		if err == sql.ErrNoRows {
-			return 0, errors.NewNotFoundError(errors.ErrUserNotFound)
-		}
-		// Wrap other errors as InternalError for upstream handling.
-		return 0, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
-	}
-	// Return the found user ID.
-	return id, nil
-}
\ No newline at end of file
+		return errors.NewInternalError(errors.ErrDatabaseUpdate).WithError(err)
+	}
+	if rows == 0 {
+		return errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	return nil
+}
+
+// UpdatePassword stores a new password hash for the user with the given ID.
+// It returns a NotFoundError if no row was updated, or an InternalError if the update fails.
+func (r *SQLUserRepository) UpdatePassword(userID int, hash string) error {
+	result, err := r.Exec(context.Background(), "UPDATE User_Registration SET Password = ? WHERE UserID = ?", hash, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewInternalError(errors.ErrDatabaseUpdate).WithError(err)
+	}
+	if rows == 0 {
+		return errors.NewNotFoundError(errors.ErrUserNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package repository_test
+
+import (
+	"database/sql"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+var userProfileColumns = []string{"UserID", "UserName", "DisplayName", "CreatedAt", "Role"}
+
+func newMockUserRepository(t *testing.T) (output.UserRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+		db.Close()
+	})
+	return repository.NewSQLUserRepository(sqlx.NewDb(db, "sqlmock"), securityAuth.BcryptHasher{}), mock
+}
+
+func TestListUsers(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	repo, mock := newMockUserRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM User_Registration WHERE DeletedAt IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta("LIMIT ? OFFSET ?")).WithArgs(5, 10).
+		WillReturnRows(sqlmock.NewRows(userProfileColumns).
+			AddRow(11, "alice", "Alice", createdAt, models.RoleAdmin).
+			AddRow(12, "bob", "bob", createdAt, models.RoleUser))
+
+	users, total, err := repo.ListUsers(3, 5)
+	if err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+	want := []models.UserProfile{
+		{ID: 11, UserName: "alice", DisplayName: "Alice", CreatedAt: createdAt, Role: models.RoleAdmin},
+		{ID: 12, UserName: "bob", DisplayName: "bob", CreatedAt: createdAt, Role: models.RoleUser},
+	}
+	if total != 12 || len(users) != len(want) || users[0] != want[0] || users[1] != want[1] {
+		t.Errorf("Expected %+v of 12, Got %+v of %d", want, users, total)
+	}
+}
+
+func TestListUsersEmpty(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM User_Registration")).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("LIMIT ? OFFSET ?")).WithArgs(20, 0).
+		WillReturnRows(sqlmock.NewRows(userProfileColumns))
+
+	users, total, err := repo.ListUsers(1, 20)
+	if err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+	if total != 0 || len(users) != 0 {
+		t.Errorf("Expected no users, Got %+v of %d", users, total)
+	}
+}
+
+func TestListUsersDatabaseErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		failCount bool
+	}{
+		{name: "count fails", failCount: true},
+		{name: "select fails"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockUserRepository(t)
+			count := mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM User_Registration"))
+			if tt.failCount {
+				count.WillReturnError(sql.ErrConnDone)
+			} else {
+				count.WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+				mock.ExpectQuery(regexp.QuoteMeta("LIMIT ? OFFSET ?")).WillReturnError(sql.ErrConnDone)
+			}
+
+			if _, _, err := repo.ListUsers(1, 20); !errors.IsInternalError(err) {
+				t.Errorf("Expected an InternalError, Got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetIDQueriesNormalizedUserName(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE UserNameNorm = ? AND DeletedAt IS NULL")).WithArgs("alice").
+		WillReturnRows(sqlmock.NewRows([]string{"UserID"}).AddRow(11))
+
+	id, err := repo.GetID("Alice")
+	if err != nil || id != 11 {
+		t.Errorf("GetID() = %d, %v; want 11, nil", id, err)
+	}
+}
+
+func TestSaveUserConcurrentDuplicate(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+	mock.MatchExpectationsInOrder(false)
+
+	// Every registration reaches the INSERT; the UNIQUE index on UserNameNorm lets exactly one of them through.
+	const registrations = 5
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO User_Registration (UserName, Password)")).
+		WithArgs("alice", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	for i := 1; i < registrations; i++ {
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO User_Registration (UserName, Password)")).
+			WithArgs("alice", sqlmock.AnyArg()).WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'alice'"})
+	}
+
+	results := make([]error, registrations)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = repo.SaveUser("alice", "Str0ng!Password")
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range results {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.IsConflict(err):
+			t.Errorf("Expected a ConflictError for the duplicate registration, Got: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("Expected exactly one registration to succeed, Got %d", succeeded)
+	}
+}
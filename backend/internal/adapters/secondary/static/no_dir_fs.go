@@ -0,0 +1,34 @@
+// Package static provides an implementation of the StaticFilePort interface for serving static files from a configured directory in the sale-watches application.
+// This file contains noDirFS, the filesystem wrapper that prevents http.FileServer from listing directories.
+package static
+
+import (
+	"io/fs"
+	"os"
+)
+
+// noDirFS wraps an fs.FS and refuses to open directories.
+
+// http.FileServer renders a listing for any directory it can open; returning os.ErrPermission makes it answer 403 Forbidden instead, so the layout of the static directory is never exposed.
+type noDirFS struct {
+	fsys fs.FS
+}
+
+// Open opens the named file, returning os.ErrPermission if it is a directory.
+func (n noDirFS) Open(name string) (fs.File, error) {
+	file, err := n.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: os.ErrPermission}
+	}
+	return file, nil
+}
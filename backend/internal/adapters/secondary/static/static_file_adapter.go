@@ -7,13 +7,18 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
 // StaticFileAdapter implements the output.StaticFilePort interface.
 
 // It serves files from a base directory, validates requested paths to prevent directory traversal, and determines the correct MIME type for each file.
+// Public URLs are built under urlPrefix, which is "/" unless the assets are served from a CDN.
+// Content hashes used for versioned URLs are cached in hashes, keyed by file path and modification time.
 type StaticFileAdapter struct {
 	staticDir string
+	urlPrefix string
+	hashes    sync.Map
 }
 
 // NewStaticFileAdapter creates a new StaticFileAdapter with the given directory.
@@ -23,25 +28,44 @@ type StaticFileAdapter struct {
 func NewStaticFileAdapter(staticDir string) *StaticFileAdapter {
 	return &StaticFileAdapter{
 		staticDir: staticDir,
+		urlPrefix: "/",
 	}
 }
 
+// WithURLPrefix sets the prefix of the public URLs of static files, e.g. "https://cdn.example.com/assets/", and returns the adapter.
+// An empty prefix is treated as "/", i.e. assets served by this application.
+func (s *StaticFileAdapter) WithURLPrefix(prefix string) *StaticFileAdapter {
+	if prefix == "" {
+		prefix = "/"
+	}
+	s.urlPrefix = prefix
+	return s
+}
+
 // GetStaticDir returns the root directory configured for static file serving.
 func (s *StaticFileAdapter) GetStaticDir() string {
 	return s.staticDir
 }
 
+// GetPublicURL returns the URL under which clients fetch the static file at path, by joining the configured URL prefix and path.
+
+// path is relative to the static root; a leading slash is optional (e.g. "css/style.css" becomes "https://cdn.example.com/assets/css/style.css").
+func (s *StaticFileAdapter) GetPublicURL(path string) string {
+	return strings.TrimSuffix(s.urlPrefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
 // GetFileHandler returns an HTTP handler that serves files from a specific subdirectory.
 
 // prefix: the URL path prefix to strip (e.g., "/static/")
 // subPath: a subdirectory under staticDir (e.g., "images")
 // The returned handler serves files from filepath.Join(staticDir, subPath), optionally stripping the given URL prefix before filesystem lookup
+// Directories are never listed: the file server is backed by a noDirFS, so requesting a directory yields 403 Forbidden.
 func (s *StaticFileAdapter) GetFileHandler(prefix, subPath string) http.Handler {
 	// Build the full filesystem path
 	fullPath := filepath.Join(s.staticDir, subPath)
 
-	// Create the standard file server
-	fileServer := http.FileServer(http.Dir(fullPath))
+	// Create the standard file server on a filesystem that hides directories
+	fileServer := http.FileServer(http.FS(noDirFS{fsys: os.DirFS(fullPath)}))
 
 	// If a non-root prefix is provided, strip it before serving
 	if prefix != "" && prefix != "/" {
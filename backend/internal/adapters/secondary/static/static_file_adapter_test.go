@@ -0,0 +1,117 @@
+package static_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/static"
+)
+
+func TestGetFileHandlerDoesNotListDirectories(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "assets", "img"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "assets", "logo.png"), []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := static.NewStaticFileAdapter(root).GetFileHandler("/assets/", "assets")
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/assets/logo.png", http.StatusOK},
+		{"/assets/missing.png", http.StatusNotFound},
+		{"/assets/", http.StatusForbidden},
+		{"/assets/img/", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("GET %s: Expected status %d, Got %d", tt.path, tt.status, rec.Code)
+		}
+	}
+}
+
+func TestVersionedURLChangesWithContent(t *testing.T) {
+	root := t.TempDir()
+	cssPath := filepath.Join(root, "css", "style.css")
+	if err := os.MkdirAll(filepath.Dir(cssPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cssPath, []byte("body{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	adapter := static.NewStaticFileAdapter(root)
+
+	first, err := adapter.VersionedURL("/css/style.css")
+	if err != nil {
+		t.Fatalf("VersionedURL() error = %v", err)
+	}
+	if !strings.HasPrefix(first, "/css/style.css?v=") {
+		t.Errorf("Expected /css/style.css?v=<hash>, Got %s", first)
+	}
+
+	if err := os.WriteFile(cssPath, []byte("body{color:red}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(cssPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := adapter.VersionedURL("css/style.css")
+	if err != nil {
+		t.Fatalf("VersionedURL() error = %v", err)
+	}
+	if second == first {
+		t.Errorf("Expected a new version after the file changed, Got %s twice", first)
+	}
+
+	manifest, err := adapter.VersionManifest()
+	if err != nil {
+		t.Fatalf("VersionManifest() error = %v", err)
+	}
+	if manifest["/css/style.css"] != second {
+		t.Errorf("Expected manifest entry %s, Got %s", second, manifest["/css/style.css"])
+	}
+
+	if _, err := adapter.VersionedURL("../secret.txt"); err == nil {
+		t.Error("Expected an error for a path outside the static directory")
+	}
+}
+
+func TestPublicURLsUseConfiguredPrefix(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "app.js"), []byte("void 0"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	local := static.NewStaticFileAdapter(root)
+	if got := local.GetPublicURL("css/style.css"); got != "/css/style.css" {
+		t.Errorf("Expected /css/style.css, Got %s", got)
+	}
+
+	cdn := static.NewStaticFileAdapter(root).WithURLPrefix("https://cdn.example.com/assets/")
+	if got := cdn.GetPublicURL("/css/style.css"); got != "https://cdn.example.com/assets/css/style.css" {
+		t.Errorf("Expected https://cdn.example.com/assets/css/style.css, Got %s", got)
+	}
+
+	versioned, err := cdn.VersionedURL("/app.js")
+	if err != nil {
+		t.Fatalf("VersionedURL() error = %v", err)
+	}
+	if !strings.HasPrefix(versioned, "https://cdn.example.com/assets/app.js?v=") {
+		t.Errorf("Expected https://cdn.example.com/assets/app.js?v=<hash>, Got %s", versioned)
+	}
+}
@@ -0,0 +1,90 @@
+// Package static provides an implementation of the StaticFilePort interface for serving static files from a configured directory in the sale-watches application.
+// This file contains content-hash versioning of static asset URLs, used for cache busting.
+package static
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// versionHashLength is the number of hex characters of the SHA-256 digest kept in the ?v= query parameter.
+const versionHashLength = 12
+
+// VersionedURL returns the URL of the static file at path with a content hash appended, e.g. "/css/style.css?v=3f2a9c1d0b7e".
+
+// path is relative to the static root; a leading slash is optional. The URL is built with GetPublicURL, so it points to the CDN when a URL prefix is configured. The hash is cached per path and modification time, so it is only recomputed after the file changes on disk. Paths escaping the static directory and directories are rejected with os.ErrNotExist.
+func (s *StaticFileAdapter) VersionedURL(path string) (string, error) {
+	cleanPath := filepath.Clean(strings.TrimPrefix(path, "/"))
+	if strings.Contains(cleanPath, "..") {
+		return "", os.ErrNotExist
+	}
+
+	fullPath := filepath.Join(s.staticDir, cleanPath)
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", os.ErrNotExist
+	}
+
+	hash, err := s.contentHash(fullPath, info)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s?v=%s", s.GetPublicURL(filepath.ToSlash(cleanPath)), hash), nil
+}
+
+// VersionManifest returns the versioned URL of every file under the static directory, keyed by its URL path (e.g. "/css/style.css").
+func (s *StaticFileAdapter) VersionManifest() (map[string]string, error) {
+	manifest := make(map[string]string)
+	err := filepath.WalkDir(s.staticDir, func(fullPath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(s.staticDir, fullPath)
+		if err != nil {
+			return err
+		}
+		versioned, err := s.VersionedURL(relPath)
+		if err != nil {
+			return err
+		}
+		manifest["/"+filepath.ToSlash(relPath)] = versioned
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// contentHash returns the truncated hex SHA-256 digest of the file at fullPath, reusing the cached value while its modification time is unchanged.
+func (s *StaticFileAdapter) contentHash(fullPath string, info fs.FileInfo) (string, error) {
+	key := fmt.Sprintf("%s|%d", fullPath, info.ModTime().UnixNano())
+	if hash, ok := s.hashes.Load(key); ok {
+		return hash.(string), nil
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	digest := sha256.New()
+	if _, err := io.Copy(digest, file); err != nil {
+		return "", err
+	}
+
+	hash := hex.EncodeToString(digest.Sum(nil))[:versionHashLength]
+	s.hashes.Store(key, hash)
+	return hash, nil
+}
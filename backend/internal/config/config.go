@@ -3,18 +3,33 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
 // AppConfig holds the Viper instance for application-wide settings.
 // It offers typed accessors for different configuration values and validation routines for security-sensitive settings.
+// It is safe for concurrent use: the Viper instance is only reached through lockedViper.
 type AppConfig struct {
-	config *viper.Viper
+	config *lockedViper
+
+	// overridesMu serializes ApplyOverrides, so two changes cannot both be validated against the same previous configuration.
+	overridesMu sync.Mutex
+
+	// routesMu guards disabledRoutes, which is rebuilt whenever the configuration file changes while hot reload is enabled.
+	routesMu       sync.RWMutex
+	disabledRoutes map[string]bool
 }
 
 // NewAppConfig initializes and returns a new AppConfig.
@@ -29,18 +44,69 @@ func NewAppConfig() *AppConfig {
 
 	// Default values for JWT, server port, rate limiting, static directory, and database
 	config.SetDefault("security.jwt.jwt_secret", "your-secret-key")
+	config.SetDefault("security.jwt.clock_skew_seconds", 30)
+	config.SetDefault("security.jwt.refresh_token_ttl_hours", 7*24)
+	config.SetDefault("security.jwt.refresh_grace_seconds", 60)
+	config.SetDefault("security.cookie.refresh_name", "refresh_token")
+	config.SetDefault("security.cookie.auth_name", "token")
+	config.SetDefault("security.csrf_secret", "")
+	config.SetDefault("security.salt_bytes", 32)
+	config.SetDefault("security.password_hash_algorithm", "argon2id")
+	config.SetDefault("security.argon2.time", 3)
+	config.SetDefault("security.argon2.memory", 64*1024)
+	config.SetDefault("security.argon2.threads", 2)
+	config.SetDefault("security.argon2.key_length", 32)
+	config.SetDefault("security.username.max_length", 32)
+	config.SetDefault("security.username.allowed_pattern", DefaultUsernamePattern)
 
 	config.SetDefault("server.port", "8080")
+	config.SetDefault("server.tls.cert_file", "")
+	config.SetDefault("server.tls.key_file", "")
+	config.SetDefault("server.http_redirect_port", "80")
+	config.SetDefault("server.deduplication_ttl_ms", 1000)
+	config.SetDefault("server.response_buffer_bytes", 512*1024)
+	config.SetDefault("server.json_pretty_print", false)
+	config.SetDefault("server.read_timeout_seconds", 5)
+	config.SetDefault("server.write_timeout_seconds", 10)
+	config.SetDefault("server.idle_timeout_seconds", 120)
+	config.SetDefault("server.read_header_timeout_ms", 1000)
 	config.SetDefault("rate_limiting.requests", 10.0)
 	config.SetDefault("rate_limiting.cleanup_minutes", 5)
+	config.SetDefault("rate_limiting.cleanup.expiration_minutes", 15)
+	config.SetDefault("rate_limiting.cleanup.interval_minutes", DefaultRateLimiterCleanupIntervalMinutes)
+	config.SetDefault("rate_limiting.csrf_token.requests", 50.0)
+	config.SetDefault("rate_limiting.csrf_token.burst", 100)
+	config.SetDefault("rate_limiting.redis_url", "")
 
 	config.SetDefault("STATIC_DIR", "./../frontend")
+	config.SetDefault("static.url_prefix", "/")
+	config.SetDefault("static.spa_mode", false)
+	config.SetDefault("docs.swagger_ui_dir", "")
+
+	config.SetDefault("cors.allowed_origins", []string{"*"})
+
+	config.SetDefault("logging.sensitive_query_params", []string{"token", "password", "secret", "api_key", "code"})
+	config.SetDefault("logging.sla_log_enabled", false)
+
+	config.SetDefault("security.geo.db_path", "")
+	config.SetDefault("security.geo.blocked_countries", []string{})
+	config.SetDefault("security.geo.allowed_countries", []string{})
 
 	config.SetDefault("database.user", "root")
 	config.SetDefault("database.password", "password")
 	config.SetDefault("database.host", "localhost")
 	config.SetDefault("database.port", 3306)
 	config.SetDefault("database.name", "store_watches")
+	config.SetDefault("database.slow_query_threshold_ms", 200)
+	config.SetDefault("database.connect_retries", 5)
+	config.SetDefault("database.connect_max_backoff", "16s")
+	config.SetDefault("database.seed_on_startup", false)
+	config.SetDefault("database.seeds_dir", "./seeds")
+
+	config.SetDefault("DEBUG", false)
+
+	config.SetDefault("feature_flags.disabled_routes", []string{})
+	config.SetDefault("config.hot_reload", false)
 
 	// Allow environment variables to override settings
 	config.AutomaticEnv()
@@ -51,9 +117,74 @@ func NewAppConfig() *AppConfig {
 		log.Println("Using default values and environment variable")
 	}
 
-	return &AppConfig{
-		config: config,
+	appConfig := &AppConfig{
+		config: newLockedViper(config),
+	}
+	appConfig.loadDisabledRoutes()
+
+	if appConfig.IsHotReloadEnabled() {
+		appConfig.watchConfig()
+	}
+
+	return appConfig
+}
+
+// watchConfig watches the configuration file and, on every change, rereads it and reloads the disabled routes.
+// viper.WatchConfig is not used because it rereads the file without any lock, racing with the requests reading the configuration.
+func (a *AppConfig) watchConfig() {
+	file := a.config.ConfigFileUsed()
+	if file == "" {
+		log.Println("Warning: hot reload is enabled but no configuration file was read")
+		return
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("Warning: cannot watch the configuration file: %v", err)
+		return
+	}
+	// Editors often replace the file instead of writing it, which only its directory sees.
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		log.Printf("Warning: cannot watch the configuration file: %v", err)
+		watcher.Close()
+		return
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(file) || !event.Has(fsnotify.Write|fsnotify.Create) {
+					continue
+				}
+				if err := a.config.ReadInConfig(); err != nil {
+					log.Printf("Error reloading configuration from %s: %v", event.Name, err)
+					continue
+				}
+				a.loadDisabledRoutes()
+				log.Printf("Configuration reloaded from %s", event.Name)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Error watching the configuration file: %v", err)
+			}
+		}
+	}()
+}
+
+// loadDisabledRoutes rebuilds the set of disabled route paths from feature_flags.disabled_routes.
+func (a *AppConfig) loadDisabledRoutes() {
+	disabled := make(map[string]bool)
+	for _, path := range a.config.GetStringSlice("feature_flags.disabled_routes") {
+		disabled[path] = true
 	}
+
+	a.routesMu.Lock()
+	a.disabledRoutes = disabled
+	a.routesMu.Unlock()
 }
 
 // GetPort returns the HTTP server port as a string.
@@ -66,9 +197,29 @@ func (a *AppConfig) GetPort() string {
 	return port
 }
 
-// GetConfig exposes the underlying Viper instance for advanced use cases.
-func (a *AppConfig) GetConfig() *viper.Viper {
-	return a.config
+// GetTLSCertFile returns the path to the TLS certificate (PEM). Empty when TLS is disabled.
+func (a *AppConfig) GetTLSCertFile() string {
+	return a.config.GetString("server.tls.cert_file")
+}
+
+// GetTLSKeyFile returns the path to the TLS private key (PEM). Empty when TLS is disabled.
+func (a *AppConfig) GetTLSKeyFile() string {
+	return a.config.GetString("server.tls.key_file")
+}
+
+// IsTLSEnabled returns true when both a certificate and a private key are configured.
+func (a *AppConfig) IsTLSEnabled() bool {
+	return a.GetTLSCertFile() != "" && a.GetTLSKeyFile() != ""
+}
+
+// GetHTTPRedirectPort returns the plain HTTP port that redirects to HTTPS when TLS is enabled.
+// It falls back to "80" if not set.
+func (a *AppConfig) GetHTTPRedirectPort() string {
+	port := a.config.GetString("server.http_redirect_port")
+	if port == "" {
+		return "80"
+	}
+	return port
 }
 
 // GetJWTSecret retrieves the JWT secret key from configuration.
@@ -76,6 +227,74 @@ func (a *AppConfig) GetJWTSecret() string {
 	return a.config.GetString("security.jwt.jwt_secret")
 }
 
+// GetCSRFSecret returns the key CSRF tokens are signed with, from security.csrf_secret, falling back to the JWT secret when it is not set.
+func (a *AppConfig) GetCSRFSecret() string {
+	if secret := a.config.GetString("security.csrf_secret"); secret != "" {
+		return secret
+	}
+	return a.GetJWTSecret()
+}
+
+// GetJWTClockSkew returns how long after its expiry a JWT is still accepted, to tolerate clock differences, from security.jwt.clock_skew_seconds.
+func (a *AppConfig) GetJWTClockSkew() time.Duration {
+	return time.Duration(a.config.GetInt("security.jwt.clock_skew_seconds")) * time.Second
+}
+
+// GetRefreshTokenConfig returns the refresh token settings from security.jwt.refresh_token_ttl_hours, security.jwt.refresh_grace_seconds and security.cookie.refresh_name.
+// A TTL of zero disables refresh tokens.
+func (a *AppConfig) GetRefreshTokenConfig() models.RefreshTokenConfig {
+	return models.RefreshTokenConfig{
+		TTL:         time.Duration(a.config.GetInt("security.jwt.refresh_token_ttl_hours")) * time.Hour,
+		GracePeriod: time.Duration(a.config.GetInt("security.jwt.refresh_grace_seconds")) * time.Second,
+		CookieName:  a.config.GetString("security.cookie.refresh_name"),
+	}
+}
+
+// GetAuthCookieName returns the name of the cookie that stores the JWT token.
+// Defaults to "token"; a custom name allows several instances to run side by side on the same domain.
+func (a *AppConfig) GetAuthCookieName() string {
+	return a.config.GetString("security.cookie.auth_name")
+}
+
+// GetSaltByteLength returns the number of random bytes in each password salt.
+func (a *AppConfig) GetSaltByteLength() int {
+	return a.config.GetInt("security.salt_bytes")
+}
+
+// GetActiveHashAlgorithm returns the algorithm ("argon2id" or "bcrypt") used to hash new passwords.
+// Stored hashes produced by another algorithm are re-hashed with it on the user's next successful login.
+func (a *AppConfig) GetActiveHashAlgorithm() string {
+	return a.config.GetString("security.password_hash_algorithm")
+}
+
+// GetArgon2Config returns the Argon2id cost parameters from security.argon2 settings.
+// Defaults follow the RFC 9106 recommendations: 3 passes, 64 MiB, 2 threads and a 32-byte key.
+func (a *AppConfig) GetArgon2Config() models.Argon2Config {
+	return models.Argon2Config{
+		Time:    a.config.GetUint32("security.argon2.time"),
+		Memory:  a.config.GetUint32("security.argon2.memory"),
+		Threads: uint8(a.config.GetUint("security.argon2.threads")),
+		KeyLen:  a.config.GetUint32("security.argon2.key_length"),
+	}
+}
+
+// DefaultUsernamePattern restricts usernames to ASCII letters, digits, underscores and hyphens.
+const DefaultUsernamePattern = `^[a-zA-Z0-9_-]+$`
+
+// GetUsernamePolicy returns the rules applied to new usernames.
+// An invalid security.username.allowed_pattern is reported by ValidateConfig and replaced here by DefaultUsernamePattern.
+func (a *AppConfig) GetUsernamePolicy() models.UsernamePolicy {
+	pattern, err := regexp.Compile(a.config.GetString("security.username.allowed_pattern"))
+	if err != nil {
+		pattern = regexp.MustCompile(DefaultUsernamePattern)
+	}
+
+	return models.UsernamePolicy{
+		MaxLength:      a.config.GetInt("security.username.max_length"),
+		AllowedPattern: pattern,
+	}
+}
+
 // GetRateLimitConfig returns a LimiterConfig populated from rate_limiting settings.
 func (a *AppConfig) GetRateLimitConfig() models.LimiterConfig {
 	return models.LimiterConfig{
@@ -84,6 +303,89 @@ func (a *AppConfig) GetRateLimitConfig() models.LimiterConfig {
 	}
 }
 
+// GetCSRFTokenRateLimitConfig returns the looser LimiterConfig applied to GET /auth/csrf-token, populated from rate_limiting.csrf_token settings.
+func (a *AppConfig) GetCSRFTokenRateLimitConfig() models.LimiterConfig {
+	return models.LimiterConfig{
+		RequestPerSecond: a.config.GetFloat64("rate_limiting.csrf_token.requests"),
+		Burst:            a.config.GetInt("rate_limiting.csrf_token.burst"),
+	}
+}
+
+// DefaultRateLimiterCleanupIntervalMinutes is how often inactive rate limiters are purged unless rate_limiting.cleanup.interval_minutes says otherwise.
+const DefaultRateLimiterCleanupIntervalMinutes = 5
+
+// GetRateLimiterCleanup returns the schedule for purging inactive rate limiters from rate_limiting.cleanup settings.
+// An interval below one minute, which would make the cleaner's ticker panic, is reported by ValidateConfig and replaced here by DefaultRateLimiterCleanupIntervalMinutes.
+func (a *AppConfig) GetRateLimiterCleanup() models.RateLimiterCleanupConfig {
+	interval := a.config.GetInt("rate_limiting.cleanup.interval_minutes")
+	if interval < 1 {
+		interval = DefaultRateLimiterCleanupIntervalMinutes
+	}
+	return models.RateLimiterCleanupConfig{
+		ExpirationMinutes:      a.config.GetInt("rate_limiting.cleanup.expiration_minutes"),
+		CleanupIntervalMinutes: interval,
+	}
+}
+
+// GetRateLimiterRedisURL returns the redis:// URL of the Redis server holding the rate limiters' buckets, from rate_limiting.redis_url.
+// An empty URL keeps the buckets in memory, per instance.
+func (a *AppConfig) GetRateLimiterRedisURL() string {
+	return a.config.GetString("rate_limiting.redis_url")
+}
+
+// GetDeduplicationTTL returns how long responses collapsed by the request deduplication middleware are replayed, from server.deduplication_ttl_ms.
+// A value of zero disables deduplication.
+func (a *AppConfig) GetDeduplicationTTL() time.Duration {
+	return time.Duration(a.config.GetInt("server.deduplication_ttl_ms")) * time.Millisecond
+}
+
+// GetResponseBufferBytes returns the size up to which API responses are buffered to send a Content-Length header, from server.response_buffer_bytes.
+func (a *AppConfig) GetResponseBufferBytes() int64 {
+	return a.config.GetInt64("server.response_buffer_bytes")
+}
+
+// GetServerTimeouts returns the connection timeouts of the HTTP servers from server.read_timeout_seconds, server.read_header_timeout_ms, server.write_timeout_seconds and server.idle_timeout_seconds.
+func (a *AppConfig) GetServerTimeouts() models.ServerTimeouts {
+	return models.ServerTimeouts{
+		Read:       time.Duration(a.config.GetInt("server.read_timeout_seconds")) * time.Second,
+		ReadHeader: time.Duration(a.config.GetInt("server.read_header_timeout_ms")) * time.Millisecond,
+		Write:      time.Duration(a.config.GetInt("server.write_timeout_seconds")) * time.Second,
+		Idle:       time.Duration(a.config.GetInt("server.idle_timeout_seconds")) * time.Second,
+	}
+}
+
+// GetJSONPrettyPrint reports whether JSON responses are indented for readability, from server.json_pretty_print.
+func (a *AppConfig) GetJSONPrettyPrint() bool {
+	return a.config.GetBool("server.json_pretty_print")
+}
+
+// GetSensitiveQueryParams returns the query parameter names whose values are redacted from request logs, from logging.sensitive_query_params.
+func (a *AppConfig) GetSensitiveQueryParams() []string {
+	return a.config.GetStringSlice("logging.sensitive_query_params")
+}
+
+// GetSLALogEnabled reports whether every request is persisted to the http_request_log table for SLA reporting, from logging.sla_log_enabled.
+func (a *AppConfig) GetSLALogEnabled() bool {
+	return a.config.GetBool("logging.sla_log_enabled")
+}
+
+// GetSwaggerUIDir returns the directory holding the Swagger UI distribution served under /docs/, from docs.swagger_ui_dir.
+// It is empty by default, which disables the documentation routes.
+func (a *AppConfig) GetSwaggerUIDir() string {
+	return a.config.GetString("docs.swagger_ui_dir")
+}
+
+// GetSPAMode reports whether unknown navigational paths are answered with index.html, so a single-page application can handle client-side routes, from static.spa_mode.
+func (a *AppConfig) GetSPAMode() bool {
+	return a.config.GetBool("static.spa_mode")
+}
+
+// GetStaticURLPrefix returns the prefix of public static asset URLs, from static.url_prefix.
+// It defaults to "/"; set it to a CDN base URL (e.g. "https://cdn.example.com/assets/") to serve assets from the CDN without code changes.
+func (a *AppConfig) GetStaticURLPrefix() string {
+	return a.config.GetString("static.url_prefix")
+}
+
 // GetStaticDir returns the path to the static files directory.
 // It verifies that the configured directory exists, and if not, attempts to resolve an alternate path relative to the executable.
 // Logs a warning if neither path exists.
@@ -118,10 +420,167 @@ func (a *AppConfig) IsProduction() bool {
 	return a.config.GetString("ENV") == "production"
 }
 
-// ValidateConfig performs sanity checks on critical settings.
-// Currently warns if the default JWT secret is used in production
-func (a *AppConfig) ValidateConfig() {
-	if a.GetJWTSecret() == "your-secret-key" && a.IsProduction() {
-		log.Println("WARNING: Using default JWT key in production, this is insecure")
+// IsDebugMode returns true if the DEBUG environment variable is set to a true value.
+// In debug mode every database query is logged.
+func (a *AppConfig) IsDebugMode() bool {
+	return a.config.GetBool("DEBUG")
+}
+
+// IsHotReloadEnabled returns true if config.hot_reload is set, in which case changes to the configuration file are picked up without a restart.
+// Only the feature flags are reloaded; other settings are read once at startup.
+func (a *AppConfig) IsHotReloadEnabled() bool {
+	return a.config.GetBool("config.hot_reload")
+}
+
+// IsRouteDisabled reports whether the route registered with the given path template is listed in feature_flags.disabled_routes.
+// It is safe to call concurrently with a configuration reload.
+func (a *AppConfig) IsRouteDisabled(path string) bool {
+	a.routesMu.RLock()
+	defer a.routesMu.RUnlock()
+	return a.disabledRoutes[path]
+}
+
+// GetDatabaseDSN builds the MySQL data source name from the database.* settings.
+func (a *AppConfig) GetDatabaseDSN() string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
+		a.config.GetString("database.user"),
+		a.config.GetString("database.password"),
+		a.config.GetString("database.host"),
+		a.config.GetInt("database.port"),
+		a.config.GetString("database.name"),
+	)
+}
+
+// GetSeedOnStartup returns true if the API server should apply pending database seeds before it starts serving.
+func (a *AppConfig) GetSeedOnStartup() bool {
+	return a.config.GetBool("database.seed_on_startup")
+}
+
+// GetSeedsDir returns the directory holding the *.up.sql seed files.
+func (a *AppConfig) GetSeedsDir() string {
+	return a.config.GetString("database.seeds_dir")
+}
+
+// GetDBConnectRetries returns how many times the startup database connection is attempted.
+// Values below 1 are treated as a single attempt.
+func (a *AppConfig) GetDBConnectRetries() int {
+	retries := a.config.GetInt("database.connect_retries")
+	if retries < 1 {
+		return 1
+	}
+	return retries
+}
+
+// GetDBConnectMaxBackoff returns the longest wait between two database connection attempts.
+func (a *AppConfig) GetDBConnectMaxBackoff() time.Duration {
+	return a.config.GetDuration("database.connect_max_backoff")
+}
+
+// GetSlowQueryThreshold returns the duration beyond which a database query is logged as slow.
+func (a *AppConfig) GetSlowQueryThreshold() time.Duration {
+	return time.Duration(a.config.GetInt("database.slow_query_threshold_ms")) * time.Millisecond
+}
+
+// GetCORSAllowedOrigins returns the origins allowed to perform cross-origin requests.
+// Defaults to ["*"], which is only acceptable during development.
+func (a *AppConfig) GetCORSAllowedOrigins() []string {
+	return a.config.GetStringSlice("cors.allowed_origins")
+}
+
+// GetGeoDBPath returns the path to the GeoLite2 country database.
+// An empty string means geographic filtering is disabled.
+func (a *AppConfig) GetGeoDBPath() string {
+	return a.config.GetString("security.geo.db_path")
+}
+
+// GetGeoBlockedCountries returns the ISO country codes rejected by the geographic filter.
+func (a *AppConfig) GetGeoBlockedCountries() []string {
+	return a.config.GetStringSlice("security.geo.blocked_countries")
+}
+
+// GetGeoAllowedCountries returns the ISO country codes accepted by the geographic filter.
+// When not empty, it replaces the blocklist with an allowlist.
+func (a *AppConfig) GetGeoAllowedCountries() []string {
+	return a.config.GetStringSlice("security.geo.allowed_countries")
+}
+
+// minJWTSecretLength is the minimum number of characters accepted for the JWT signing secret.
+const minJWTSecretLength = 32
+
+// ConfigError describes a single invalid configuration setting.
+type ConfigError struct {
+	Field   string // Configuration key that failed validation
+	Message string // Human-readable description of the problem
+}
+
+// Error implements the error interface so a ConfigError can be logged or wrapped directly.
+func (e ConfigError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidateConfig performs sanity checks on critical settings and returns every problem found.
+// An empty slice means the configuration is valid. Some checks only apply in production:
+//   - server.port must be numeric
+//   - database.password must not be empty (production only)
+//   - security.jwt.jwt_secret must not be the default key and must be at least 32 characters
+//   - cors.allowed_origins must not be ["*"] (production only)
+//   - STATIC_DIR must exist on disk
+//   - security.salt_bytes must provide at least 128 bits of entropy
+//   - rate_limiting.cleanup.interval_minutes, when set, must be at least 1
+//
+// The caller decides how to react: main treats any error as fatal in production and as a warning otherwise.
+func (a *AppConfig) ValidateConfig() []ConfigError {
+	var configErrors []ConfigError
+	isProduction := a.IsProduction()
+
+	if _, err := strconv.Atoi(a.GetPort()); err != nil {
+		configErrors = append(configErrors, ConfigError{Field: "server.port", Message: "must be numeric"})
+	}
+
+	if isProduction && a.config.GetString("database.password") == "" {
+		configErrors = append(configErrors, ConfigError{Field: "database.password", Message: "must not be empty in production"})
 	}
+
+	jwtSecret := a.GetJWTSecret()
+	if jwtSecret == "your-secret-key" {
+		configErrors = append(configErrors, ConfigError{Field: "security.jwt.jwt_secret", Message: "the default JWT key is insecure"})
+	}
+	if len(jwtSecret) < minJWTSecretLength {
+		configErrors = append(configErrors, ConfigError{Field: "security.jwt.jwt_secret", Message: fmt.Sprintf("must be at least %d characters", minJWTSecretLength)})
+	}
+
+	allowedOrigins := a.GetCORSAllowedOrigins()
+	if isProduction && len(allowedOrigins) == 1 && allowedOrigins[0] == "*" {
+		configErrors = append(configErrors, ConfigError{Field: "cors.allowed_origins", Message: `must not be ["*"] in production`})
+	}
+
+	if _, err := os.Stat(a.config.GetString("STATIC_DIR")); err != nil {
+		configErrors = append(configErrors, ConfigError{Field: "STATIC_DIR", Message: "directory does not exist"})
+	}
+
+	switch securityAuth.HashAlgorithm(a.GetActiveHashAlgorithm()) {
+	case securityAuth.HashAlgorithmArgon2id, securityAuth.HashAlgorithmBcrypt:
+	default:
+		configErrors = append(configErrors, ConfigError{Field: "security.password_hash_algorithm", Message: `must be "argon2id" or "bcrypt"`})
+	}
+
+	if _, err := regexp.Compile(a.config.GetString("security.username.allowed_pattern")); err != nil {
+		configErrors = append(configErrors, ConfigError{Field: "security.username.allowed_pattern", Message: fmt.Sprintf("invalid regular expression: %v", err)})
+	}
+
+	if a.config.IsSet("rate_limiting.cleanup.interval_minutes") && a.config.GetInt("rate_limiting.cleanup.interval_minutes") < 1 {
+		configErrors = append(configErrors, ConfigError{Field: "rate_limiting.cleanup.interval_minutes", Message: "must be at least 1"})
+	}
+
+	sampleSalt, err := securityAuth.NewRandomSaltGenerator(a.GetSaltByteLength()).Generate()
+	if err == nil {
+		if bits, err := securityAuth.SaltStrength(sampleSalt); err == nil && bits < securityAuth.MinSaltEntropyBits {
+			configErrors = append(configErrors, ConfigError{
+				Field:   "security.salt_bytes",
+				Message: fmt.Sprintf("salts provide %d bits of entropy, at least %d are required", bits, securityAuth.MinSaltEntropyBits),
+			})
+		}
+	}
+
+	return configErrors
 }
@@ -0,0 +1,236 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func newTestAppConfig(settings map[string]interface{}) *AppConfig {
+	v := viper.New()
+	for key, value := range settings {
+		v.Set(key, value)
+	}
+	return &AppConfig{config: newLockedViper(v)}
+}
+
+func TestValidateConfigMisconfiguredProduction(t *testing.T) {
+	appConfig := newTestAppConfig(map[string]interface{}{
+		"ENV":                                    "production",
+		"server.port":                            "eighty",
+		"database.password":                      "",
+		"security.jwt.jwt_secret":                "short-secret",
+		"cors.allowed_origins":                   []string{"*"},
+		"STATIC_DIR":                             "./does-not-exist",
+		"security.salt_bytes":                    8,
+		"security.password_hash_algorithm":       "md5",
+		"rate_limiting.cleanup.interval_minutes": 0,
+	})
+
+	configErrors := appConfig.ValidateConfig()
+
+	expectedFields := []string{
+		"server.port",
+		"database.password",
+		"security.jwt.jwt_secret",
+		"cors.allowed_origins",
+		"STATIC_DIR",
+		"security.salt_bytes",
+		"security.password_hash_algorithm",
+		"rate_limiting.cleanup.interval_minutes",
+	}
+	for _, field := range expectedFields {
+		found := false
+		for _, configErr := range configErrors {
+			if configErr.Field == field {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected a configuration error for %s, Got: %v", field, configErrors)
+		}
+	}
+}
+
+func TestValidateConfigValidProduction(t *testing.T) {
+	appConfig := newTestAppConfig(map[string]interface{}{
+		"ENV":                              "production",
+		"server.port":                      "8080",
+		"database.password":                "a-strong-password",
+		"security.jwt.jwt_secret":          "0123456789abcdef0123456789abcdef",
+		"cors.allowed_origins":             []string{"https://store.example.com"},
+		"STATIC_DIR":                       ".",
+		"security.salt_bytes":              32,
+		"security.password_hash_algorithm": "argon2id",
+	})
+
+	if configErrors := appConfig.ValidateConfig(); len(configErrors) != 0 {
+		t.Errorf("Expected no configuration errors, Got: %v", configErrors)
+	}
+}
+
+func TestValidateConfigDevelopmentSkipsProductionChecks(t *testing.T) {
+	appConfig := newTestAppConfig(map[string]interface{}{
+		"server.port":                      "8080",
+		"database.password":                "",
+		"security.jwt.jwt_secret":          "0123456789abcdef0123456789abcdef",
+		"cors.allowed_origins":             []string{"*"},
+		"STATIC_DIR":                       ".",
+		"security.salt_bytes":              32,
+		"security.password_hash_algorithm": "argon2id",
+	})
+
+	if configErrors := appConfig.ValidateConfig(); len(configErrors) != 0 {
+		t.Errorf("Expected no configuration errors in development, Got: %v", configErrors)
+	}
+}
+
+func TestIsRouteDisabled(t *testing.T) {
+	appConfig := newTestAppConfig(map[string]interface{}{
+		"feature_flags.disabled_routes": []string{"/comments/newComments"},
+	})
+	appConfig.loadDisabledRoutes()
+
+	if !appConfig.IsRouteDisabled("/comments/newComments") {
+		t.Error("Expected /comments/newComments to be disabled")
+	}
+	if appConfig.IsRouteDisabled("/comments") {
+		t.Error("Expected /comments to stay enabled")
+	}
+
+	appConfig.config.SetAll(map[string]interface{}{"feature_flags.disabled_routes": []string{}})
+	appConfig.loadDisabledRoutes()
+	if appConfig.IsRouteDisabled("/comments/newComments") {
+		t.Error("Expected /comments/newComments to be enabled after reload")
+	}
+}
+
+func TestWatchConfigReloadsDisabledRoutes(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte("feature_flags:\n  disabled_routes: []\n"), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("ReadInConfig() unexpected error: %v", err)
+	}
+	appConfig := &AppConfig{config: newLockedViper(v)}
+	appConfig.loadDisabledRoutes()
+	appConfig.watchConfig()
+
+	if err := os.WriteFile(file, []byte("feature_flags:\n  disabled_routes: [\"/products\"]\n"), 0o600); err != nil {
+		t.Fatalf("rewriting config: %v", err)
+	}
+	// Reading while the watcher reloads the file is what go test -race checks here.
+	deadline := time.Now().Add(5 * time.Second)
+	for !appConfig.IsRouteDisabled("/products") {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected /products to be disabled once the file was reloaded")
+		}
+		appConfig.SanitizedSettings()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSanitizedSettingsRedactsSecrets(t *testing.T) {
+	appConfig := newTestAppConfig(map[string]interface{}{
+		"server.port":             "8080",
+		"database.password":       "hunter2",
+		"security.jwt.jwt_secret": "a-very-long-and-secret-signing-key",
+	})
+
+	settings := appConfig.SanitizedSettings()
+	if settings["server.port"] != "8080" {
+		t.Errorf("server.port Expected: 8080, Got: %v", settings["server.port"])
+	}
+	for _, key := range []string{"database.password", "security.jwt.jwt_secret"} {
+		if settings[key] != redactedSetting {
+			t.Errorf("%s Expected: %s, Got: %v", key, redactedSetting, settings[key])
+		}
+	}
+}
+
+func TestApplyOverridesRejectsInvalidChanges(t *testing.T) {
+	appConfig := newTestAppConfig(map[string]interface{}{
+		"server.port":                   "8080",
+		"feature_flags.disabled_routes": []string{},
+		"STATIC_DIR":                    t.TempDir(),
+	})
+
+	// server.port is only read at startup, so even a valid value is rejected.
+	configErrors, err := appConfig.ApplyOverrides(map[string]interface{}{"server.port": "9090", "feature_flags.disabled_routes": []string{"/products"}})
+	if err != nil {
+		t.Fatalf("ApplyOverrides() unexpected error: %v", err)
+	}
+	if len(configErrors) != 1 || configErrors[0].Field != "server.port" {
+		t.Errorf("Expected a server.port error, Got: %v", configErrors)
+	}
+	if appConfig.GetPort() != "8080" || appConfig.IsRouteDisabled("/products") {
+		t.Errorf("Expected the rejected overrides not to be applied, Got port: %s", appConfig.GetPort())
+	}
+
+	if configErrors, _ := appConfig.ApplyOverrides(map[string]interface{}{"no.such.key": 1}); len(configErrors) != 1 {
+		t.Errorf("Expected an unknown setting error, Got: %v", configErrors)
+	}
+
+	configErrors, err = appConfig.ApplyOverrides(map[string]interface{}{"feature_flags.disabled_routes": []string{"/products"}})
+	if err != nil || len(configErrors) != 0 {
+		t.Fatalf("ApplyOverrides() unexpected errors: %v, %v", configErrors, err)
+	}
+	if !appConfig.IsRouteDisabled("/products") {
+		t.Error("Expected /products to be disabled")
+	}
+}
+
+func TestApplyOverridesPersistsOnlyFileSettings(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte("config:\n  hot_reload: true\nfeature_flags:\n  disabled_routes: []\n"), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	t.Setenv("TEST_JWT_SECRET", "secret-from-the-environment-0123456789")
+	v := viper.New()
+	v.SetConfigFile(file)
+	v.SetDefault("database.password", "secret-from-the-defaults")
+	if err := v.BindEnv("security.jwt.jwt_secret", "TEST_JWT_SECRET"); err != nil {
+		t.Fatalf("BindEnv() unexpected error: %v", err)
+	}
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("ReadInConfig() unexpected error: %v", err)
+	}
+	appConfig := &AppConfig{config: newLockedViper(v)}
+
+	configErrors, err := appConfig.ApplyOverrides(map[string]interface{}{"feature_flags.disabled_routes": []string{"/products"}})
+	if err != nil || len(configErrors) != 0 {
+		t.Fatalf("ApplyOverrides() unexpected errors: %v, %v", configErrors, err)
+	}
+
+	written, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("reading config: %v", err)
+	}
+	for _, want := range []string{"hot_reload: true", "/products"} {
+		if !strings.Contains(string(written), want) {
+			t.Errorf("Expected the file to contain %q, Got:\n%s", want, written)
+		}
+	}
+	for _, secret := range []string{"secret-from-the-environment", "secret-from-the-defaults"} {
+		if strings.Contains(string(written), secret) {
+			t.Errorf("Expected %q not to be written, Got:\n%s", secret, written)
+		}
+	}
+}
+
+func TestGetRateLimiterCleanupReplacesInvalidInterval(t *testing.T) {
+	for _, interval := range []int{0, -3} {
+		appConfig := newTestAppConfig(map[string]interface{}{"rate_limiting.cleanup.interval_minutes": interval})
+		if got := appConfig.GetRateLimiterCleanup().CleanupIntervalMinutes; got != DefaultRateLimiterCleanupIntervalMinutes {
+			t.Errorf("interval %d: Expected %d, Got %d", interval, DefaultRateLimiterCleanupIntervalMinutes, got)
+		}
+	}
+}
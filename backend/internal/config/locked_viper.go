@@ -0,0 +1,126 @@
+// Package config provides application configuration management for the sale-watches application.
+// This file contains lockedViper, which makes the Viper instance of AppConfig safe for concurrent use.
+package config
+
+import (
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// lockedViper guards a *viper.Viper with a sync.RWMutex.
+//
+// Viper is not safe for concurrent use, yet AppConfig is read by every request while ApplyOverrides and the configuration file watcher change it. Reads take the read lock and changes the write lock; only the methods AppConfig needs are exposed.
+type lockedViper struct {
+	mu sync.RWMutex
+	v  *viper.Viper
+}
+
+// newLockedViper wraps v, which must not be used directly afterwards.
+func newLockedViper(v *viper.Viper) *lockedViper {
+	return &lockedViper{v: v}
+}
+
+// The getters below mirror their viper.Viper counterparts, each under the read lock.
+
+func (l *lockedViper) Get(key string) interface{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.Get(key)
+}
+
+func (l *lockedViper) GetString(key string) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.GetString(key)
+}
+
+func (l *lockedViper) GetBool(key string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.GetBool(key)
+}
+
+func (l *lockedViper) GetInt(key string) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.GetInt(key)
+}
+
+func (l *lockedViper) GetInt64(key string) int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.GetInt64(key)
+}
+
+func (l *lockedViper) GetUint(key string) uint {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.GetUint(key)
+}
+
+func (l *lockedViper) GetUint32(key string) uint32 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.GetUint32(key)
+}
+
+func (l *lockedViper) GetFloat64(key string) float64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.GetFloat64(key)
+}
+
+func (l *lockedViper) GetDuration(key string) time.Duration {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.GetDuration(key)
+}
+
+func (l *lockedViper) GetStringSlice(key string) []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.GetStringSlice(key)
+}
+
+func (l *lockedViper) IsSet(key string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.IsSet(key)
+}
+
+func (l *lockedViper) ConfigFileUsed() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.v.ConfigFileUsed()
+}
+
+// Settings returns every key with its value, read under a single lock so the result is consistent.
+func (l *lockedViper) Settings() map[string]interface{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	settings := make(map[string]interface{})
+	for _, key := range l.v.AllKeys() {
+		settings[key] = l.v.Get(key)
+	}
+	return settings
+}
+
+// SetAll overrides every key of settings at once, so readers never see part of the change.
+func (l *lockedViper) SetAll(settings map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, value := range settings {
+		l.v.Set(key, value)
+	}
+}
+
+// ReadInConfig reloads the configuration file; values set with SetAll keep precedence over it.
+func (l *lockedViper) ReadInConfig() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.v.ReadInConfig()
+}
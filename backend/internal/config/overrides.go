@@ -0,0 +1,120 @@
+// Package config provides application configuration management for the sale-watches application.
+// This file contains the runtime view and editing of settings used by the admin configuration endpoints.
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// redactedSetting replaces the value of sensitive settings in SanitizedSettings.
+const redactedSetting = "[REDACTED]"
+
+// sensitiveSettingWords lists the fragments that mark a setting as sensitive when they appear in its key.
+var sensitiveSettingWords = []string{"password", "secret", "key", "token"}
+
+// isSensitiveSetting reports whether the key of a setting contains one of sensitiveSettingWords.
+func isSensitiveSetting(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range sensitiveSettingWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// SanitizedSettings returns every known setting keyed by its dotted name (e.g. "server.port"), with the values of keys containing password, secret, key or token replaced by [REDACTED].
+func (a *AppConfig) SanitizedSettings() map[string]interface{} {
+	settings := a.config.Settings()
+	for key := range settings {
+		if isSensitiveSetting(key) {
+			settings[key] = redactedSetting
+		}
+	}
+	return settings
+}
+
+// runtimeReloadableSettings lists the settings that take effect without a restart, the only ones ApplyOverrides accepts.
+// Every other setting is read once at startup, so changing it at runtime would report a change that is not in effect.
+var runtimeReloadableSettings = map[string]bool{
+	"feature_flags.disabled_routes": true,
+}
+
+// ApplyOverrides sets each of the given dotted keys to its value, after checking that the resulting configuration is still valid.
+//
+// Only runtimeReloadableSettings can be overridden; any other known key is rejected as requiring a restart. The overrides are first applied to a copy of the configuration and validated with ValidateConfig. Problems that already existed before the change are tolerated, so a development setup with the default JWT secret can still be edited. If any override targets an unknown or non-reloadable key or introduces a new problem, nothing is applied and the problems are returned.
+// Applied overrides only last until the next restart, unless hot reload is enabled, in which case they are also written to the configuration file (see persistOverrides). The returned error reports a failure to write that file.
+func (a *AppConfig) ApplyOverrides(overrides map[string]interface{}) ([]ConfigError, error) {
+	a.overridesMu.Lock()
+	defer a.overridesMu.Unlock()
+
+	current := a.config.Settings()
+	candidate := newAppConfigCopy(current)
+
+	var configErrors []ConfigError
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, known := current[strings.ToLower(key)]; !known {
+			configErrors = append(configErrors, ConfigError{Field: key, Message: "unknown setting"})
+			continue
+		}
+		if !runtimeReloadableSettings[strings.ToLower(key)] {
+			configErrors = append(configErrors, ConfigError{Field: key, Message: "only takes effect after a restart; change it in the configuration file instead"})
+		}
+	}
+	if len(configErrors) > 0 {
+		return configErrors, nil
+	}
+	candidate.config.SetAll(overrides)
+
+	existing := make(map[ConfigError]bool)
+	for _, configErr := range a.ValidateConfig() {
+		existing[configErr] = true
+	}
+	for _, configErr := range candidate.ValidateConfig() {
+		if !existing[configErr] {
+			configErrors = append(configErrors, configErr)
+		}
+	}
+	if len(configErrors) > 0 {
+		return configErrors, nil
+	}
+
+	a.config.SetAll(overrides)
+	a.loadDisabledRoutes()
+
+	if a.IsHotReloadEnabled() {
+		if err := a.persistOverrides(overrides); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// persistOverrides rewrites the configuration file with the settings it already holds plus overrides.
+// The file is read into a separate Viper instance, so defaults and environment variables, which carry secrets such as database.password, are never written to disk.
+func (a *AppConfig) persistOverrides(overrides map[string]interface{}) error {
+	fileConfig := viper.New()
+	fileConfig.SetConfigFile(a.config.ConfigFileUsed())
+	if err := fileConfig.ReadInConfig(); err != nil {
+		return err
+	}
+	for key, value := range overrides {
+		fileConfig.Set(key, value)
+	}
+	return fileConfig.WriteConfig()
+}
+
+// newAppConfigCopy returns an AppConfig holding settings, a snapshot taken with lockedViper.Settings, for validating changes without touching the original.
+func newAppConfigCopy(settings map[string]interface{}) *AppConfig {
+	snapshot := &AppConfig{config: newLockedViper(viper.New())}
+	snapshot.config.SetAll(settings)
+	return snapshot
+}
@@ -0,0 +1,7 @@
+// Package models defines core domain entities for the sale‑watches application.
+
+// This file declares the keys of the settings stored in the app_settings table.
+package models
+
+// SettingAllowAnonymousComments enables posting comments without attributing them to their author.
+const SettingAllowAnonymousComments = "allow_anonymous"
@@ -0,0 +1,22 @@
+// Package models defines core domain entities and configuration structs for the sale-watches application.
+package models
+
+// Argon2Config holds the cost parameters of the Argon2id password hash.
+
+// Time: number of passes over the memory.
+// Memory: memory used by the algorithm, in KiB.
+// Threads: degree of parallelism.
+// KeyLen: length in bytes of the derived key.
+type Argon2Config struct {
+	// Time specifies the number of iterations.
+	Time uint32 `mapstructure:"time"`
+
+	// Memory specifies the memory cost in KiB (e.g. 65536 for 64 MiB).
+	Memory uint32 `mapstructure:"memory"`
+
+	// Threads specifies how many lanes are computed in parallel.
+	Threads uint8 `mapstructure:"threads"`
+
+	// KeyLen specifies the size in bytes of the resulting hash.
+	KeyLen uint32 `mapstructure:"key_length"`
+}
@@ -0,0 +1,41 @@
+// Package models defines core domain entities for the sale-watches application.
+
+// This file declares the audit log entries recorded for sensitive administrator actions.
+package models
+
+import "time"
+
+// AuditActionImpersonation is the Action of the entries recorded when an administrator starts impersonating a user.
+const AuditActionImpersonation = "impersonation"
+
+// AuditEntry is one recorded administrator action.
+
+// Fields:
+//   - ID:        identifier assigned by the repository.
+//   - Action:    what was done, such as AuditActionImpersonation.
+//   - ActorID:   ID of the administrator who acted.
+//   - UserID:    ID of the user the action targeted.
+//   - Reason:    justification given by the administrator.
+//   - CreatedAt: moment the action was taken.
+type AuditEntry struct {
+	ID        int64     `db:"ID" json:"id"`
+	Action    string    `db:"Action" json:"action"`
+	ActorID   int       `db:"ActorID" json:"actor_id"`
+	UserID    int       `db:"UserID" json:"user_id"`
+	Reason    string    `db:"Reason" json:"reason"`
+	CreatedAt time.Time `db:"CreatedAt" json:"created_at"`
+}
+
+// AuditFilter selects audit entries.
+
+// Fields:
+//   - Action: only entries with this action.
+//   - UserID: only entries targeting this user; zero matches every user.
+//   - From:   only entries created at or after From; the zero time leaves the range open.
+//   - To:     only entries created before To; the zero time leaves the range open.
+type AuditFilter struct {
+	Action string
+	UserID int
+	From   time.Time
+	To     time.Time
+}
@@ -8,8 +8,23 @@ import "github.com/golang-jwt/jwt/v5"
 // Claims represents the JWT payload for authenticated users.
 
 // It embeds jwt.RegisteredClaims—which includes standard fields like ExpiresAt (exp), Issuer (iss), Subject (sub), NotBefore (nbf), IssuedAt (iat), Audience (aud), and ID (jti)—and adds a custom UserName claim for identifying the user. This structure conforms to RFC 7519 and integrates seamlessly with the golang‑jwt library.
+// Role is the account's role (RoleUser or RoleAdmin) when the token was issued; tokens issued before roles existed carry none and are treated as regular users.
+// ImpersonatedBy is set only on tokens issued to support staff acting as another user; it holds the staff member's user ID.
+// TokenType is TokenTypeRefresh on refresh tokens, which are identified by their ID (jti) claim and are never accepted as access tokens.
 type Claims struct {
 	UserId int `json:"userId"` // Custom claim for user id
 	UserName string `json:"userName"` // Custom claim for the user's username
+	Role string `json:"role,omitempty"` // Role of the user, RoleUser or RoleAdmin
+	ImpersonatedBy int `json:"impersonated_by,omitempty"` // ID of the impersonating user, 0 for regular tokens
+	TokenType string `json:"token_type,omitempty"` // TokenTypeRefresh for refresh tokens, empty for access tokens
 	jwt.RegisteredClaims // Standard JWT claims
 }
+
+// Roles stored in User_Registration.Role and carried by the role claim.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// TokenTypeRefresh marks the claims of a refresh token.
+const TokenTypeRefresh = "refresh"
@@ -8,9 +8,10 @@ package models
 // Fields:
 //   - ID:        unique identifier of the comment.
 //   - Date:      timestamp when the comment was posted, usually in ISO 8601 format.
-//   - UserName:  identifier of the user who posted the comment.
+//   - UserName:  display name of the user who posted the comment (their login username if no display name is set).
 //   - Content:   textual body of the comment.
 //   - Rating:    numeric score given by the user (e.g., 1–5).
+//   - Pinned:    whether the comment is highlighted as a homepage testimonial.
 type Comment struct {
 	ID int `db:"ID"`
 	Date string `db:"Date"`
@@ -18,4 +19,14 @@ type Comment struct {
 	UserName string `db:"UserName"`
 	Content string `db:"Content"`
 	Rating int `db:"Rating"`
-} 
\ No newline at end of file
+	Pinned bool `db:"Pinned"`
+}
+
+// AnonymousUserID is stored as the UserID of comments posted anonymously; no user has this ID.
+const AnonymousUserID = 0
+
+// AnonymousUserName is shown as the author of comments posted anonymously.
+const AnonymousUserName = "Anonymous"
+
+// MaxPinnedComments is the maximum number of comments that can be pinned at the same time.
+const MaxPinnedComments = 5 
\ No newline at end of file
@@ -0,0 +1,20 @@
+// Package models defines core domain entities for the sale-watches application.
+
+// This file declares IdempotencyRecord, the stored outcome of a request sent with an idempotency key.
+package models
+
+import "time"
+
+// IdempotencyRecord is the response recorded for an idempotency key so a retried request can be answered without running the handler again.
+
+// Fields:
+//   - Key:        idempotency key sent by the client (scoped per user by the middleware).
+//   - StatusCode: HTTP status code of the original response.
+//   - Body:       raw body of the original response.
+//   - ExpiresAt:  moment after which the record is ignored and the request is processed again.
+type IdempotencyRecord struct {
+	Key        string    `db:"IdempotencyKey"`
+	StatusCode int       `db:"StatusCode"`
+	Body       []byte    `db:"Body"`
+	ExpiresAt  time.Time `db:"ExpiresAt"`
+}
@@ -14,3 +14,15 @@ type LimiterConfig struct {
 	// Burst specifies the maximum burst size over the steady request rate.
 	Burst            int     `mapstructure:"burst"`
 }
+
+// RateLimiterCleanupConfig holds the schedule used to purge inactive per-IP rate limiters.
+
+// ExpirationMinutes: time since last access after which a limiter is considered inactive.
+// CleanupIntervalMinutes: frequency between cleanup cycles.
+type RateLimiterCleanupConfig struct {
+	// ExpirationMinutes specifies how long an unused limiter is kept in memory.
+	ExpirationMinutes int `mapstructure:"expiration_minutes"`
+
+	// CleanupIntervalMinutes specifies how often inactive limiters are purged.
+	CleanupIntervalMinutes int `mapstructure:"interval_minutes"`
+}
@@ -0,0 +1,41 @@
+// Package models defines core domain entities for the sale-watches application.
+
+// This file declares Page, the generic result of a paginated list query.
+package models
+
+// Page is one page of a list together with the metadata needed to navigate the whole list.
+
+// Fields:
+//   - Items:      the elements of the requested page, possibly empty.
+//   - Total:      number of elements across all pages.
+//   - Page:       1-based index of the returned page.
+//   - PerPage:    maximum number of elements per page.
+//   - TotalPages: number of pages, 0 when the list is empty.
+type Page[T any] struct {
+	Items      []T
+	Total      int
+	Page       int
+	PerPage    int
+	TotalPages int
+}
+
+// NewPage builds a Page and derives TotalPages from total and perPage.
+// A nil items slice is replaced by an empty one so the page always encodes as a JSON array.
+func NewPage[T any](items []T, total, page, perPage int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = (total + perPage - 1) / perPage
+	}
+
+	return Page[T]{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: totalPages,
+	}
+}
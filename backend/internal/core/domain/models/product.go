@@ -0,0 +1,28 @@
+// Package models defines core domain entities for the sale‑watches application.
+
+// This file declares the Product type, a watch offered in the store.
+package models
+
+import "time"
+
+// Product is a watch offered in the store.
+
+// Fields:
+//   - ID:          unique identifier of the product.
+//   - Name:        model name shown in the catalogue.
+//   - Brand:       manufacturer of the watch.
+//   - Price:       unit price in the store's currency.
+//   - Stock:       units available for sale.
+//   - ImageURL:    URL of the product picture; empty when there is none.
+//   - Description: free-text description of the watch.
+//   - CreatedAt:   when the product was added to the catalogue.
+type Product struct {
+	ID          int       `db:"ID" json:"id"`
+	Name        string    `db:"Name" json:"name"`
+	Brand       string    `db:"Brand" json:"brand"`
+	Price       float64   `db:"Price" json:"price"`
+	Stock       int       `db:"Stock" json:"stock"`
+	ImageURL    string    `db:"ImageURL" json:"imageUrl"`
+	Description string    `db:"Description" json:"description"`
+	CreatedAt   time.Time `db:"CreatedAt" json:"createdAt"`
+}
@@ -0,0 +1,23 @@
+// Package models defines core domain entities for the sale-watches application.
+
+// This file declares RatingHistogram, the distribution of comment ratings used by star-rating UIs.
+package models
+
+// MinRating and MaxRating bound the star rating a comment can carry.
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// RatingHistogram maps each star rating (1-5) to the number of comments with that rating.
+// Every rating level is present, with a zero count when no comment has it. It encodes to JSON as {"1": 3, "2": 7, ...}.
+type RatingHistogram map[int]int
+
+// NewRatingHistogram returns a histogram with every rating level set to zero.
+func NewRatingHistogram() RatingHistogram {
+	histogram := make(RatingHistogram, MaxRating-MinRating+1)
+	for rating := MinRating; rating <= MaxRating; rating++ {
+		histogram[rating] = 0
+	}
+	return histogram
+}
@@ -0,0 +1,34 @@
+// Package models defines core domain entities and configuration structs for the sale‑watches application.
+package models
+
+import "time"
+
+// RefreshTokenConfig holds the settings of refresh tokens, which renew expired access tokens without a new login.
+
+// TTL: lifetime of a refresh token; zero disables refresh tokens.
+// GracePeriod: how long after its expiry a refresh token is still redeemed, to tolerate clock skew and requests in flight.
+// CookieName: name of the cookie the refresh token is stored in.
+type RefreshTokenConfig struct {
+	TTL         time.Duration
+	GracePeriod time.Duration
+	CookieName  string
+}
+
+// Enabled reports whether refresh tokens are issued.
+func (c RefreshTokenConfig) Enabled() bool {
+	return c.TTL > 0 && c.CookieName != ""
+}
+
+// RefreshToken is the server-side record of an issued refresh token, used to revoke it.
+
+// Fields:
+//   - TokenID:   the token's ID (jti) claim.
+//   - UserID:    ID of the user the token was issued to.
+//   - ExpiresAt: when the token expires.
+//   - RevokedAt: when the token was used or revoked; nil while it is active.
+type RefreshToken struct {
+	TokenID   string     `db:"TokenID"`
+	UserID    int        `db:"UserID"`
+	ExpiresAt time.Time  `db:"ExpiresAt"`
+	RevokedAt *time.Time `db:"RevokedAt"`
+}
@@ -0,0 +1,46 @@
+// Package models defines core domain entities for the sale-watches application.
+
+// This file declares the per-request log records and the daily SLA report built from them.
+package models
+
+import "time"
+
+// RequestLogRecord is the outcome of a single handled HTTP request, persisted for SLA reporting.
+
+// Fields:
+//   - Method:    HTTP method of the request.
+//   - Route:     route template that matched (e.g. "/comments"), or the path when no route matched.
+//   - Status:    HTTP status code of the response.
+//   - Duration:  time taken to serve the request.
+//   - Timestamp: moment the request started.
+type RequestLogRecord struct {
+	Method    string
+	Route     string
+	Status    int
+	Duration  time.Duration
+	Timestamp time.Time
+}
+
+// RouteRequestStats counts the requests logged for a route over a period.
+type RouteRequestStats struct {
+	Route  string `db:"Route"`
+	Total  int    `db:"Total"`
+	Errors int    `db:"Errors"`
+}
+
+// RouteSLA is the latency and error summary of one route in an SLA report. Latencies are in milliseconds.
+type RouteSLA struct {
+	Route         string  `json:"route"`
+	TotalRequests int     `json:"total_requests"`
+	ErrorRate     float64 `json:"error_rate"`
+	P50           float64 `json:"p50"`
+	P95           float64 `json:"p95"`
+	P99           float64 `json:"p99"`
+}
+
+// SLAReport summarises the requests of one day, per route. Responses with a status of 500 or above count as errors.
+type SLAReport struct {
+	Date          string     `json:"date"`
+	TotalRequests int        `json:"total_requests"`
+	Routes        []RouteSLA `json:"routes"`
+}
@@ -9,9 +9,12 @@ package models
 // Fields:
 //   - Content:  the textual body of the review.
 //   - Rating:   the numeric score given by the user (e.g., 1–5).
+//   - IsAnonymous: hides the author; only accepted when the allow_anonymous setting is enabled.
 // Comments should begin with the name of the thing being described and end in a period. :contentReference[oaicite:0]{index=0}
 type Review struct {
 	Content  string `json:"Content"` // the textual body of the review :contentReference[oaicite:2]{index=2}
 
 	Rating   int    `json:"Rating"` // the numeric score assigned by the user :contentReference[oaicite:3]{index=3}
+
+	IsAnonymous bool `json:"IsAnonymous"` // posts the review without attributing it to the user
 }
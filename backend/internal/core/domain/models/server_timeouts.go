@@ -0,0 +1,17 @@
+// Package models defines core domain entities and configuration structs for the sale‑watches application.
+package models
+
+import "time"
+
+// ServerTimeouts holds the connection timeouts of the HTTP servers, so a slow client cannot hold a connection open forever.
+
+// Read: maximum time to read a whole request, body included.
+// ReadHeader: maximum time to read the request headers.
+// Write: maximum time from the end of the request headers to the end of the response.
+// Idle: maximum time a keep-alive connection waits for the next request.
+type ServerTimeouts struct {
+	Read       time.Duration
+	ReadHeader time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+}
@@ -0,0 +1,24 @@
+// Package models defines core domain entities for the sale-watches application.
+
+// This file declares User, a registered account as stored in User_Registration.
+package models
+
+import "time"
+
+// User represents a stored account, credential data included; use UserProfile to expose a user.
+
+// Fields:
+//   - ID:        unique identifier assigned by the database (User_Registration.UserID).
+//   - UserName:  the unique login name, compared without regard to case.
+//   - Password:  the password hash, never the plain password.
+//   - Role:      the user's role, RoleUser or RoleAdmin.
+//   - CreatedAt: when the user registered.
+//   - DeletedAt: when the account was soft-deleted, nil for active accounts.
+type User struct {
+	ID        int        `db:"UserID"`
+	UserName  string     `db:"UserName"`
+	Password  string     `db:"Password"`
+	Role      string     `db:"Role"`
+	CreatedAt time.Time  `db:"CreatedAt"`
+	DeletedAt *time.Time `db:"DeletedAt"`
+}
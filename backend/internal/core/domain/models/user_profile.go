@@ -0,0 +1,22 @@
+// Package models defines core domain entities for the sale-watches application.
+
+// This file declares UserProfile, the public view of a registered user.
+package models
+
+import "time"
+
+// UserProfile represents a registered user without any credential data.
+
+// Fields:
+//   - ID:          unique identifier assigned by the database (User_Registration.UserID).
+//   - UserName:    the unique login name the user registered with; it cannot be changed.
+//   - DisplayName: the name shown next to the user's comments (user_profiles.DisplayName), falling back to UserName when not set.
+//   - CreatedAt:   when the user registered (User_Registration.CreatedAt).
+//   - Role:        the user's role, RoleUser or RoleAdmin (User_Registration.Role).
+type UserProfile struct {
+	ID          int       `db:"UserID" json:"id"`
+	UserName    string    `db:"UserName" json:"username"`
+	DisplayName string    `db:"DisplayName" json:"displayName"`
+	CreatedAt   time.Time `db:"CreatedAt" json:"createdAt"`
+	Role        string    `db:"Role" json:"role"`
+}
@@ -0,0 +1,13 @@
+// Package models defines core domain entities and configuration structs for the sale-watches application.
+package models
+
+import "regexp"
+
+// UsernamePolicy holds the rules a new username must satisfy, in addition to the fixed minimum length.
+
+// MaxLength: maximum number of characters (runes); 0 disables the check.
+// AllowedPattern: expression the whole username must match; nil disables the check.
+type UsernamePolicy struct {
+	MaxLength      int
+	AllowedPattern *regexp.Regexp
+}
@@ -23,9 +23,12 @@ type BaseAuthService struct {
 }
 
 // ValidateUserName checks the supplied username against the UserNameValidator.
-// Returns a ValidationError if the username is invalid.
+// Returns a ValidationError if the username is invalid: the validator's own AppError when it reports one (e.g. "Username too long"), otherwise a generic ErrInvalidUsername.
 func (b *BaseAuthService) ValidateUserName(username interface{}) error {
 	if err := b.UserNameValidator.Validate(username); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return appErr
+		}
 		return errors.NewValidationError(errors.ErrInvalidUsername)
 	}
 	return nil
@@ -49,9 +52,9 @@ func (b *BaseAuthService) CheckUserExists(username string) (bool, error) {
 	return exists, nil
 }
 
-// GenerateToken creates a signed JWT for the given username using the default JWT service. Returns the token string or an InternalError if token generation fails.
-func (b *BaseAuthService) GenerateToken(userId int, username string) (string, error) {
-	token, err := securityAuth.GenerateJWT(userId, username)
+// GenerateToken creates a signed JWT for the given username and role using the default JWT service. Returns the token string or an InternalError if token generation fails.
+func (b *BaseAuthService) GenerateToken(userId int, username, role string) (string, error) {
+	token, err := securityAuth.GenerateJWT(userId, username, role)
 	if err != nil {
 		return "", errors.NewInternalError(errors.ErrTokenGeneration).WithError(err)
 	}
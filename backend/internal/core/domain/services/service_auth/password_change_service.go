@@ -0,0 +1,62 @@
+// Package service_auth provides implementations of input port interfaces for authentication services.
+package service_auth
+
+import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+// PasswordChangeService implements the input.PasswordChangeService interface.
+
+// It re-authenticates the user with the current password, validates the new one and stores it hashed with Hasher.
+type PasswordChangeService struct {
+	BaseAuthService
+	Hasher securityAuth.Hasher
+}
+
+// NewPasswordChangeService constructs a PasswordChangeService with necessary dependencies.
+
+// Parameters:
+//   - userRepo: repository for user data access (output.UserRepository)
+//   - hasher: hasher of the active algorithm, applied to the new password (securityAuth.Hasher)
+//   - passwordValidator: validator for the new password (input.Validator)
+
+// Returns:
+//   - input.PasswordChangeService: ready-to-use password change service.
+func NewPasswordChangeService(userRepo output.UserRepository, hasher securityAuth.Hasher, passwordValidator input.Validator) input.PasswordChangeService {
+	return &PasswordChangeService{
+		BaseAuthService: BaseAuthService{
+			UserRepo:          userRepo,
+			PasswordValidator: passwordValidator,
+		},
+		Hasher: hasher,
+	}
+}
+
+// ChangePassword replaces the password of the given user.
+
+// Steps:
+//  1. Verify currentPassword against the stored bcrypt or Argon2id hash (AuthError when wrong).
+//  2. Validate newPassword against the PasswordValidator (ValidationError when invalid).
+//  3. Hash newPassword with the active algorithm and store it.
+func (s *PasswordChangeService) ChangePassword(userID int, currentPassword, newPassword string) error {
+	storedHash, err := s.UserRepo.GetHashPasswordByID(userID)
+	if err != nil {
+		return err
+	}
+	if err := securityAuth.VerifyPassword(storedHash, []byte(currentPassword)); err != nil {
+		return err
+	}
+
+	if err := s.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	newHash, err := s.Hasher.Hash([]byte(newPassword))
+	if err != nil {
+		return errors.NewInternalError(errors.ErrDatabaseUpdate).WithError(err)
+	}
+	return s.UserRepo.UpdatePassword(userID, newHash)
+}
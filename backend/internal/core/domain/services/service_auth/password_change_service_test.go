@@ -0,0 +1,59 @@
+package service_auth_test
+
+import (
+	"testing"
+
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_auth"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+func TestChangePassword(t *testing.T) {
+	const currentPassword = "Str0ng!Password"
+	hasher := securityAuth.NewArgon2idHasher(
+		models.Argon2Config{Time: 1, Memory: 8 * 1024, Threads: 1, KeyLen: 32},
+		securityAuth.NewRandomSaltGenerator(16),
+	)
+	currentHash, err := hasher.Hash([]byte(currentPassword))
+	if err != nil {
+		t.Fatalf("Hash() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		currentPassword string
+		newPassword     string
+		isError         func(error) bool
+	}{
+		{name: "changed", currentPassword: currentPassword, newPassword: "N3w!Password"},
+		{name: "wrong current password", currentPassword: "Wr0ng!Password", newPassword: "N3w!Password", isError: errors.IsAuthError},
+		{name: "weak new password", currentPassword: currentPassword, newPassword: "weak", isError: errors.IsValidationError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repotesting.NewInMemoryUserRepository(repotesting.WithUsers([]models.User{{ID: 1, UserName: "alice", Password: currentHash}}))
+			service := service_auth.NewPasswordChangeService(repo, hasher, &service_auth.PasswordValidator{})
+
+			err := service.ChangePassword(1, tt.currentPassword, tt.newPassword)
+			storedHash, _ := repo.GetHashPasswordByID(1)
+			if tt.isError != nil {
+				if !tt.isError(err) {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if storedHash != currentHash {
+					t.Error("Expected the stored hash to be unchanged")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ChangePassword failed: %v", err)
+			}
+			if err := securityAuth.VerifyPassword(storedHash, []byte(tt.newPassword)); err != nil {
+				t.Errorf("Expected the stored hash to match the new password, Got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Package service_auth provides implementations of input port interfaces for authentication services.
+package service_auth
+
+import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+// TokenRevocationService implements the input.TokenRevocationService interface.
+
+// Revoked tokens are recorded by their ID in a token repository until they expire.
+type TokenRevocationService struct {
+	tokenRepository output.TokenRepository
+}
+
+// NewTokenRevocationService constructs a TokenRevocationService that records revoked tokens in tokenRepository.
+func NewTokenRevocationService(tokenRepository output.TokenRepository) input.TokenRevocationService {
+	return &TokenRevocationService{
+		tokenRepository: tokenRepository,
+	}
+}
+
+// RevokeToken records the token and marks it as revoked.
+// Tokens that fail validation, including expired ones, and tokens issued without an ID or expiry are ignored.
+func (s *TokenRevocationService) RevokeToken(token string) error {
+	claims, err := securityAuth.ParseTokenWithClaims(token)
+	if err != nil || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	if err := s.tokenRepository.Save(claims.ID, claims.UserId, claims.ExpiresAt.Time); err != nil {
+		return err
+	}
+	_, err = s.tokenRepository.Revoke(claims.ID)
+	return err
+}
+
+// IsRevoked reports whether the token with the given ID was revoked.
+func (s *TokenRevocationService) IsRevoked(tokenID string) (bool, error) {
+	return s.tokenRepository.IsRevoked(tokenID)
+}
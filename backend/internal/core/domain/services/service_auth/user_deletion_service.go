@@ -0,0 +1,30 @@
+// Package service_auth provides implementations of input port interfaces for authentication services.
+package service_auth
+
+import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+)
+
+// UserDeletionService implements the input.UserDeletionService interface on top of the UserRepository soft delete.
+// Deleting an account also revokes its refresh tokens, so no other session can be renewed afterwards.
+type UserDeletionService struct {
+	userRepo  output.UserRepository
+	tokenRepo output.TokenRepository
+}
+
+// NewUserDeletionService constructs a UserDeletionService using userRepo for persistence and tokenRepo for the refresh tokens to revoke.
+func NewUserDeletionService(userRepo output.UserRepository, tokenRepo output.TokenRepository) input.UserDeletionService {
+	return &UserDeletionService{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+	}
+}
+
+// DeleteAccount soft-deletes the user's account through the repository, then revokes every refresh token issued to it.
+func (s *UserDeletionService) DeleteAccount(userID int) error {
+	if err := s.userRepo.DeleteUser(userID); err != nil {
+		return err
+	}
+	return s.tokenRepo.RevokeAllForUser(userID)
+}
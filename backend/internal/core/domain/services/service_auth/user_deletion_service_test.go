@@ -0,0 +1,46 @@
+package service_auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository"
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_auth"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+func TestDeleteAccountEndsEverySession(t *testing.T) {
+	userRepo := repotesting.NewInMemoryUserRepository(repotesting.WithUsers([]models.User{{ID: 1, UserName: "alice"}}))
+	tokenRepo := repository.NewMemoryTokenRepository()
+	jwtService := securityAuth.NewJWTService("0123456789abcdef0123456789abcdef").WithRefreshTokens(
+		tokenRepo,
+		userRepo,
+		models.RefreshTokenConfig{TTL: time.Hour, CookieName: "refresh_token"},
+	)
+
+	// Two sessions, as from two devices.
+	var refreshTokens []string
+	for range 2 {
+		accessToken, err := jwtService.GenerateJWT(1, "alice", models.RoleUser)
+		if err != nil {
+			t.Fatalf("GenerateJWT failed: %v", err)
+		}
+		refreshToken, err := jwtService.IssueRefreshToken(accessToken)
+		if err != nil {
+			t.Fatalf("IssueRefreshToken failed: %v", err)
+		}
+		refreshTokens = append(refreshTokens, refreshToken)
+	}
+
+	if err := service_auth.NewUserDeletionService(userRepo, tokenRepo).DeleteAccount(1); err != nil {
+		t.Fatalf("DeleteAccount failed: %v", err)
+	}
+
+	// The account was deleted from the first session; the second one must not be renewable.
+	if _, _, err := jwtService.RefreshToken(refreshTokens[1]); !errors.IsAuthError(err) {
+		t.Errorf("Expected an AuthError when refreshing another session of a deleted account, Got: %v", err)
+	}
+}
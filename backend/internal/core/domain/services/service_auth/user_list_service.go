@@ -0,0 +1,35 @@
+// Package service_auth provides implementations of input port interfaces for authentication services.
+package service_auth
+
+import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// UserListService implements the input.UserListService interface on top of UserRepository.ListUsers.
+type UserListService struct {
+	userRepo output.UserRepository
+}
+
+// NewUserListService constructs a UserListService using userRepo for persistence.
+func NewUserListService(userRepo output.UserRepository) input.UserListService {
+	return &UserListService{
+		userRepo: userRepo,
+	}
+}
+
+// ListUsers retrieves one page of users with pagination metadata.
+// It returns a ValidationError if page or perPage is below 1, and the repository's error if the query fails.
+func (s *UserListService) ListUsers(page, perPage int) (models.Page[models.UserProfile], error) {
+	if page < 1 || perPage < 1 {
+		return models.Page[models.UserProfile]{}, errors.NewValidationError(errors.ErrInvalidRequest)
+	}
+
+	users, total, err := s.userRepo.ListUsers(page, perPage)
+	if err != nil {
+		return models.Page[models.UserProfile]{}, err
+	}
+	return models.NewPage(users, total, page, perPage), nil
+}
@@ -2,36 +2,50 @@
 package service_auth
 
 import (
+	"log"
+
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
 	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/metrics"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
 )
 
 // UserLoginService implements the input.UserServiceLogin interface.
 
 // It handles user authentication by validating input, checking user existence, verifying credentials, and issuing JWT tokens.
+// Hashes produced by an algorithm other than ActiveAlgorithm (e.g. legacy bcrypt hashes) are re-hashed with Hasher after a successful login.
+// Every login attempt is counted in Metrics by outcome.
 type UserLoginService struct {
 	BaseAuthService
+	Hasher          securityAuth.Hasher
+	ActiveAlgorithm securityAuth.HashAlgorithm
+	Metrics         *metrics.BusinessMetrics
 }
 
 // NewUserLoginService constructs a UserLoginService with necessary dependencies.
 
 // Parameters:
 //   - userRepo: repository for user data access (output.UserRepository)
+//   - hasher: hasher of the current algorithm, used to upgrade legacy hashes (securityAuth.Hasher)
+//   - activeAlgorithm: algorithm implemented by hasher, as configured by AppConfig.GetActiveHashAlgorithm (securityAuth.HashAlgorithm)
 //   - userNameValidator: validator for username input (input.Validator)
 //   - passwordValidator: validator for password input (input.Validator)
+//   - businessMetrics: counters for login outcomes; nil disables counting (*metrics.BusinessMetrics)
 
 // Returns:
 //   - input.UserServiceLogin: ready-to-use login service.
-func NewUserLoginService(userRepo output.UserRepository, userNameValidator, passwordValidator input.Validator) input.UserServiceLogin {
+func NewUserLoginService(userRepo output.UserRepository, hasher securityAuth.Hasher, activeAlgorithm securityAuth.HashAlgorithm, userNameValidator, passwordValidator input.Validator, businessMetrics *metrics.BusinessMetrics) input.UserServiceLogin {
 	return &UserLoginService{
 		BaseAuthService: BaseAuthService{
 			UserRepo:          userRepo,
 			UserNameValidator: userNameValidator,
 			PasswordValidator: passwordValidator,
 		},
+		Hasher:          hasher,
+		ActiveAlgorithm: activeAlgorithm,
+		Metrics:         businessMetrics,
 	}
 }
 
@@ -39,10 +53,11 @@ func NewUserLoginService(userRepo output.UserRepository, userNameValidator, pass
 
 // Steps:
 //   1. Validate username format.
-//   2. Check that the user exists in the repository.
+//   2. Check that the user exists in the repository, ignoring case.
 //   3. Retrieve stored salt and password hash for the username.
-//   4. Combine provided password with salt and compare hash.
-//   5. Generate and return a JWT token if credentials are valid.
+//   4. Verify the provided password against the stored bcrypt or Argon2id hash.
+//   5. Re-hash the password when the stored hash was produced by another algorithm than the active one.
+//   6. Generate and return a JWT token carrying the user's role if credentials are valid.
 
 // Parameters:
 //   - account: models.Account containing Username and Password.
@@ -51,13 +66,21 @@ func NewUserLoginService(userRepo output.UserRepository, userNameValidator, pass
 //   - token string: a signed JWT token on success.
 //   - error: non-nil if validation, lookup, or authentication fails.
 func (l *UserLoginService) Login(account models.Account) (string, error) {
+	token, err := l.login(account)
+	l.Metrics.RecordLogin(err)
+	return token, err
+}
+
+// login performs the steps of Login without counting the attempt.
+func (l *UserLoginService) login(account models.Account) (string, error) {
 	// 1. Validate username
 	if err := l.ValidateUserName(account.UserName); err != nil {
 		return "", errors.NewValidationError(errors.ErrInvalidUsername)
 	}
 
-	// 2. Check user existence
-	exists, err := l.CheckUserExists(account.UserName)
+	// 2. Check user existence; lookups ignore case and surrounding whitespace
+	username := NormalizeUserName(account.UserName)
+	exists, err := l.CheckUserExists(username)
 	if err != nil {
 		return "", err
 	}
@@ -65,22 +88,47 @@ func (l *UserLoginService) Login(account models.Account) (string, error) {
 		return "", errors.NewNotFoundError(errors.ErrUserNotFound)
 	}
 
-	storedHash, err := l.UserRepo.GetHashPassword(account.UserName)
+	storedHash, err := l.UserRepo.GetHashPassword(username)
+	if err != nil {
+		return "", err
+	}
+
+	userId, err := l.UserRepo.GetID(username)
 	if err != nil {
 		return "", err
 	}
 
-	userId, err := l.UserRepo.GetID(account.UserName)
+	// 4. Verify password against the stored hash, whatever algorithm produced it
+	if err := securityAuth.VerifyPassword(storedHash, []byte(account.Password)); err != nil {
+		return "", err
+	}
+
+	// 5. Upgrade hashes of another algorithm while the plain password is still in memory
+	if storedAlgorithm := securityAuth.DetectAlgorithm(storedHash); storedAlgorithm != l.ActiveAlgorithm {
+		l.upgradePasswordHash(userId, account.Password, storedAlgorithm)
+	}
+
+	// 6. Generate JWT token carrying the user's role
+	role, err := l.UserRepo.GetRole(userId)
 	if err != nil {
 		return "", err
 	}
+	return l.GenerateToken(userId, account.UserName, role)
+}
 
-	// 4. Verify password by hashing provided password with salt
-	err = bcrypt.CompareHashAndPassword([]byte(storedHash), []byte(account.Password))
+// upgradePasswordHash re-hashes the just-verified password with the current Hasher and stores it.
+// The salt is embedded in the hash, so no separate salt is stored.
+// Failures are only logged: the user has already been authenticated and will be upgraded on a later login.
+func (l *UserLoginService) upgradePasswordHash(userId int, password string, from securityAuth.HashAlgorithm) {
+	newHash, err := l.Hasher.Hash([]byte(password))
 	if err != nil {
-		return "", errors.NewAuthError(errors.ErrInvalidCredentials)
+		log.Printf("[ERROR] re-hashing password for user %d: %v", userId, err)
+		return
 	}
 
-	// 5. Generate JWT token
-	return l.GenerateToken(userId, account.UserName)
+	if err := l.UserRepo.UpdatePassword(userId, newHash); err != nil {
+		log.Printf("[ERROR] storing upgraded password hash for user %d: %v", userId, err)
+		return
+	}
+	log.Printf("[INFO] upgraded password hash for user %d from %s to %s", userId, from, l.ActiveAlgorithm)
 }
@@ -0,0 +1,69 @@
+package service_auth_test
+
+import (
+	"strings"
+	"testing"
+
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_auth"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/metrics"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestLoginUpgradesBcryptHashToArgon2id(t *testing.T) {
+	securityAuth.SetDefaultJWTService("0123456789abcdef0123456789abcdef", securityAuth.DefaultClockSkewTolerance)
+	const password = "Str0ng!Password"
+
+	bcryptHash, err := securityAuth.BcryptHasher{}.Hash([]byte(password))
+	if err != nil {
+		t.Fatalf("Hash() unexpected error: %v", err)
+	}
+	repo := repotesting.NewInMemoryUserRepository(repotesting.WithUsers([]models.User{{ID: 1, UserName: "alice", Password: bcryptHash}}))
+	storedHash := func() string {
+		hash, _ := repo.GetHashPasswordByID(1)
+		return hash
+	}
+	hasher := securityAuth.NewArgon2idHasher(
+		models.Argon2Config{Time: 1, Memory: 8 * 1024, Threads: 1, KeyLen: 32},
+		securityAuth.NewRandomSaltGenerator(16),
+	)
+	service := service_auth.NewUserLoginService(repo, hasher, securityAuth.HashAlgorithmArgon2id, &service_auth.UserNameValidator{}, &service_auth.PasswordValidator{}, nil)
+
+	if !strings.HasPrefix(storedHash(), "$2a$") {
+		t.Fatalf("Expected a bcrypt hash before login, Got %q", storedHash())
+	}
+
+	if _, err := service.Login(models.Account{UserName: "alice", Password: password}); err != nil {
+		t.Fatalf("Expected first login to succeed, Got: %v", err)
+	}
+	upgraded := storedHash()
+	if !strings.HasPrefix(upgraded, "$argon2id$") {
+		t.Fatalf("Expected an Argon2id hash after login, Got %q", upgraded)
+	}
+
+	if _, err := service.Login(models.Account{UserName: "Alice", Password: password}); err != nil {
+		t.Fatalf("Expected second login with the upgraded hash to succeed, Got: %v", err)
+	}
+	if storedHash() != upgraded {
+		t.Error("Expected an up-to-date hash not to be re-hashed")
+	}
+}
+
+func TestFailedLoginIncrementsFailureCounter(t *testing.T) {
+	businessMetrics := metrics.NewBusinessMetrics()
+	repo := repotesting.NewInMemoryUserRepository()
+	service := service_auth.NewUserLoginService(repo, securityAuth.BcryptHasher{}, securityAuth.HashAlgorithmBcrypt, &service_auth.UserNameValidator{}, &service_auth.PasswordValidator{}, businessMetrics)
+
+	if _, err := service.Login(models.Account{UserName: "nobody", Password: "Str0ng!Password"}); err == nil {
+		t.Fatal("Expected login of an unknown user to fail")
+	}
+
+	if failures := testutil.ToFloat64(businessMetrics.UserLogins.WithLabelValues(metrics.StatusFailure)); failures != 1 {
+		t.Errorf("Expected user_logins_total{status=\"failure\"} to be 1, Got %v", failures)
+	}
+	if successes := testutil.ToFloat64(businessMetrics.UserLogins.WithLabelValues(metrics.StatusSuccess)); successes != 0 {
+		t.Errorf("Expected no successful logins, Got %v", successes)
+	}
+}
@@ -2,17 +2,22 @@
 package service_auth
 
 import (
+	"strings"
+
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
 	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/metrics"
 )
 
 // UserRegisterService implements the input.UserServiceRegister interface.
 
 // It uses BaseAuthService for shared validation and token generation logic, and orchestrates the full registration flow.
+// Every registration attempt is counted in Metrics by outcome.
 type UserRegisterService struct {
 	BaseAuthService
+	Metrics *metrics.BusinessMetrics
 }
 
 // NewUserRegisterService constructs a UserRegisterService with its dependencies.
@@ -21,16 +26,18 @@ type UserRegisterService struct {
 //   - userRepo: repository for persisting and querying user data.
 //   - userNameValidator: enforces rules on username formats.
 //   - passwordValidator: enforces rules on password strength.
+//   - businessMetrics: counters for registration outcomes; nil disables counting.
 
 // Returns:
 //   - input.UserServiceRegister: the initialized registration service.
-func NewUserRegisterService(userRepo output.UserRepository, userNameValidator, passwordValidator input.Validator) input.UserServiceRegister {
+func NewUserRegisterService(userRepo output.UserRepository, userNameValidator, passwordValidator input.Validator, businessMetrics *metrics.BusinessMetrics) input.UserServiceRegister {
 	return &UserRegisterService{
 		BaseAuthService: BaseAuthService{
 			UserRepo:          userRepo,
 			UserNameValidator: userNameValidator,
 			PasswordValidator: passwordValidator,
 		},
+		Metrics: businessMetrics,
 	}
 }
 
@@ -39,7 +46,7 @@ func NewUserRegisterService(userRepo output.UserRepository, userNameValidator, p
 // It performs the following steps in order:
 //   1. ValidateUserName – ensures the username meets formatting rules.
 //   2. ValidatePassword – ensures the password meets strength rules.
-//   3. CheckUserExists – returns a ConflictError if the username is already taken.
+//   3. CheckUserExists – returns a ConflictError if the username is already taken, ignoring case.
 //   4. SaveUser       – persists the new username and password (with salt and hash).
 //   5. GenerateToken – issues a JWT token for the newly created user.
 
@@ -50,9 +57,16 @@ func NewUserRegisterService(userRepo output.UserRepository, userNameValidator, p
 //   - string: a signed JWT token upon successful registration.
 //   - error: non‑nil if any validation, conflict, or persistence error occurs.
 func (r *UserRegisterService) Register(account models.Account) (string, error) {
+	token, err := r.register(account)
+	r.Metrics.RecordRegistration(err)
+	return token, err
+}
+
+// register performs the steps of Register without counting the attempt.
+func (r *UserRegisterService) register(account models.Account) (string, error) {
 	// 1. Validate username format
 	if err := r.ValidateUserName(account.UserName); err != nil {
-		return "", errors.NewValidationError(errors.ErrInvalidUsername)
+		return "", err
 	}
 
 	// 2. Validate password strength
@@ -60,8 +74,8 @@ func (r *UserRegisterService) Register(account models.Account) (string, error) {
 		return "", errors.NewValidationError(errors.ErrInvalidPassword)
 	}
 
-	// 3. Ensure the user does not already exist
-	exists, err := r.CheckUserExists(account.UserName)
+	// 3. Ensure the user does not already exist under any casing
+	exists, err := r.CheckUserExists(NormalizeUserName(account.UserName))
 	if err != nil {
 		return "", err
 	}
@@ -70,16 +84,17 @@ func (r *UserRegisterService) Register(account models.Account) (string, error) {
 	}
 
 	
-	// 4. Persist the new user with salted+hashed password
-	if err := r.UserRepo.SaveUser(account.UserName, account.Password); err != nil {
+	// 4. Persist the new user with salted+hashed password, keeping the casing chosen by the user
+	userName := strings.TrimSpace(account.UserName)
+	if err := r.UserRepo.SaveUser(userName, account.Password); err != nil {
 		return "", err
 	}
 	
-	userId, err := r.UserRepo.GetID(account.UserName)
+	userId, err := r.UserRepo.GetID(NormalizeUserName(userName))
 	if err != nil {
 		return "", err
 	}
 
-	// 5. Issue a JWT token for the new user
-	return r.GenerateToken(userId, account.UserName)
+	// 5. Issue a JWT token for the new user; new accounts are always regular users
+	return r.GenerateToken(userId, userName, models.RoleUser)
 }
@@ -0,0 +1,29 @@
+package service_auth_test
+
+import (
+	stdErrors "errors"
+	"net/http"
+	"testing"
+
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_auth"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+func TestRegisterRejectsUsernameDifferingOnlyByCase(t *testing.T) {
+	securityAuth.SetDefaultJWTService("0123456789abcdef0123456789abcdef", securityAuth.DefaultClockSkewTolerance)
+	repo := repotesting.NewInMemoryUserRepository()
+	service := service_auth.NewUserRegisterService(repo, &service_auth.UserNameValidator{}, &service_auth.PasswordValidator{}, nil)
+
+	if _, err := service.Register(models.Account{UserName: "alice", Password: "Str0ng!Password"}); err != nil {
+		t.Fatalf("Expected first registration to succeed, Got: %v", err)
+	}
+
+	_, err := service.Register(models.Account{UserName: "Alice", Password: "Str0ng!Password"})
+	var appErr *errors.AppError
+	if !stdErrors.As(err, &appErr) || appErr.Code != http.StatusConflict {
+		t.Errorf("Expected a ConflictError, Got: %v", err)
+	}
+}
@@ -5,7 +5,11 @@ package service_auth
 
 import (
 	"fmt"
+	"strings"
 	"unicode"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
 )
 
 const (
@@ -14,18 +18,41 @@ const (
 	minPasswordLength = 10 // minimum number of characters for a valid password
 )
 
+// reservedUserNames holds normalized usernames that cannot be registered.
+// "admin" is created by seeds/001_initial_admin.up.sql; letting anyone register it first would make the seed fail.
+var reservedUserNames = map[string]bool{
+	"admin": true,
+}
+
+// NormalizeUserName trims surrounding whitespace and lowercases the username.
+// Usernames are unique case-insensitively, so every lookup uses the normalized form; "Alice" and "alice" are the same account.
+func NormalizeUserName(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
 // UserNameValidator checks that usernames are non‑empty and meet a minimum length.
 //
-// It returns an error if the username is empty or shorter than minUserNameLength.
-type UserNameValidator struct{}
+// It returns an error if the username is empty or shorter than minUserNameLength. When built with NewUserNameValidator it also enforces the maximum length and character set of a UsernamePolicy and rejects reserved usernames; the zero value applies neither, so existing accounts, including the seeded admin, can still log in.
+type UserNameValidator struct {
+	policy         models.UsernamePolicy
+	rejectReserved bool
+}
+
+// NewUserNameValidator creates a UserNameValidator for new usernames, which additionally enforces policy and rejects reservedUserNames.
+func NewUserNameValidator(policy models.UsernamePolicy) *UserNameValidator {
+	return &UserNameValidator{policy: policy, rejectReserved: true}
+}
 
-// Validate enforces the username rules:
+// Validate enforces the username rules on the normalized username (see NormalizeUserName):
 //   • non‑empty
 //   • at least minUserNameLength characters
+//   • at most policy.MaxLength characters, counted in runes (ValidationError "Username too long")
+//   • matching policy.AllowedPattern (ValidationError "Username contains invalid characters")
+//   • not one of reservedUserNames (ValidationError "Username is reserved")
 
 // Returns a formatted error describing the violation.
 func (c *UserNameValidator) Validate(input interface{}) error {
-	username := input.(string)
+	username := NormalizeUserName(input.(string))
 	if username == "" {
 		return fmt.Errorf("you cannot enter empty fields")
 	}
@@ -34,6 +61,18 @@ func (c *UserNameValidator) Validate(input interface{}) error {
 		return fmt.Errorf("you cannot enter a name that is less than 5 characters")
 	}
 
+	if c.policy.MaxLength > 0 && len([]rune(username)) > c.policy.MaxLength {
+		return errors.NewValidationError(errors.ErrUsernameTooLong)
+	}
+
+	if c.policy.AllowedPattern != nil && !c.policy.AllowedPattern.MatchString(username) {
+		return errors.NewValidationError(errors.ErrUsernameCharset)
+	}
+
+	if c.rejectReserved && reservedUserNames[username] {
+		return errors.NewValidationError(errors.ErrUsernameReserved)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,58 @@
+package service_auth_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_auth"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+func TestUserNameValidatorPolicy(t *testing.T) {
+	validator := service_auth.NewUserNameValidator(models.UsernamePolicy{
+		MaxLength:      10,
+		AllowedPattern: regexp.MustCompile(`^[a-zA-Z0-9_-]+$`),
+	})
+
+	tests := []struct {
+		name     string
+		username string
+		message  string
+	}{
+		{"valid", "watch_fan", ""},
+		{"exactly max length", "abcdefghij", ""},
+		{"too long", "abcdefghijk", errors.ErrUsernameTooLong},
+		{"too long in runes", "relojeríaaa", errors.ErrUsernameTooLong},
+		{"space", "watch fan", errors.ErrUsernameCharset},
+		{"accented letter", "relojería", errors.ErrUsernameCharset},
+		{"reserved", "admin", errors.ErrUsernameReserved},
+		{"reserved in another case", " Admin ", errors.ErrUsernameReserved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.username)
+			if tt.message == "" {
+				if err != nil {
+					t.Errorf("Expected no error, Got: %v", err)
+				}
+				return
+			}
+			appErr, ok := err.(*errors.AppError)
+			if !ok || appErr.Message != tt.message {
+				t.Errorf("Expected ValidationError %q, Got: %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestUserNameValidatorZeroValueHasNoPolicy(t *testing.T) {
+	validator := &service_auth.UserNameValidator{}
+
+	for _, username := range []string{"a very long username with spaces", "admin"} {
+		if err := validator.Validate(username); err != nil {
+			t.Errorf("Expected no policy checks on the zero value for %q, Got: %v", username, err)
+		}
+	}
+}
@@ -2,9 +2,11 @@
 package service_comments
 
 import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
 	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/metrics"
 )
 
 // CommentAddService orchestrates the validation and persistence of a new comment.
@@ -13,9 +15,13 @@ import (
 // Fields:
 //   - commentRepository: handles database operations for comments.
 //   - commentValidate: enforces validation rules via the input.Validator interface.
+//   - settingsRepository: tells whether anonymous comments are allowed; nil keeps them disabled.
+//   - metrics: counts added comments by outcome; nil disables counting.
 type CommentAddService struct {
 	commentRepository output.CommentRepository
     commentValidate input.Validator
+    settingsRepository output.AppSettingsRepository
+    metrics *metrics.BusinessMetrics
 }
 
 // NewCommentAddService constructs a CommentAddService with the given dependencies.
@@ -23,13 +29,17 @@ type CommentAddService struct {
 // Parameters:
 //   - commentRepository: implementation of output.CommentRepository for data access.
 //   - commentValidate: implementation of input.Validator for comment data validation.
+//   - settingsRepository: implementation of output.AppSettingsRepository read for the allow_anonymous setting; nil disables anonymous comments.
+//   - businessMetrics: counters for comment outcomes; nil disables counting.
 
 // Returns:
 //   - input.CommentAddService: service to add new comments.
-func NewCommentAddService(commentRepository output.CommentRepository, commentValidate input.Validator) input.CommentAddService {
+func NewCommentAddService(commentRepository output.CommentRepository, commentValidate input.Validator, settingsRepository output.AppSettingsRepository, businessMetrics *metrics.BusinessMetrics) input.CommentAddService {
     return &CommentAddService{
         commentRepository: commentRepository,
         commentValidate: commentValidate,
+        settingsRepository: settingsRepository,
+        metrics: businessMetrics,
     }
 }
 
@@ -48,6 +58,43 @@ func NewCommentAddService(commentRepository output.CommentRepository, commentVal
 // Returns:
 //   - error: nil on success, or a validation/InternalError on failure.
 func (s *CommentAddService) AddComment(userID int, content string, rating int) error {
+    err := s.addComment(userID, content, rating)
+    s.metrics.RecordCommentAdded(err)
+    return err
+}
+
+// AddAnonymousComment validates and saves a comment under models.AnonymousUserID, so it is listed as written by models.AnonymousUserName.
+// It is rejected with a ForbiddenError unless the allow_anonymous setting is enabled.
+
+// Parameters:
+//   - content: the text content of the comment.
+//   - rating: numerical rating score for the comment.
+
+// Returns:
+//   - error: nil on success, or a Forbidden/validation/InternalError on failure.
+func (s *CommentAddService) AddAnonymousComment(content string, rating int) error {
+    err := s.addAnonymousComment(content, rating)
+    s.metrics.RecordCommentAdded(err)
+    return err
+}
+
+// addAnonymousComment checks the allow_anonymous setting, then performs the steps of AddComment for the anonymous user.
+func (s *CommentAddService) addAnonymousComment(content string, rating int) error {
+    if s.settingsRepository == nil {
+        return errors.NewForbiddenError(errors.ErrAnonymousCommentsDisabled)
+    }
+    allowed, err := s.settingsRepository.GetBool(models.SettingAllowAnonymousComments)
+    if err != nil {
+        return err
+    }
+    if !allowed {
+        return errors.NewForbiddenError(errors.ErrAnonymousCommentsDisabled)
+    }
+    return s.addComment(models.AnonymousUserID, content, rating)
+}
+
+// addComment performs the steps of AddComment without counting the attempt.
+func (s *CommentAddService) addComment(userID int, content string, rating int) error {
     // Step 1: Prepare validation payload
     validationData := CommentValidationData{
         Content: content,
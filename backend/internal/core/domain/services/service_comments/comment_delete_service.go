@@ -0,0 +1,57 @@
+// Package service_comments implements comment-related domain services, orchestrating validation and retrieval of user comments.
+package service_comments
+
+import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// CommentDeleteService lets users remove their own comments, and administrators remove any comment.
+
+// Fields:
+//   - commentRepository: provides access to persisted comment data; it enforces that only the author deletes a comment.
+type CommentDeleteService struct {
+	commentRepository output.CommentRepository
+}
+
+// NewCommentDeleteService constructs and returns a CommentDeleteService instance.
+
+// Parameters:
+//   - commentRepository: implementation of output.CommentRepository used to delete comments.
+
+// Returns:
+//   - input.CommentDeleteService: service interface for deleting comments.
+func NewCommentDeleteService(commentRepository output.CommentRepository) input.CommentDeleteService {
+	return &CommentDeleteService{
+		commentRepository: commentRepository,
+	}
+}
+
+// DeleteComment deletes the comment if userID wrote it.
+// NotFoundError and ForbiddenError from the repository are returned as is; other failures are wrapped in an InternalError.
+func (s *CommentDeleteService) DeleteComment(commentID, userID int) error {
+	if commentID < 1 {
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	}
+
+	err := s.commentRepository.DeleteComment(commentID, userID)
+	if err != nil && !errors.IsNotFound(err) && !errors.IsForbidden(err) {
+		return errors.NewInternalError(errors.ErrCommentDelete).WithError(err)
+	}
+	return err
+}
+
+// ForceDeleteComment deletes the comment regardless of its author; callers must restrict it to administrators.
+// NotFoundError from the repository is returned as is; other failures are wrapped in an InternalError.
+func (s *CommentDeleteService) ForceDeleteComment(commentID int) error {
+	if commentID < 1 {
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	}
+
+	err := s.commentRepository.ForceDeleteComment(commentID)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.NewInternalError(errors.ErrCommentDelete).WithError(err)
+	}
+	return err
+}
@@ -34,7 +34,7 @@ func NewCommentGetService(commentRepository output.CommentRepository, commentVal
     }
 }
 
-// AllComments retrieves all comments sorted by date (descending) via the repository.
+// AllComments retrieves all comments via the repository, pinned comments first, then sorted by date (descending).
 // It returns an InternalError if the underlying query fails.
 //
 // Returns:
@@ -48,4 +48,48 @@ func (s *CommentGetService) AllComments() ([]models.Comment, error) {
         return nil, errors.NewInternalError("Error while making the query")
     }
     return comments, nil
-}
\ No newline at end of file
+}
+
+// GetPage retrieves one page of comments, pinned comments first, then sorted by date (descending), with pagination metadata.
+// It returns a ValidationError if page or perPage is below 1, and an InternalError if the underlying query fails.
+func (s *CommentGetService) GetPage(page, perPage int) (models.Page[models.Comment], error) {
+	if page < 1 || perPage < 1 {
+		return models.Page[models.Comment]{}, errors.NewValidationError(errors.ErrInvalidRequest)
+	}
+
+	comments, total, err := s.commentRepository.GetCommentsPaginated(page, perPage)
+	if err != nil {
+		return models.Page[models.Comment]{}, errors.NewInternalError("Error while making the query").WithError(err)
+	}
+	return models.NewPage(comments, total, page, perPage), nil
+}
+
+// GetRatingHistogram returns the rating distribution of all comments, with every level from 1 to 5 present.
+// It returns an InternalError if the underlying query fails.
+func (s *CommentGetService) GetRatingHistogram() (models.RatingHistogram, error) {
+	counts, err := s.commentRepository.GetRatingHistogram()
+	if err != nil {
+		return nil, errors.NewInternalError("Error while making the query").WithError(err)
+	}
+
+	histogram := models.NewRatingHistogram()
+	for rating, count := range counts {
+		if _, ok := histogram[rating]; ok {
+			histogram[rating] = count
+		}
+	}
+	return histogram, nil
+}
+
+// GetPinned returns the pinned comments, most recently pinned first, as a non-nil slice.
+// It returns an InternalError if the underlying query fails.
+func (s *CommentGetService) GetPinned() ([]models.Comment, error) {
+	comments, err := s.commentRepository.GetPinned()
+	if err != nil {
+		return nil, errors.NewInternalError("Error while making the query").WithError(err)
+	}
+	if comments == nil {
+		comments = []models.Comment{}
+	}
+	return comments, nil
+}
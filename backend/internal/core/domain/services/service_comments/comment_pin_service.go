@@ -0,0 +1,57 @@
+// Package service_comments implements comment-related domain services, orchestrating validation and retrieval of user comments.
+package service_comments
+
+import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// CommentPinService lets administrators pin comments as homepage testimonials, and unpin them.
+
+// Fields:
+//   - commentRepository: provides access to persisted comment data; it enforces the models.MaxPinnedComments limit.
+type CommentPinService struct {
+	commentRepository output.CommentRepository
+}
+
+// NewCommentPinService constructs and returns a CommentPinService instance.
+
+// Parameters:
+//   - commentRepository: implementation of output.CommentRepository used to pin comments.
+
+// Returns:
+//   - input.CommentPinService: service interface for pinning comments.
+func NewCommentPinService(commentRepository output.CommentRepository) input.CommentPinService {
+	return &CommentPinService{
+		commentRepository: commentRepository,
+	}
+}
+
+// Pin pins the comment; callers must restrict it to administrators.
+// NotFoundError and ConflictError from the repository are returned as is; other failures are wrapped in an InternalError.
+func (s *CommentPinService) Pin(commentID int) error {
+	if commentID < 1 {
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	}
+
+	err := s.commentRepository.Pin(commentID)
+	if err != nil && !errors.IsNotFound(err) && !errors.IsConflict(err) {
+		return errors.NewInternalError(errors.ErrCommentUpdate).WithError(err)
+	}
+	return err
+}
+
+// Unpin unpins the comment; callers must restrict it to administrators.
+// NotFoundError from the repository is returned as is; other failures are wrapped in an InternalError.
+func (s *CommentPinService) Unpin(commentID int) error {
+	if commentID < 1 {
+		return errors.NewNotFoundError(errors.ErrCommentNotFound)
+	}
+
+	err := s.commentRepository.Unpin(commentID)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.NewInternalError(errors.ErrCommentUpdate).WithError(err)
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+// Package service_comments implements comment-related domain services, orchestrating validation and persistence of user comments.
+package service_comments
+
+import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// CommentUpdateService lets users correct their own comments, applying the same rules as new comments.
+
+// Fields:
+//   - commentRepository: handles database operations for comments; it enforces that only the author edits a comment.
+//   - commentValidate: enforces validation rules via the input.Validator interface.
+type CommentUpdateService struct {
+	commentRepository output.CommentRepository
+	commentValidate   input.Validator
+}
+
+// NewCommentUpdateService constructs a CommentUpdateService with the given dependencies.
+
+// Parameters:
+//   - commentRepository: implementation of output.CommentRepository for data access.
+//   - commentValidate: implementation of input.Validator for comment data validation, typically CommentValidator.
+
+// Returns:
+//   - input.CommentUpdateService: service to edit comments.
+func NewCommentUpdateService(commentRepository output.CommentRepository, commentValidate input.Validator) input.CommentUpdateService {
+	return &CommentUpdateService{
+		commentRepository: commentRepository,
+		commentValidate:   commentValidate,
+	}
+}
+
+// UpdateComment validates the new content and rating, stores them if userID wrote the comment and returns the updated comment.
+// Validation, NotFound and Forbidden errors are returned as is; other failures are wrapped in an InternalError.
+func (s *CommentUpdateService) UpdateComment(commentID, userID int, content string, rating int) (models.Comment, error) {
+	if err := s.commentValidate.Validate(CommentValidationData{Content: content, Rating: rating}); err != nil {
+		return models.Comment{}, err
+	}
+	if commentID < 1 {
+		return models.Comment{}, errors.NewNotFoundError(errors.ErrCommentNotFound)
+	}
+
+	if err := s.commentRepository.UpdateComment(commentID, userID, content, rating); err != nil {
+		if errors.IsNotFound(err) || errors.IsForbidden(err) {
+			return models.Comment{}, err
+		}
+		return models.Comment{}, errors.NewInternalError(errors.ErrCommentUpdate).WithError(err)
+	}
+
+	comment, err := s.commentRepository.GetComment(commentID)
+	if err != nil {
+		return models.Comment{}, errors.NewInternalError(errors.ErrCommentUpdate).WithError(err)
+	}
+	return comment, nil
+}
@@ -0,0 +1,51 @@
+package service_comments_test
+
+import (
+	"testing"
+
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_comments"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+func TestUpdateComment(t *testing.T) {
+	repo := repotesting.NewInMemoryCommentRepository(repotesting.WithComments([]models.Comment{
+		{ID: 1, UserID: 7, Content: "Great watch", Rating: 4},
+	}))
+	service := service_comments.NewCommentUpdateService(repo, &service_comments.CommentValidator{})
+
+	tests := []struct {
+		name      string
+		commentID int
+		userID    int
+		content   string
+		rating    int
+		isError   func(error) bool
+	}{
+		{"empty content", 1, 7, "", 4, errors.IsValidationError},
+		{"rating out of range", 1, 7, "Great watch", 6, errors.IsValidationError},
+		{"another user's comment", 1, 8, "Edited", 5, errors.IsForbidden},
+		{"missing comment", 99, 7, "Edited", 5, errors.IsNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.UpdateComment(tt.commentID, tt.userID, tt.content, tt.rating)
+			if !tt.isError(err) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if stored, _ := repo.GetComment(1); stored.Content != "Great watch" {
+				t.Errorf("Expected the comment to be unchanged, Got %q", stored.Content)
+			}
+		})
+	}
+
+	comment, err := service.UpdateComment(1, 7, "Still great after a year", 5)
+	if err != nil {
+		t.Fatalf("UpdateComment failed: %v", err)
+	}
+	if comment.Content != "Still great after a year" || comment.Rating != 5 {
+		t.Errorf("Expected the updated comment, Got %+v", comment)
+	}
+}
@@ -0,0 +1,60 @@
+// Package service_products implements product domain services for the watch catalogue.
+package service_products
+
+import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// ProductGetService implements the input.ProductGetService interface.
+
+// Fields:
+//   - productRepository: provides access to persisted products.
+type ProductGetService struct {
+	productRepository output.ProductRepository
+}
+
+// NewProductGetService constructs and returns a ProductGetService instance.
+
+// Parameters:
+//   - productRepository: implementation of output.ProductRepository for data fetching.
+
+// Returns:
+//   - input.ProductGetService: service interface for browsing products.
+func NewProductGetService(productRepository output.ProductRepository) input.ProductGetService {
+	return &ProductGetService{
+		productRepository: productRepository,
+	}
+}
+
+// AllProducts retrieves the whole catalogue, newest first; a nil result is replaced by an empty slice so it encodes as a JSON array.
+// It returns an InternalError if the underlying query fails.
+func (s *ProductGetService) AllProducts() ([]models.Product, error) {
+	products, err := s.productRepository.GetProducts()
+	if err != nil {
+		return nil, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+	}
+	if products == nil {
+		products = []models.Product{}
+	}
+	return products, nil
+}
+
+// ProductByID retrieves a single product.
+// It returns a NotFoundError if no product has this ID, and an InternalError if the underlying query fails.
+func (s *ProductGetService) ProductByID(id int) (*models.Product, error) {
+	if id < 1 {
+		return nil, errors.NewNotFoundError(errors.ErrProductNotFound)
+	}
+
+	product, err := s.productRepository.GetProductByID(id)
+	if errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.NewInternalError(errors.ErrDatabaseQuery).WithError(err)
+	}
+	return product, nil
+}
@@ -0,0 +1,52 @@
+// Package service_profile implements user profile domain services, orchestrating validation and persistence of the public profile.
+package service_profile
+
+import (
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// UserProfileService implements the input.UserProfileService interface.
+
+// Fields:
+//   - profileRepository: provides access to persisted profile data.
+//   - displayNameValidator: validator applied to new display names.
+type UserProfileService struct {
+	profileRepository    output.UserProfileRepository
+	displayNameValidator input.Validator
+}
+
+// NewUserProfileService constructs and returns a UserProfileService instance.
+
+// Parameters:
+//   - profileRepository: implementation of output.UserProfileRepository.
+//   - displayNameValidator: implementation of input.Validator for display names.
+
+// Returns:
+//   - input.UserProfileService: service interface for reading and updating profiles.
+func NewUserProfileService(profileRepository output.UserProfileRepository, displayNameValidator input.Validator) input.UserProfileService {
+	return &UserProfileService{
+		profileRepository:    profileRepository,
+		displayNameValidator: displayNameValidator,
+	}
+}
+
+// GetProfile returns the profile of the given user as stored by the repository.
+func (s *UserProfileService) GetProfile(userID int) (models.UserProfile, error) {
+	return s.profileRepository.GetProfile(userID)
+}
+
+// UpdateDisplayName validates the new display name, stores it and returns the refreshed profile.
+// It returns a ValidationError if the name is invalid, or the repository error if persistence fails.
+func (s *UserProfileService) UpdateDisplayName(userID int, displayName string) (models.UserProfile, error) {
+	if err := s.displayNameValidator.Validate(displayName); err != nil {
+		return models.UserProfile{}, errors.NewValidationError(errors.ErrInvalidDisplayName).WithError(err)
+	}
+
+	if err := s.profileRepository.UpdateDisplayName(userID, displayName); err != nil {
+		return models.UserProfile{}, err
+	}
+	return s.profileRepository.GetProfile(userID)
+}
@@ -0,0 +1,41 @@
+// Package service_profile implements user profile domain services.
+
+// This file contains the display name validator.
+package service_profile
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	minDisplayNameLength = 2  // minimum number of characters for a display name
+	maxDisplayNameLength = 50 // maximum number of characters for a display name
+)
+
+// DisplayNameValidator checks that display names have a reasonable length and no surrounding whitespace.
+type DisplayNameValidator struct{}
+
+// Validate enforces the display name rules:
+//   • between minDisplayNameLength and maxDisplayNameLength characters (counted as runes)
+//   • no leading or trailing whitespace
+
+// Returns a formatted error describing the violation.
+func (v *DisplayNameValidator) Validate(input interface{}) error {
+	displayName, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("the display name must be a string")
+	}
+
+	if strings.TrimSpace(displayName) != displayName {
+		return fmt.Errorf("the display name cannot start or end with spaces")
+	}
+
+	length := utf8.RuneCountInString(displayName)
+	if length < minDisplayNameLength || length > maxDisplayNameLength {
+		return fmt.Errorf("the display name must be between %d and %d characters", minDisplayNameLength, maxDisplayNameLength)
+	}
+
+	return nil
+}
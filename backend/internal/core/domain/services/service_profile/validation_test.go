@@ -0,0 +1,34 @@
+package service_profile_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_profile"
+)
+
+func TestDisplayNameValidator(t *testing.T) {
+	tests := []struct {
+		name        string
+		displayName string
+		expectError bool
+	}{
+		{"valid", "Watch Fan", false},
+		{"minimum length", "Al", false},
+		{"multi-byte characters", "José Ñúñez", false},
+		{"too short", "A", true},
+		{"too long", strings.Repeat("a", 51), true},
+		{"leading whitespace", " Watch Fan", true},
+		{"trailing whitespace", "Watch Fan ", true},
+	}
+
+	validator := &service_profile.DisplayNameValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.displayName)
+			if (err != nil) != tt.expectError {
+				t.Errorf("Validate(%q) Expected error: %v, Got: %v", tt.displayName, tt.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Package service_sla implements the SLA reporting service, which summarises the persisted request log per route.
+package service_sla
+
+import (
+	"math"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/input"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+)
+
+// SLAReportService computes daily SLA reports from an output.RequestLogRepository.
+type SLAReportService struct {
+	requestLogRepository output.RequestLogRepository
+}
+
+// NewSLAReportService constructs an SLAReportService reading from requestLogRepository.
+func NewSLAReportService(requestLogRepository output.RequestLogRepository) input.SLAReportService {
+	return &SLAReportService{
+		requestLogRepository: requestLogRepository,
+	}
+}
+
+// GetDailyReport summarises the requests logged during the UTC day containing date.
+
+// Percentiles use the nearest-rank method: the p-th percentile of n requests is the duration at rank ceil(p/100 * n), read from the repository with one ordered query per percentile.
+func (s *SLAReportService) GetDailyReport(date time.Time) (models.SLAReport, error) {
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	stats, err := s.requestLogRepository.GetRouteStats(from, to)
+	if err != nil {
+		return models.SLAReport{}, err
+	}
+
+	report := models.SLAReport{
+		Date:   from.Format(time.DateOnly),
+		Routes: make([]models.RouteSLA, 0, len(stats)),
+	}
+	for _, route := range stats {
+		sla := models.RouteSLA{
+			Route:         route.Route,
+			TotalRequests: route.Total,
+			ErrorRate:     float64(route.Errors) / float64(route.Total),
+		}
+		for _, target := range []struct {
+			percentile float64
+			value      *float64
+		}{{50, &sla.P50}, {95, &sla.P95}, {99, &sla.P99}} {
+			*target.value, err = s.requestLogRepository.GetDurationAt(route.Route, from, to, nearestRankOffset(target.percentile, route.Total))
+			if err != nil {
+				return models.SLAReport{}, err
+			}
+		}
+
+		report.TotalRequests += route.Total
+		report.Routes = append(report.Routes, sla)
+	}
+	return report, nil
+}
+
+// nearestRankOffset returns the 0-based offset of the percentile-th value among total sorted values.
+func nearestRankOffset(percentile float64, total int) int {
+	rank := int(math.Ceil(percentile / 100 * float64(total)))
+	if rank < 1 {
+		rank = 1
+	}
+	return rank - 1
+}
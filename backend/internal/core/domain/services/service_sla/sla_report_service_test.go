@@ -0,0 +1,86 @@
+package service_sla_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_sla"
+)
+
+// memoryRequestLog is an in-memory output.RequestLogRepository.
+type memoryRequestLog struct {
+	records []models.RequestLogRecord
+}
+
+func (m *memoryRequestLog) Save(record models.RequestLogRecord) error {
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *memoryRequestLog) inRange(route string, from, to time.Time) []models.RequestLogRecord {
+	var matched []models.RequestLogRecord
+	for _, record := range m.records {
+		if (route == "" || record.Route == route) && !record.Timestamp.Before(from) && record.Timestamp.Before(to) {
+			matched = append(matched, record)
+		}
+	}
+	return matched
+}
+
+func (m *memoryRequestLog) GetRouteStats(from, to time.Time) ([]models.RouteRequestStats, error) {
+	byRoute := map[string]*models.RouteRequestStats{}
+	for _, record := range m.inRange("", from, to) {
+		if byRoute[record.Route] == nil {
+			byRoute[record.Route] = &models.RouteRequestStats{Route: record.Route}
+		}
+		byRoute[record.Route].Total++
+		if record.Status >= 500 {
+			byRoute[record.Route].Errors++
+		}
+	}
+	var stats []models.RouteRequestStats
+	for _, s := range byRoute {
+		stats = append(stats, *s)
+	}
+	return stats, nil
+}
+
+func (m *memoryRequestLog) GetDurationAt(route string, from, to time.Time, offset int) (float64, error) {
+	var durations []float64
+	for _, record := range m.inRange(route, from, to) {
+		durations = append(durations, float64(record.Duration)/float64(time.Millisecond))
+	}
+	sort.Float64s(durations)
+	return durations[offset], nil
+}
+
+func TestGetDailyReport(t *testing.T) {
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	repo := &memoryRequestLog{}
+	for i := 1; i <= 100; i++ {
+		status := 200
+		if i%10 == 0 {
+			status = 500
+		}
+		repo.Save(models.RequestLogRecord{Method: "GET", Route: "/comments", Status: status, Duration: time.Duration(i) * time.Millisecond, Timestamp: day.Add(time.Duration(i) * time.Minute)})
+	}
+	repo.Save(models.RequestLogRecord{Method: "GET", Route: "/comments", Status: 200, Duration: time.Second, Timestamp: day.AddDate(0, 0, 1)})
+
+	report, err := service_sla.NewSLAReportService(repo).GetDailyReport(day.Add(15 * time.Hour))
+	if err != nil {
+		t.Fatalf("GetDailyReport() unexpected error: %v", err)
+	}
+
+	if report.Date != "2024-03-10" || report.TotalRequests != 100 || len(report.Routes) != 1 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	route := report.Routes[0]
+	if route.P50 != 50 || route.P95 != 95 || route.P99 != 99 {
+		t.Errorf("Expected p50/p95/p99 of 50/95/99ms, Got %v/%v/%v", route.P50, route.P95, route.P99)
+	}
+	if route.ErrorRate != 0.1 {
+		t.Errorf("Expected an error rate of 0.1, Got %v", route.ErrorRate)
+	}
+}
@@ -18,4 +18,11 @@ type UserServiceRegister interface {
 	// Register creates a new user account with provided credentials.
 	// Returns a JWT token string for the newly created account or an error if registration fails.
 	Register(account models.Account) (string, error)
-}
\ No newline at end of file
+}
+
+// PasswordChangeService defines the interface for changing the password of an authenticated user.
+type PasswordChangeService interface {
+	// ChangePassword replaces the user's password after checking currentPassword.
+	// Returns an AuthError if currentPassword is wrong and a ValidationError if newPassword breaks the password rules.
+	ChangePassword(userID int, currentPassword, newPassword string) error
+}
@@ -11,4 +11,11 @@ type CommentAddService interface {
     // Returns:
     //   - error: non-nil if validation or persistence fails.
 	AddComment(userID int, content string, rating int) error
+	// AddAnonymousComment creates a comment that is not attributed to its author.
+    // Parameters:
+    //   - content:  Body text of the comment.
+    //   - rating:   Numerical rating (1–5).
+    // Returns:
+    //   - error: ForbiddenError if anonymous comments are disabled, or non-nil if validation or persistence fails.
+	AddAnonymousComment(content string, rating int) error
 }
@@ -0,0 +1,20 @@
+// Package input defines service contracts for comment-related business logic, user operations, and input validation.
+package input
+
+// CommentDeleteService handles removal of comments by their authors and by administrators.
+type CommentDeleteService interface {
+	// DeleteComment deletes a comment written by the given user.
+	// Parameters:
+	//   - commentID: ID of the comment to delete.
+	//   - userID:    ID of the authenticated user.
+	// Returns:
+	//   - error: NotFoundError if the comment does not exist, ForbiddenError if it belongs to another user, or non-nil if persistence fails.
+	DeleteComment(commentID, userID int) error
+
+	// ForceDeleteComment deletes any comment, whoever wrote it, for moderation by administrators.
+	// Parameters:
+	//   - commentID: ID of the comment to delete.
+	// Returns:
+	//   - error: NotFoundError if the comment does not exist, or non-nil if persistence fails.
+	ForceDeleteComment(commentID int) error
+}
@@ -5,9 +5,30 @@ import "github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/mod
 
 // CommentGetService handles retrieval of comments.
 type CommentGetService interface {
-	// AllComments returns all comments ordered by date descending.
+	// AllComments returns all comments, pinned ones first, then ordered by date descending.
     // Returns:
     //   - []models.Comment: list of comments including metadata.
     //   - error: non-nil if the query fails.
 	AllComments() ([]models.Comment, error)
+
+	// GetPage returns one page of comments, pinned ones first, then ordered by date descending.
+	// Parameters:
+	//   - page:    1-based page index.
+	//   - perPage: maximum number of comments per page.
+	// Returns:
+	//   - models.Page[models.Comment]: the comments of the page with pagination metadata.
+	//   - error: non-nil if the arguments are out of range or the query fails.
+	GetPage(page, perPage int) (models.Page[models.Comment], error)
+
+	// GetRatingHistogram returns the number of comments at each rating level (1–5).
+	// Returns:
+	//   - models.RatingHistogram: counts for every rating level, zero when absent.
+	//   - error: non-nil if the query fails.
+	GetRatingHistogram() (models.RatingHistogram, error)
+
+	// GetPinned returns the comments pinned as homepage testimonials, most recently pinned first.
+	// Returns:
+	//   - []models.Comment: the pinned comments; empty when none are pinned.
+	//   - error: non-nil if the query fails.
+	GetPinned() ([]models.Comment, error)
 }
\ No newline at end of file
@@ -0,0 +1,19 @@
+// Package input defines service contracts for comment-related business logic, user operations, and input validation.
+package input
+
+// CommentPinService handles pinning comments as homepage testimonials, for administrators.
+type CommentPinService interface {
+	// Pin marks a comment as pinned. Pinning an already pinned comment is a no-op.
+	// Parameters:
+	//   - commentID: ID of the comment to pin.
+	// Returns:
+	//   - error: NotFoundError if the comment does not exist, ConflictError if models.MaxPinnedComments comments are already pinned, or non-nil if persistence fails.
+	Pin(commentID int) error
+
+	// Unpin removes the pinned mark from a comment. Unpinning a comment that is not pinned is a no-op.
+	// Parameters:
+	//   - commentID: ID of the comment to unpin.
+	// Returns:
+	//   - error: NotFoundError if the comment does not exist, or non-nil if persistence fails.
+	Unpin(commentID int) error
+}
@@ -0,0 +1,19 @@
+// Package input defines service contracts for comment-related business logic, user operations, and input validation.
+package input
+
+import "github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+
+// CommentUpdateService handles edits of comments by their authors.
+type CommentUpdateService interface {
+	// UpdateComment validates and stores the new content and rating of a comment written by the given user.
+	// Parameters:
+	//   - commentID: ID of the comment to edit.
+	//   - userID:    ID of the authenticated user.
+	//   - content:   new body text of the comment.
+	//   - rating:    new numerical rating (1–5).
+	// Returns:
+	//   - models.Comment: the updated comment.
+	//   - error: ValidationError if the content or rating breaks the comment rules, NotFoundError if the comment does not exist,
+	//     ForbiddenError if it belongs to another user, or non-nil if persistence fails.
+	UpdateComment(commentID, userID int, content string, rating int) (models.Comment, error)
+}
@@ -0,0 +1,21 @@
+// Package input defines service contracts for comment-related business logic, user operations, and input validation.
+package input
+
+import "github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+
+// ProductGetService handles browsing of the watch catalogue.
+type ProductGetService interface {
+	// AllProducts returns every product, newest first.
+	// Returns:
+	//   - []models.Product: the catalogue; empty when there are no products.
+	//   - error: non-nil if the query fails.
+	AllProducts() ([]models.Product, error)
+
+	// ProductByID returns a single product.
+	// Parameters:
+	//   - id: ID of the product.
+	// Returns:
+	//   - *models.Product: the product.
+	//   - error: NotFoundError if the product does not exist, or non-nil if the query fails.
+	ProductByID(id int) (*models.Product, error)
+}
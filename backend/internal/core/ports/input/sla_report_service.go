@@ -0,0 +1,14 @@
+// Package input defines service contracts for comment-related business logic, user operations, and input validation.
+package input
+
+import (
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+)
+
+// SLAReportService builds latency and error reports from the persisted request log.
+type SLAReportService interface {
+	// GetDailyReport returns the p50, p95 and p99 latency, error rate and request count of every route for the UTC day containing date.
+	GetDailyReport(date time.Time) (models.SLAReport, error)
+}
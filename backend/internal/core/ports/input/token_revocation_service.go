@@ -0,0 +1,12 @@
+// Package input defines service contracts for comment-related business logic, user operations, and input validation.
+package input
+
+// TokenRevocationService revokes access tokens before they expire, so a logged-out session cannot be reused.
+type TokenRevocationService interface {
+	// RevokeToken revokes the given access token.
+	// Tokens that are expired or invalid are ignored, since they are rejected anyway.
+	RevokeToken(token string) error
+
+	// IsRevoked reports whether the token with the given ID (jti claim) was revoked.
+	IsRevoked(tokenID string) (bool, error)
+}
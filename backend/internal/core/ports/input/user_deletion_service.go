@@ -0,0 +1,10 @@
+// Package input defines service contracts for comment-related business logic, user operations, and input validation.
+package input
+
+// UserDeletionService handles accounts deleted by their owners.
+type UserDeletionService interface {
+	// DeleteAccount soft-deletes the account of the given user, who can no longer sign in afterwards.
+	// Returns:
+	//   - error: NotFoundError if the user does not exist or was already deleted, or non-nil if persistence fails.
+	DeleteAccount(userID int) error
+}
@@ -0,0 +1,16 @@
+// Package input defines service contracts for comment-related business logic, user operations, and input validation.
+package input
+
+import "github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+
+// UserListService lists registered accounts for administrators.
+type UserListService interface {
+	// ListUsers returns one page of registered users, oldest account first, without any credential data.
+	// Parameters:
+	//   - page:    1-based page index.
+	//   - perPage: maximum number of users per page.
+	// Returns:
+	//   - models.Page[models.UserProfile]: the users of the page with pagination metadata.
+	//   - error: non-nil if the arguments are out of range or the query fails.
+	ListUsers(page, perPage int) (models.Page[models.UserProfile], error)
+}
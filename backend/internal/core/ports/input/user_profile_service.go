@@ -0,0 +1,14 @@
+// Package input defines service contracts for comment-related business logic, user operations, and input validation.
+package input
+
+import "github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+
+// UserProfileService handles reading and updating the profile of the authenticated user.
+type UserProfileService interface {
+	// GetProfile returns the profile of the given user.
+	GetProfile(userID int) (models.UserProfile, error)
+
+	// UpdateDisplayName validates and stores a new display name, returning the updated profile.
+	// Returns a ValidationError if the display name breaks the naming rules.
+	UpdateDisplayName(userID int, displayName string) (models.UserProfile, error)
+}
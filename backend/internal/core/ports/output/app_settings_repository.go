@@ -0,0 +1,9 @@
+// Package output defines persistence contracts for comments and users.
+package output
+
+// AppSettingsRepository reads application settings that can be changed at runtime without a redeploy.
+type AppSettingsRepository interface {
+	// GetBool returns the value of a boolean setting.
+	// A setting that has never been stored is reported as false, so features guarded by it stay disabled.
+	GetBool(key string) (bool, error)
+}
@@ -0,0 +1,18 @@
+// Package output defines persistence contracts for comments and users.
+package output
+
+import "github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+
+// AuditRepository persists the audit log of administrator actions.
+type AuditRepository interface {
+	// Record stores a new entry; its ID is ignored and assigned by the repository.
+	// Returns:
+	//   - error: non-nil if persistence fails.
+	Record(entry models.AuditEntry) error
+
+	// List returns the entries matching filter, most recent first.
+	// Returns:
+	//   - []models.AuditEntry: the matching entries, empty when there are none.
+	//   - error: non-nil if the lookup fails.
+	List(filter models.AuditFilter) ([]models.AuditEntry, error)
+}
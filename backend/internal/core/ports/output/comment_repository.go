@@ -10,6 +10,16 @@ type CommentRepository interface {
     //   - []models.Comment: slice of comments.
     //   - error: non-nil if retrieval fails.
 	GetComments() ([]models.Comment, error)
+
+	// GetCommentsPaginated fetches one page of comments, newest first.
+	// Parameters:
+	//   - page:     1-based page index.
+	//   - pageSize: maximum number of comments per page.
+	// Returns:
+	//   - []models.Comment: comments of the requested page.
+	//   - int: total number of stored comments.
+	//   - error: non-nil if retrieval fails.
+	GetCommentsPaginated(page, pageSize int) ([]models.Comment, int, error)
 	
 	// SaveComment stores a new comment with associated user ID and rating.
     // Parameters:
@@ -19,4 +29,66 @@ type CommentRepository interface {
     // Returns:
     //   - error: non-nil if persistence fails.
 	SaveComment(userID int, content string, rating int) error
+
+	// GetRatingHistogram counts the stored comments grouped by rating.
+	// Returns:
+	//   - map[int]int: rating → number of comments; ratings without comments are absent.
+	//   - error: non-nil if the query fails.
+	GetRatingHistogram() (map[int]int, error)
+
+	// GetPinned fetches the pinned comments, most recently pinned first.
+	// Returns:
+	//   - []models.Comment: the pinned comments (at most models.MaxPinnedComments).
+	//   - error: non-nil if retrieval fails.
+	GetPinned() ([]models.Comment, error)
+
+	// Pin marks a comment as pinned. Pinning an already pinned comment is a no-op.
+	// Parameters:
+	//   - commentID: ID of the comment to pin.
+	// Returns:
+	//   - error: NotFoundError if the comment does not exist, ConflictError if
+	//     models.MaxPinnedComments comments are already pinned, non-nil if persistence fails.
+	Pin(commentID int) error
+
+	// Unpin removes the pinned mark from a comment.
+	// Parameters:
+	//   - commentID: ID of the comment to unpin.
+	// Returns:
+	//   - error: NotFoundError if the comment does not exist, non-nil if persistence fails.
+	Unpin(commentID int) error
+
+	// DeleteComment removes a comment written by the requesting user.
+	// Parameters:
+	//   - commentID:        ID of the comment to delete.
+	//   - requestingUserID: ID of the authenticated user; only the author may delete the comment.
+	// Returns:
+	//   - error: NotFoundError if the comment does not exist, ForbiddenError if it
+	//     belongs to another user, non-nil if persistence fails.
+	DeleteComment(commentID, requestingUserID int) error
+
+	// ForceDeleteComment removes a comment whoever wrote it, for moderation by administrators.
+	// Parameters:
+	//   - commentID: ID of the comment to delete.
+	// Returns:
+	//   - error: NotFoundError if the comment does not exist, non-nil if persistence fails.
+	ForceDeleteComment(commentID int) error
+
+	// GetComment fetches a single comment.
+	// Parameters:
+	//   - commentID: ID of the comment.
+	// Returns:
+	//   - models.Comment: the comment.
+	//   - error: NotFoundError if the comment does not exist, non-nil if retrieval fails.
+	GetComment(commentID int) (models.Comment, error)
+
+	// UpdateComment replaces the content and rating of a comment written by the user.
+	// Parameters:
+	//   - commentID: ID of the comment to edit.
+	//   - userID:    ID of the authenticated user; only the author may edit the comment.
+	//   - content:   new comment text.
+	//   - rating:    new numerical rating (1–5).
+	// Returns:
+	//   - error: NotFoundError if the comment does not exist, ForbiddenError if it
+	//     belongs to another user, non-nil if persistence fails.
+	UpdateComment(commentID, userID int, content string, rating int) error
 }
\ No newline at end of file
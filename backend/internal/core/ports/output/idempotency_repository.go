@@ -0,0 +1,25 @@
+// Package output defines persistence contracts for comments and users.
+package output
+
+import (
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+)
+
+// IdempotencyRepository persists the responses of requests sent with an idempotency key.
+type IdempotencyRepository interface {
+	// Check looks up a non-expired record for the key.
+	// Returns (nil, nil) when no record exists, so the request must be processed normally.
+	Check(key string) (*models.IdempotencyRecord, error)
+
+	// Store records the response produced for the key.
+	// Parameters:
+	//   - key:        idempotency key.
+	//   - statusCode: HTTP status code of the response.
+	//   - body:       raw response body.
+	//   - ttl:        how long the record stays valid.
+	// Returns:
+	//   - error: non-nil if persistence fails.
+	Store(key string, statusCode int, body []byte, ttl time.Duration) error
+}
@@ -0,0 +1,23 @@
+// Package output defines persistence contracts for comments and users.
+package output
+
+import "time"
+
+// PKCERepository stores the PKCE code challenges of pending OAuth2 authorization requests, keyed by their state parameter.
+type PKCERepository interface {
+	// SaveChallenge records the S256 code challenge sent with an authorization request.
+	// Parameters:
+	//   - state:     opaque state value identifying the authorization request.
+	//   - challenge: BASE64URL(SHA256(code_verifier)) sent by the client.
+	//   - ttl:       how long the challenge may be redeemed.
+	// Returns:
+	//   - error: non-nil if persistence fails.
+	SaveChallenge(state, challenge string, ttl time.Duration) error
+
+	// ValidateChallenge recomputes the challenge of verifier and compares it with the one stored for state.
+	// A challenge can be redeemed only once: it is removed whether or not the verifier matches.
+	// Returns:
+	//   - bool: true if a non-expired challenge exists for state and matches verifier.
+	//   - error: non-nil if the lookup fails.
+	ValidateChallenge(state, verifier string) (bool, error)
+}
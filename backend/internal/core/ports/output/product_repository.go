@@ -0,0 +1,42 @@
+// Package output defines persistence contracts for comments and users.
+package output
+
+import "github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+
+// ProductRepository persists and retrieves the watches offered in the store.
+type ProductRepository interface {
+	// GetProducts fetches all products, newest first.
+	// Returns:
+	//   - []models.Product: slice of products.
+	//   - error: non-nil if retrieval fails.
+	GetProducts() ([]models.Product, error)
+
+	// GetProductByID fetches a single product.
+	// Parameters:
+	//   - id: ID of the product.
+	// Returns:
+	//   - *models.Product: the product.
+	//   - error: NotFoundError if the product does not exist, non-nil if retrieval fails.
+	GetProductByID(id int) (*models.Product, error)
+
+	// SaveProduct stores a new product. Its ID and CreatedAt are assigned by the store.
+	// Parameters:
+	//   - p: the product to add.
+	// Returns:
+	//   - error: non-nil if persistence fails.
+	SaveProduct(p models.Product) error
+
+	// UpdateProduct replaces the name, brand, price, stock, image and description of the product with p.ID.
+	// Parameters:
+	//   - p: the product with its new values.
+	// Returns:
+	//   - error: NotFoundError if the product does not exist, non-nil if persistence fails.
+	UpdateProduct(p models.Product) error
+
+	// DeleteProduct removes a product.
+	// Parameters:
+	//   - id: ID of the product to delete.
+	// Returns:
+	//   - error: NotFoundError if the product does not exist, non-nil if persistence fails.
+	DeleteProduct(id int) error
+}
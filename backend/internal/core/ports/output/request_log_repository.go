@@ -0,0 +1,20 @@
+// Package output defines persistence contracts for comments and users.
+package output
+
+import (
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+)
+
+// RequestLogRepository persists per-request records and answers the queries behind the SLA report.
+type RequestLogRepository interface {
+	// Save stores the record of one handled request.
+	Save(record models.RequestLogRecord) error
+
+	// GetRouteStats returns, for every route with requests in [from, to), the number of requests and of server errors (status >= 500).
+	GetRouteStats(from, to time.Time) ([]models.RouteRequestStats, error)
+
+	// GetDurationAt returns the duration in milliseconds of the request at the 0-based offset when the route's requests in [from, to) are sorted by duration.
+	GetDurationAt(route string, from, to time.Time, offset int) (float64, error)
+}
@@ -25,4 +25,16 @@ type StaticFilePort interface {
 	// Uses file extension to identify content type. Returns empty string for unknown types.
 	// Common return values include "text/css" for .css, "application/js" for .js, etc.
 	GetMimeType(filename string) string
+
+	// GetPublicURL returns the URL under which clients fetch the static file at path, i.e. the path
+	// joined to the configured URL prefix ("/" by default, or a CDN base URL such as "https://cdn.example.com/assets/").
+	GetPublicURL(path string) string
+
+	// VersionedURL returns the URL of a static file with a content hash query parameter appended
+	// (e.g. "/css/style.css?v=3f2a9c1d0b7e"), so browsers fetch a fresh copy whenever the file changes.
+	// Returns an error if the path does not point to a file inside the static directory.
+	VersionedURL(path string) (string, error)
+
+	// VersionManifest returns the versioned URL of every static file, keyed by its unversioned URL path.
+	VersionManifest() (map[string]string, error)
 }
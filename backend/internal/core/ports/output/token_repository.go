@@ -0,0 +1,33 @@
+// Package output defines persistence contracts for comments and users.
+package output
+
+import "time"
+
+// TokenRepository records issued tokens so they can be revoked server-side.
+type TokenRepository interface {
+	// Save records a newly issued token.
+	// Parameters:
+	//   - tokenID:   the token's ID (jti) claim.
+	//   - userID:    ID of the user the token was issued to.
+	//   - expiresAt: when the token expires.
+	// Returns:
+	//   - error: non-nil if persistence fails.
+	Save(tokenID string, userID int, expiresAt time.Time) error
+
+	// Revoke marks the token as used, so it cannot be redeemed again.
+	// Returns:
+	//   - bool: true if the token was active and this call revoked it; false if it is unknown or was already revoked.
+	//   - error: non-nil if the update fails.
+	Revoke(tokenID string) (bool, error)
+
+	// IsRevoked reports whether the token was revoked.
+	// Returns:
+	//   - bool: true if the token is recorded and revoked; false if it is unknown or still active.
+	//   - error: non-nil if the lookup fails.
+	IsRevoked(tokenID string) (bool, error)
+
+	// RevokeAllForUser revokes every active token issued to the user, as when the account is deleted.
+	// Returns:
+	//   - error: non-nil if the update fails.
+	RevokeAllForUser(userID int) error
+}
@@ -0,0 +1,19 @@
+// Package output defines persistence contracts for comments and users.
+package output
+
+import "github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+
+// UserProfileRepository persists and retrieves the public profile of a user.
+type UserProfileRepository interface {
+	// GetProfile returns the profile of the user with the given ID.
+	// DisplayName falls back to the login username when the user never set one; CreatedAt is the registration date.
+	// Returns:
+	//   - models.UserProfile: the user's profile.
+	//   - error: NotFoundError if the user does not exist, InternalError on storage failures.
+	GetProfile(userID int) (models.UserProfile, error)
+
+	// UpdateDisplayName sets the display name of the user, creating the profile row if needed.
+	// Returns:
+	//   - error: non-nil if persistence fails.
+	UpdateDisplayName(userID int, displayName string) error
+}
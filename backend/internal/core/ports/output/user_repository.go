@@ -1,6 +1,8 @@
 // Package output defines persistence contracts for comments and users.
 package output
 
+import "github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+
 // UserRepository persists and retrieves user credentials.
 type UserRepository interface {
 	// UserExists checks if a username is already registered in the system.
@@ -15,6 +17,13 @@ type UserRepository interface {
 		// - Storage system failure occurs
 	GetHashPassword(username string) (string, error)
 
+	// GetHashPasswordByID retrieves the hashed password of the user with the given ID.
+	// Used to re-authenticate a logged-in user, e.g. before a password change.
+	// Returns:
+	//   - string: the stored password hash.
+	//   - error: NotFoundError if the user does not exist, InternalError on storage failures.
+	GetHashPasswordByID(userID int) (string, error)
+
 	// SaveUser persists a new user record with secure credential storage.
 	// Implementations should:
 		// - Generate unique salt per user
@@ -32,4 +41,31 @@ type UserRepository interface {
     //   - int: user ID.
     //   - error: non-nil if user not found or storage error.
 	GetID(username string) (int, error)
+
+	// GetRole returns the role of the user with the given ID, models.RoleUser unless the account was promoted.
+	// Returns:
+	//   - string: the stored role.
+	//   - error: NotFoundError if the user does not exist, InternalError on storage failures.
+	GetRole(userID int) (string, error)
+
+	// UpdatePassword replaces the stored password hash of a user.
+	// Used to upgrade legacy hashes to the current algorithm after a successful login.
+	// Returns:
+	//   - error: non-nil if the user does not exist or the update fails.
+	UpdatePassword(userID int, hash string) error
+
+	// ListUsers fetches one page of registered users, oldest account first. Credential data is never included.
+	// Parameters:
+	//   - page:     1-based page index.
+	//   - pageSize: maximum number of users per page.
+	// Returns:
+	//   - []models.UserProfile: users of the requested page.
+	//   - int: total number of users.
+	//   - error: InternalError on storage failures.
+	ListUsers(page, pageSize int) ([]models.UserProfile, int, error)
+
+	// DeleteUser soft-deletes a user: the account row is kept but ignored by every lookup.
+	// Returns:
+	//   - error: NotFoundError if the user does not exist or was already deleted, InternalError on storage failures.
+	DeleteUser(userID int) error
 }
@@ -0,0 +1,3 @@
+ALTER TABLE User_Registration
+    DROP INDEX idx_user_registration_username_norm,
+    DROP COLUMN UserNameNorm;
@@ -0,0 +1,5 @@
+-- Enforce case-insensitive uniqueness of usernames: "Alice" and "alice" can no longer be registered as separate accounts.
+-- Existing accounts that differ only by case must be merged or renamed before applying this migration, otherwise the UNIQUE index cannot be created.
+ALTER TABLE User_Registration
+    ADD COLUMN UserNameNorm VARCHAR(255) AS (LOWER(UserName)) STORED,
+    ADD UNIQUE INDEX idx_user_registration_username_norm (UserNameNorm);
@@ -0,0 +1,2 @@
+ALTER TABLE User_Registration
+    DROP COLUMN Role;
@@ -0,0 +1,3 @@
+-- Adds the role of each account. Every existing and new account is a regular "user" unless promoted.
+ALTER TABLE User_Registration
+    ADD COLUMN Role VARCHAR(20) NOT NULL DEFAULT 'user';
@@ -0,0 +1,4 @@
+ALTER TABLE comments
+    DROP INDEX idx_comments_pinned,
+    DROP COLUMN PinnedAt,
+    DROP COLUMN Pinned;
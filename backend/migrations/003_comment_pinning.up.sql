@@ -0,0 +1,5 @@
+-- Lets store owners pin up to five comments as homepage testimonials. Pinned comments are listed before all others.
+ALTER TABLE comments
+    ADD COLUMN Pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN PinnedAt DATETIME NULL,
+    ADD INDEX idx_comments_pinned (Pinned, PinnedAt);
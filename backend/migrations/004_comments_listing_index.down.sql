@@ -0,0 +1,2 @@
+ALTER TABLE comments
+    DROP INDEX idx_comments_pinned_date;
@@ -0,0 +1,3 @@
+-- Lets the comment listing (ORDER BY Pinned DESC, Date DESC) read rows in index order instead of sorting the whole table.
+ALTER TABLE comments
+    ADD INDEX idx_comments_pinned_date (Pinned, Date);
@@ -0,0 +1 @@
+DROP TABLE http_request_log;
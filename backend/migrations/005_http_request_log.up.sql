@@ -0,0 +1,11 @@
+-- Stores one row per handled request when logging.sla_log_enabled is set, for the daily SLA report (GET /admin/metrics/sla).
+CREATE TABLE http_request_log (
+    ID BIGINT AUTO_INCREMENT PRIMARY KEY,
+    Method VARCHAR(10) NOT NULL,
+    Route VARCHAR(255) NOT NULL,
+    Status SMALLINT NOT NULL,
+    DurationMs DOUBLE NOT NULL,
+    CreatedAt DATETIME NOT NULL,
+    INDEX idx_http_request_log_created_route (CreatedAt, Route),
+    INDEX idx_http_request_log_route_duration (Route, DurationMs)
+);
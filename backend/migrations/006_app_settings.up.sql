@@ -0,0 +1,8 @@
+-- Settings that can be changed at runtime without a redeploy, read through AppSettingsRepository.
+CREATE TABLE app_settings (
+    SettingKey VARCHAR(64) PRIMARY KEY,
+    BoolValue BOOL NOT NULL DEFAULT FALSE
+);
+
+-- Anonymous comments are stored with UserID 0 and shown as "Anonymous"; they stay disabled until this row is set to TRUE.
+INSERT INTO app_settings (SettingKey, BoolValue) VALUES ('allow_anonymous', FALSE);
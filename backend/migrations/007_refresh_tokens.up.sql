@@ -0,0 +1,8 @@
+-- Issued refresh tokens, keyed by their jti claim. A token is redeemed once: refreshing sets RevokedAt and issues a new token.
+CREATE TABLE refresh_tokens (
+    TokenID CHAR(32) PRIMARY KEY,
+    UserID INT NOT NULL,
+    ExpiresAt DATETIME NOT NULL,
+    RevokedAt DATETIME NULL,
+    INDEX idx_refresh_tokens_user (UserID)
+);
@@ -0,0 +1,12 @@
+-- Watches offered in the store, read and written through ProductRepository.
+CREATE TABLE products (
+    ID INT AUTO_INCREMENT PRIMARY KEY,
+    Name VARCHAR(255) NOT NULL,
+    Brand VARCHAR(100) NOT NULL,
+    Price DECIMAL(10, 2) NOT NULL,
+    Stock INT NOT NULL DEFAULT 0,
+    ImageURL VARCHAR(2048) NOT NULL DEFAULT '',
+    Description TEXT NOT NULL,
+    CreatedAt DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_products_brand (Brand)
+);
@@ -0,0 +1,4 @@
+-- Records when each account was registered, reported as the join date by GET /users/me.
+-- Accounts created before this migration get the time it was applied.
+ALTER TABLE User_Registration
+    ADD COLUMN CreatedAt DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,3 @@
+-- Soft-deletes accounts through DELETE /users/me: deleted accounts keep their row, so their comments stay attributable, but can no longer sign in.
+ALTER TABLE User_Registration
+    ADD COLUMN DeletedAt DATETIME NULL DEFAULT NULL;
@@ -0,0 +1,7 @@
+-- Optional per-user profile data, 1:1 with User_Registration. Users without a row, or with a NULL DisplayName, are shown by their UserName.
+-- The FOREIGN KEY is declared at table level because MySQL ignores inline REFERENCES clauses.
+CREATE TABLE user_profiles (
+    UserID INT PRIMARY KEY,
+    DisplayName VARCHAR(50) NULL,
+    CONSTRAINT fk_user_profiles_user FOREIGN KEY (UserID) REFERENCES User_Registration (UserID)
+);
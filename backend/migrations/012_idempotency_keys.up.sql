@@ -0,0 +1,8 @@
+-- Responses replayed by IdempotencyMiddleware for retried POST requests, keyed by "<userID>:<path>:<X-Idempotency-Key>".
+-- The PRIMARY KEY makes the ON DUPLICATE KEY UPDATE of SQLIdempotencyRepository.Store replace an expired record for the same key.
+CREATE TABLE idempotency_keys (
+    IdempotencyKey VARCHAR(255) PRIMARY KEY,
+    StatusCode SMALLINT NOT NULL,
+    Body MEDIUMBLOB NOT NULL,
+    ExpiresAt DATETIME NOT NULL
+);
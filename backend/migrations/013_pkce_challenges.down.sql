@@ -0,0 +1 @@
+DROP TABLE pkce_challenges;
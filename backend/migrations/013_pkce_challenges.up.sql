@@ -0,0 +1,7 @@
+-- PKCE code challenges awaiting redemption, keyed by the client's state. SQLPKCERepository deletes a row when it is redeemed.
+-- Challenge is the S256 code challenge sent by the client, 43 characters when well formed.
+CREATE TABLE pkce_challenges (
+    State VARCHAR(255) PRIMARY KEY,
+    Challenge VARCHAR(128) NOT NULL,
+    ExpiresAt DATETIME NOT NULL
+);
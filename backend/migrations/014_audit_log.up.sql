@@ -0,0 +1,14 @@
+-- Audit log of sensitive administrator actions; an impersonation is recorded with Action 'impersonation' before its token is issued.
+-- ActorID is the administrator and UserID the targeted user. The FOREIGN KEYs are declared at table level because MySQL ignores inline REFERENCES clauses.
+CREATE TABLE audit_log (
+    ID BIGINT AUTO_INCREMENT PRIMARY KEY,
+    Action VARCHAR(50) NOT NULL,
+    ActorID INT NOT NULL,
+    UserID INT NOT NULL,
+    Reason VARCHAR(500) NOT NULL,
+    CreatedAt DATETIME NOT NULL,
+    INDEX idx_audit_log_action_created (Action, CreatedAt),
+    INDEX idx_audit_log_action_user_created (Action, UserID, CreatedAt),
+    CONSTRAINT fk_audit_log_actor FOREIGN KEY (ActorID) REFERENCES User_Registration (UserID),
+    CONSTRAINT fk_audit_log_user FOREIGN KEY (UserID) REFERENCES User_Registration (UserID)
+);
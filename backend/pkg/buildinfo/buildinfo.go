@@ -0,0 +1,58 @@
+// Package buildinfo exposes version metadata of the running binary.
+// Version, Commit and BuildTime are injected at build time with -ldflags, for example:
+//
+//	go build -ldflags "-X github.com/David-Alejandro-Jimenez/sale-watches/pkg/buildinfo.Version=1.4.0 \
+//	  -X github.com/David-Alejandro-Jimenez/sale-watches/pkg/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/David-Alejandro-Jimenez/sale-watches/pkg/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/api
+package buildinfo
+
+import (
+	"log"
+	"runtime"
+)
+
+// Values injected via -ldflags. Binaries built without them report development defaults.
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info describes the running binary and the port it serves on.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	Port      string `json:"port"`
+}
+
+// Get returns the build metadata together with the Go runtime version, the target platform and the configured port.
+func Get(port string) Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Port:      port,
+	}
+}
+
+// Print logs a single startup banner line with the build metadata.
+func Print(logger *log.Logger, port string) {
+	info := Get(port)
+	logger.Printf(
+		"sale-watches %s (commit %s, built %s) %s %s/%s port=%s",
+		info.Version,
+		info.Commit,
+		info.BuildTime,
+		info.GoVersion,
+		info.OS,
+		info.Arch,
+		info.Port,
+	)
+}
@@ -0,0 +1,49 @@
+// Package db provides database helpers shared by the SQL repositories.
+// This file contains ExplainQuery, which retrieves the MySQL execution plan of a query for slow query analysis.
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// explainableStatements lists the statement types MySQL accepts after EXPLAIN.
+var explainableStatements = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "REPLACE", "WITH"}
+
+// IsExplainable reports whether query is a statement MySQL can EXPLAIN (SELECT, INSERT, UPDATE, DELETE, REPLACE or a WITH query).
+func IsExplainable(query string) bool {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, statement := range explainableStatements {
+		if strings.EqualFold(fields[0], statement) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExplainQuery runs EXPLAIN FORMAT=JSON for query with the given placeholder arguments and returns the plan as compact JSON.
+// The query itself is not executed. It returns an error if the statement cannot be explained or the EXPLAIN fails.
+func ExplainQuery(ctx context.Context, db *sqlx.DB, query string, args []interface{}) (string, error) {
+	if !IsExplainable(query) {
+		return "", fmt.Errorf("query cannot be explained: %s", truncateQuery(query))
+	}
+
+	var plan string
+	if err := db.QueryRowContext(ctx, "EXPLAIN FORMAT=JSON "+query, args...).Scan(&plan); err != nil {
+		return "", err
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(plan)); err != nil {
+		return plan, nil
+	}
+	return compact.String(), nil
+}
@@ -0,0 +1,233 @@
+// Package db provides database helpers shared by the SQL repositories.
+// This file contains LoggingDB, a *sqlx.DB wrapper that logs every query with its duration and redacted parameters, flagging slow and failing queries.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+)
+
+// maxLoggedQueryLength is the number of characters of a query kept in log lines.
+const maxLoggedQueryLength = 200
+
+// explainTimeout bounds the EXPLAIN run for a slow query, so logging never stalls a request for long.
+const explainTimeout = 5 * time.Second
+
+// Queryer is the subset of *sqlx.DB used by the SQL repositories.
+// Both *sqlx.DB and *LoggingDB satisfy it, so query logging can be switched on without touching repository code.
+type Queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Select(dest interface{}, query string, args ...interface{}) error
+	Get(dest interface{}, query string, args ...interface{}) error
+
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// sensitiveFields lists column name fragments whose bound values are never logged.
+var sensitiveFields = []string{"password", "salt", "secret", "token", "hash"}
+
+var (
+	// whitespacePattern collapses newlines and indentation in multi-line queries.
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	// insertColumnsPattern captures the column list of an INSERT ... (cols) VALUES statement.
+	insertColumnsPattern = regexp.MustCompile(`(?i)^\s*INSERT\s+INTO\s+\S+\s*\(([^)]*)\)\s*VALUES`)
+	// comparedColumnPattern captures the column compared against a placeholder, e.g. "Password = ?".
+	comparedColumnPattern = regexp.MustCompile(`(?i)([\w.]+)\s*(?:=|<>|!=|<=|>=|<|>|LIKE)\s*$`)
+)
+
+// LoggingDB wraps *sqlx.DB and logs each query executed through the Queryer methods.
+
+// Every query is logged with its duration (truncated to 200 characters) and its parameters, with values bound to sensitive columns replaced by "[REDACTED]". Queries slower than slowThreshold are logged with a "[SLOW QUERY]" prefix and failing queries with "[QUERY ERROR]". When the context passed to a *Context method carries a trace (see pkg/tracing), slow and failing query lines also include its trace_id.
+// With WithExplain, the execution plan of each slow query is logged as well, on a "[QUERY PLAN]" line.
+type LoggingDB struct {
+	*sqlx.DB
+	slowThreshold time.Duration
+	explainSlow   bool
+}
+
+// NewLoggingDB creates a LoggingDB around an open database connection.
+// slowThreshold: duration beyond which a query is reported as slow.
+func NewLoggingDB(db *sqlx.DB, slowThreshold time.Duration) *LoggingDB {
+	return &LoggingDB{
+		DB:            db,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// WithExplain enables or disables logging the EXPLAIN FORMAT=JSON plan of slow queries.
+// Returns the modified LoggingDB to enable method chaining.
+func (l *LoggingDB) WithExplain(enabled bool) *LoggingDB {
+	l.explainSlow = enabled
+	return l
+}
+
+// QueryRow delegates to QueryRowContext with a background context.
+func (l *LoggingDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return l.QueryRowContext(context.Background(), query, args...)
+}
+
+// Exec delegates to ExecContext with a background context.
+func (l *LoggingDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return l.ExecContext(context.Background(), query, args...)
+}
+
+// Select delegates to SelectContext with a background context.
+func (l *LoggingDB) Select(dest interface{}, query string, args ...interface{}) error {
+	return l.SelectContext(context.Background(), dest, query, args...)
+}
+
+// Get delegates to GetContext with a background context.
+func (l *LoggingDB) Get(dest interface{}, query string, args ...interface{}) error {
+	return l.GetContext(context.Background(), dest, query, args...)
+}
+
+// QueryRowContext runs the query and logs it. Errors are taken from Row.Err, since sql.Row defers them until Scan.
+func (l *LoggingDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := l.DB.QueryRowContext(ctx, query, args...)
+	l.logQuery(ctx, query, args, time.Since(start), row.Err())
+	return row
+}
+
+// ExecContext runs the statement and logs it.
+func (l *LoggingDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := l.DB.ExecContext(ctx, query, args...)
+	l.logQuery(ctx, query, args, time.Since(start), err)
+	return result, err
+}
+
+// SelectContext runs the query, scans all rows into dest and logs it.
+func (l *LoggingDB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	start := time.Now()
+	err := l.DB.SelectContext(ctx, dest, query, args...)
+	l.logQuery(ctx, query, args, time.Since(start), err)
+	return err
+}
+
+// GetContext runs the query, scans a single row into dest and logs it.
+func (l *LoggingDB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	start := time.Now()
+	err := l.DB.GetContext(ctx, dest, query, args...)
+	l.logQuery(ctx, query, args, time.Since(start), err)
+	return err
+}
+
+// logQuery writes a single log line for an executed query, tagging slow and failing queries with the trace ID found in ctx.
+func (l *LoggingDB) logQuery(ctx context.Context, query string, args []interface{}, duration time.Duration, err error) {
+	prefix := "[QUERY]"
+	switch {
+	case err != nil && err != sql.ErrNoRows:
+		prefix = "[QUERY ERROR]"
+	case duration > l.slowThreshold:
+		prefix = "[SLOW QUERY]"
+	}
+
+	line := fmt.Sprintf("%s %s %s args=%v", prefix, duration, truncateQuery(query), redactArgs(query, args))
+	if prefix == "[QUERY ERROR]" {
+		line += fmt.Sprintf(" error=%v", err)
+	}
+	traceID := tracing.TraceID(ctx)
+	if traceID != "" && prefix != "[QUERY]" {
+		line += " trace_id=" + traceID
+	}
+	log.Println(line)
+
+	if prefix == "[SLOW QUERY]" && l.explainSlow && IsExplainable(query) {
+		l.logPlan(ctx, query, args, traceID)
+	}
+}
+
+// logPlan logs the execution plan of a slow query. Failures to obtain the plan are logged and otherwise ignored.
+func (l *LoggingDB) logPlan(ctx context.Context, query string, args []interface{}, traceID string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), explainTimeout)
+	defer cancel()
+
+	plan, err := ExplainQuery(ctx, l.DB, query, args)
+	if err != nil {
+		log.Printf("[QUERY PLAN] unavailable for %s: %v", truncateQuery(query), err)
+		return
+	}
+
+	line := fmt.Sprintf("[QUERY PLAN] %s plan=%s", truncateQuery(query), plan)
+	if traceID != "" {
+		line += " trace_id=" + traceID
+	}
+	log.Println(line)
+}
+
+// truncateQuery collapses whitespace and shortens the query to maxLoggedQueryLength characters.
+func truncateQuery(query string) string {
+	query = strings.TrimSpace(whitespacePattern.ReplaceAllString(query, " "))
+	if len(query) > maxLoggedQueryLength {
+		return query[:maxLoggedQueryLength] + "..."
+	}
+	return query
+}
+
+// redactArgs returns a copy of args where every value bound to a sensitive column is replaced by "[REDACTED]".
+func redactArgs(query string, args []interface{}) []interface{} {
+	columns := placeholderColumns(query, len(args))
+	redacted := make([]interface{}, len(args))
+	for i, arg := range args {
+		if isSensitiveColumn(columns[i]) {
+			redacted[i] = "[REDACTED]"
+			continue
+		}
+		redacted[i] = arg
+	}
+	return redacted
+}
+
+// placeholderColumns guesses the column name bound to each "?" placeholder of the query.
+// INSERT column lists are mapped positionally; other placeholders use the column they are compared against. Unknown columns are returned as empty strings.
+func placeholderColumns(query string, argCount int) []string {
+	columns := make([]string, argCount)
+
+	var insertColumns []string
+	if match := insertColumnsPattern.FindStringSubmatch(query); match != nil {
+		for _, column := range strings.Split(match[1], ",") {
+			insertColumns = append(insertColumns, strings.TrimSpace(column))
+		}
+	}
+
+	placeholder := 0
+	for i := 0; i < len(query) && placeholder < argCount; i++ {
+		if query[i] != '?' {
+			continue
+		}
+		if placeholder < len(insertColumns) {
+			columns[placeholder] = insertColumns[placeholder]
+		} else if match := comparedColumnPattern.FindStringSubmatch(query[:i]); match != nil {
+			columns[placeholder] = match[1]
+		}
+		placeholder++
+	}
+	return columns
+}
+
+// isSensitiveColumn reports whether the column name contains one of the sensitive field fragments.
+func isSensitiveColumn(column string) bool {
+	column = strings.ToLower(column)
+	if column == "" {
+		return false
+	}
+	for _, field := range sensitiveFields {
+		if strings.Contains(column, field) {
+			return true
+		}
+	}
+	return false
+}
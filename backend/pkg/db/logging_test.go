@@ -0,0 +1,103 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/tracing"
+)
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		expected []interface{}
+	}{
+		{
+			name:     "insert column list",
+			query:    "INSERT INTO User_Registration (UserName, Password) VALUES (?, ?)",
+			args:     []interface{}{"alice", "hash"},
+			expected: []interface{}{"alice", "[REDACTED]"},
+		},
+		{
+			name:     "where clause",
+			query:    "SELECT ID FROM User_Registration WHERE UserName = ? AND Salt = ?",
+			args:     []interface{}{"alice", "salt"},
+			expected: []interface{}{"alice", "[REDACTED]"},
+		},
+		{
+			name:     "no sensitive columns",
+			query:    "INSERT INTO Comments (User_ID, Content, Rating) VALUES (?, ?, ?)",
+			args:     []interface{}{1, "nice", 5},
+			expected: []interface{}{1, "nice", 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactArgs(tt.query, tt.args)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("redactArgs() = Expected: %v, Got: %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTruncateQuery(t *testing.T) {
+	query := "SELECT *\n\tFROM Comments " + strings.Repeat("x", 300)
+	got := truncateQuery(query)
+
+	if strings.ContainsAny(got, "\n\t") {
+		t.Errorf("truncateQuery() kept whitespace. Got: %q", got)
+	}
+	if len(got) != maxLoggedQueryLength+len("...") {
+		t.Errorf("truncateQuery() length = Expected: %d, Got: %d", maxLoggedQueryLength+3, len(got))
+	}
+}
+
+func TestLogQueryIncludesTraceIDForSlowQueries(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	tc, _ := tracing.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := tracing.ContextWithTrace(context.Background(), tc)
+	l := &LoggingDB{slowThreshold: time.Millisecond}
+
+	l.logQuery(ctx, "SELECT 1", nil, time.Second, nil)
+	if !strings.Contains(buf.String(), "[SLOW QUERY]") || !strings.Contains(buf.String(), "trace_id="+tc.TraceID) {
+		t.Errorf("Expected a slow query line with the trace ID, Got: %q", buf.String())
+	}
+
+	buf.Reset()
+	l.logQuery(ctx, "SELECT 1", nil, 0, nil)
+	if strings.Contains(buf.String(), "trace_id=") {
+		t.Errorf("Expected no trace ID on regular query lines, Got: %q", buf.String())
+	}
+}
+
+func TestIsExplainable(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"SELECT 1", true},
+		{"\n\tselect * FROM comments", true},
+		{"UPDATE comments SET Pinned = TRUE WHERE ID = ?", true},
+		{"SHOW TABLES", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsExplainable(tt.query); got != tt.expected {
+			t.Errorf("IsExplainable(%q) Expected: %v, Got: %v", tt.query, tt.expected, got)
+		}
+	}
+}
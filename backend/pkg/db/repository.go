@@ -0,0 +1,83 @@
+// Package db provides database helpers shared by the SQL repositories.
+// This file contains BaseRepository, which runs queries for a row type and maps database errors to application errors.
+package db
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlErrDuplicateEntry is the MySQL error number (ER_DUP_ENTRY) raised when an INSERT violates a unique index.
+const mysqlErrDuplicateEntry = 1062
+
+// IsDuplicateKeyError reports whether err is a MySQL unique index violation.
+func IsDuplicateKeyError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return stdErrors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
+
+// BaseRepository runs queries whose rows scan into T and maps their errors, so SQL repositories do not repeat the same error handling.
+
+// It is meant to be embedded: the Queryer is promoted, so repositories can still issue queries that do not fit T directly.
+// Errors are mapped as follows:
+//   - sql.ErrNoRows becomes a NotFoundError with the repository's not-found message
+//   - a duplicate-key violation (MySQL error 1062) becomes a ConflictError with the repository's conflict message
+//   - any other error becomes an InternalError wrapping the original error
+type BaseRepository[T any] struct {
+	Queryer
+	notFoundMessage string
+	conflictMessage string
+}
+
+// NewBaseRepository creates a BaseRepository on db, using notFoundMessage and conflictMessage for the mapped NotFoundError and ConflictError.
+func NewBaseRepository[T any](db Queryer, notFoundMessage, conflictMessage string) BaseRepository[T] {
+	return BaseRepository[T]{
+		Queryer:         db,
+		notFoundMessage: notFoundMessage,
+		conflictMessage: conflictMessage,
+	}
+}
+
+// FindOne runs query and scans its single row into a T. It returns a NotFoundError when the query matches no row.
+func (r BaseRepository[T]) FindOne(ctx context.Context, query string, args ...interface{}) (T, error) {
+	var row T
+	if err := r.GetContext(ctx, &row, query, args...); err != nil {
+		var zero T
+		return zero, r.mapError(err, errors.ErrDatabaseQuery)
+	}
+	return row, nil
+}
+
+// FindMany runs query and scans every row into a T. No matching rows is not an error.
+func (r BaseRepository[T]) FindMany(ctx context.Context, query string, args ...interface{}) ([]T, error) {
+	var rows []T
+	if err := r.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, r.mapError(err, errors.ErrDatabaseQuery)
+	}
+	return rows, nil
+}
+
+// Exec runs a statement that returns no rows, such as an INSERT or UPDATE. A duplicate-key violation is returned as a ConflictError.
+func (r BaseRepository[T]) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	result, err := r.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapError(err, errors.ErrDatabaseQuery)
+	}
+	return result, nil
+}
+
+// mapError converts a database error into a NotFoundError, a ConflictError, or an InternalError with internalMessage.
+func (r BaseRepository[T]) mapError(err error, internalMessage string) error {
+	switch {
+	case stdErrors.Is(err, sql.ErrNoRows):
+		return errors.NewNotFoundError(r.notFoundMessage)
+	case IsDuplicateKeyError(err):
+		return errors.NewConflictError(r.conflictMessage).WithError(err)
+	default:
+		return errors.NewInternalError(internalMessage).WithError(err)
+	}
+}
@@ -0,0 +1,27 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestIsDuplicateKeyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"duplicate entry", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, true},
+		{"wrapped duplicate entry", fmt.Errorf("insert: %w", &mysql.MySQLError{Number: 1062}), true},
+		{"other mysql error", &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"}, false},
+		{"non mysql error", fmt.Errorf("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsDuplicateKeyError(tt.err); got != tt.expected {
+			t.Errorf("IsDuplicateKeyError(%s) Expected: %v, Got: %v", tt.name, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Package db provides database helpers shared by the SQL repositories.
+// This file contains the seeder, which applies the SQL files of the seeds directory once each, recording applied seeds in the schema_seeds table.
+package db
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// seedFileSuffix selects the files of the seeds directory that are applied.
+const seedFileSuffix = ".up.sql"
+
+// createSeedsTable records which seed files have already been applied.
+const createSeedsTable = `CREATE TABLE IF NOT EXISTS schema_seeds (
+	Name VARCHAR(255) NOT NULL PRIMARY KEY,
+	AppliedAt DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+// envPlaceholderPattern matches ${NAME} references to environment variables inside seed files.
+var envPlaceholderPattern = regexp.MustCompile(`\$\{([A-Z0-9_]+)\}`)
+
+// RunSeeds applies, in file name order, every *.up.sql file of dir that is not yet recorded in schema_seeds.
+
+// Each seed runs in its own transaction together with its schema_seeds record, so a failing seed is rolled back and retried on the next run. Statements are separated by ";" at the end of a line. A ${NAME} reference is bound as a query parameter to the value of the NAME environment variable, so secrets such as ADMIN_PASSWORD_HASH never appear in the SQL text; an unset variable aborts the seed.
+
+// Returns:
+//   - []string: names of the seed files applied by this run.
+//   - error: non-nil if the directory cannot be read or a seed fails.
+func RunSeeds(db *sqlx.DB, dir string) ([]string, error) {
+	if _, err := db.Exec(createSeedsTable); err != nil {
+		return nil, fmt.Errorf("creating schema_seeds table: %w", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+seedFileSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("listing seeds in %s: %w", dir, err)
+	}
+	sort.Strings(files)
+
+	var applied []string
+	for _, file := range files {
+		name := filepath.Base(file)
+
+		var done bool
+		if err := db.Get(&done, "SELECT EXISTS(SELECT 1 FROM schema_seeds WHERE Name = ?)", name); err != nil {
+			return applied, fmt.Errorf("checking seed %s: %w", name, err)
+		}
+		if done {
+			continue
+		}
+
+		if err := applySeed(db, file, name); err != nil {
+			return applied, err
+		}
+		log.Printf("Applied seed %s", name)
+		applied = append(applied, name)
+	}
+	return applied, nil
+}
+
+// applySeed runs the statements of a single seed file and records it in schema_seeds within one transaction.
+func applySeed(db *sqlx.DB, file, name string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("reading seed %s: %w", name, err)
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return fmt.Errorf("starting seed %s: %w", name, err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range splitStatements(string(content)) {
+		query, args, err := bindEnvPlaceholders(statement)
+		if err != nil {
+			return fmt.Errorf("seed %s: %w", name, err)
+		}
+		if _, err := tx.Exec(query, args...); err != nil {
+			return fmt.Errorf("applying seed %s: %w", name, err)
+		}
+	}
+
+	if _, err := tx.Exec("INSERT INTO schema_seeds (Name) VALUES (?)", name); err != nil {
+		return fmt.Errorf("recording seed %s: %w", name, err)
+	}
+	return tx.Commit()
+}
+
+// splitStatements splits a SQL file into statements terminated by ";" at the end of a line, dropping "--" comment lines and empty statements.
+func splitStatements(content string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			if statement := strings.TrimSuffix(strings.TrimSpace(current.String()), ";"); statement != "" {
+				statements = append(statements, statement)
+			}
+			current.Reset()
+		}
+	}
+	if statement := strings.TrimSpace(current.String()); statement != "" {
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+// bindEnvPlaceholders replaces every ${NAME} reference of statement with a "?" placeholder and returns the matching environment variable values as arguments.
+func bindEnvPlaceholders(statement string) (string, []interface{}, error) {
+	var args []interface{}
+	var missing []string
+	query := envPlaceholderPattern.ReplaceAllStringFunc(statement, func(match string) string {
+		name := envPlaceholderPattern.FindStringSubmatch(match)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			missing = append(missing, name)
+		}
+		args = append(args, value)
+		return "?"
+	})
+	if len(missing) > 0 {
+		return "", nil, fmt.Errorf("environment variable(s) not set: %s", strings.Join(missing, ", "))
+	}
+	return query, args, nil
+}
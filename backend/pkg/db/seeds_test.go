@@ -0,0 +1,46 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	content := `-- Creates the admin account
+INSERT INTO User_Registration (UserName, Password)
+VALUES ('admin', ${ADMIN_PASSWORD_HASH});
+
+UPDATE User_Registration SET Role = 'admin' WHERE UserName = 'admin';
+`
+	expected := []string{
+		"INSERT INTO User_Registration (UserName, Password)\nVALUES ('admin', ${ADMIN_PASSWORD_HASH})",
+		"UPDATE User_Registration SET Role = 'admin' WHERE UserName = 'admin'",
+	}
+
+	if got := splitStatements(content); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q, Got %q", expected, got)
+	}
+}
+
+func TestBindEnvPlaceholders(t *testing.T) {
+	t.Setenv("ADMIN_PASSWORD_HASH", "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$a2V5")
+
+	query, args, err := bindEnvPlaceholders("INSERT INTO User_Registration (UserName, Password) VALUES ('admin', ${ADMIN_PASSWORD_HASH})")
+	if err != nil {
+		t.Fatalf("Expected no error, Got: %v", err)
+	}
+	if query != "INSERT INTO User_Registration (UserName, Password) VALUES ('admin', ?)" {
+		t.Errorf("Expected placeholder substitution, Got %q", query)
+	}
+	if len(args) != 1 || args[0] != "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$a2V5" {
+		t.Errorf("Expected the environment value as argument, Got %v", args)
+	}
+}
+
+func TestBindEnvPlaceholdersMissingVariable(t *testing.T) {
+	t.Setenv("SEED_TEST_UNSET", "")
+
+	if _, _, err := bindEnvPlaceholders("SELECT ${SEED_TEST_UNSET}"); err == nil {
+		t.Error("Expected an error for an unset variable")
+	}
+}
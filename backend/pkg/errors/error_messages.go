@@ -11,8 +11,14 @@ const (
 	ErrUserAlreadyExists  = "The user already exists"
 	ErrInvalidUsername    = "Invalid username"
 	ErrInvalidPassword    = "Invalid password"
+	ErrInvalidDisplayName = "Invalid display name"
 	ErrTokenGeneration    = "Error generating token"
 	ErrTokenValidation    = "Invalid or expired token"
+	ErrRefreshTokenReused = "Refresh token revoked or already used"
+
+	// CSRF errors
+	ErrInvalidCSRFToken    = "Invalid or missing CSRF token"
+	ErrCSRFTokenGeneration = "Error generating CSRF token"
 
 	// Database errors
 	ErrDatabaseConnection = "Database connection error"
@@ -26,21 +32,63 @@ const (
 	ErrInvalidFormat     = "Invalid format"
 	ErrInvalidLength     = "Invalid length"
 	ErrInvalidCharacters = "Characters not allowed"
+	ErrUsernameTooLong   = "Username too long"
+	ErrUsernameCharset   = "Username contains invalid characters"
+	ErrUsernameReserved  = "Username is reserved"
 	
 	// Comment operations errors
-	ErrCommentNotFound = "Comment not found"
-	ErrCommentCreation = "Error creating comment"
-	ErrCommentUpdate   = "Error updating comment"
-	ErrCommentDelete   = "Error deleting comment"
+	ErrCommentNotFound           = "Comment not found"
+	ErrCommentCreation           = "Error creating comment"
+	ErrCommentUpdate             = "Error updating comment"
+	ErrCommentDelete             = "Error deleting comment"
+	ErrMaxPinnedComments         = "Maximum pinned comments reached"
+	ErrAnonymousCommentsDisabled = "Anonymous comments are disabled"
+	ErrCommentNotOwned           = "Comment belongs to another user"
+
+	// Product operations errors
+	ErrProductNotFound = "Product not found"
+	ErrProductCreation = "Error creating product"
+	ErrProductUpdate   = "Error updating product"
+	ErrProductDelete   = "Error deleting product"
 	
 	// Rate limiting errors
 	ErrTooManyRequests   = "Too many requests"
 	ErrRateLimitExceeded = "Rate limit exceeded"
 
 	// General API errors
-	ErrInternalServer   = "Internal Server Error"
-	ErrMethodNotAllowed = "Disallowed method"
-	ErrInvalidRequest   = "Invalid request"
-	ErrUnauthorized     = "Unauthorized"
-	ErrForbidden        = "Prohibited access"
+	ErrInternalServer       = "Internal Server Error"
+	ErrMethodNotAllowed     = "Disallowed method"
+	ErrInvalidRequest       = "Invalid request"
+	ErrUnauthorized         = "Unauthorized"
+	ErrForbidden            = "Prohibited access"
+	ErrUnsupportedMediaType = "Unsupported media type"
+	ErrMissingHeader        = "Missing or invalid required header"
+	ErrRouteNotFound        = "Route not found"
+	ErrRequestBodyTooLarge  = "Request body too large"
+
+	// Static file errors
+	ErrStaticAssetNotFound = "Static asset not found"
+
+	// Geographic filtering errors
+	ErrRegionNotAvailable = "Service not available in your region"
+
+	// Feature flag errors
+	ErrRouteDisabled = "This endpoint is temporarily unavailable"
+
+	// Configuration errors
+	ErrInvalidConfigOverride = "Invalid configuration override"
+	ErrConfigPersist         = "Error saving configuration"
+
+	// SLA reporting errors
+	ErrRequestLogNotFound = "No logged request at this rank"
+	ErrInvalidReportDate  = "date must be formatted as YYYY-MM-DD"
+
+	// Application settings errors
+	ErrSettingNotFound = "Setting not found"
+
+	// Audit errors
+	ErrAuditEntryNotFound         = "Audit entry not found"
+	ErrInvalidImpersonationReason = "reason must be between 10 and 500 characters"
+	ErrInvalidAuditUserID         = "user_id must be a positive integer"
+	ErrInvalidAuditTime           = "from and to must be RFC 3339 timestamps"
 )
@@ -7,6 +7,7 @@
 package errors
 
 import (
+	stdErrors "errors"
 	"fmt"
 	"net/http"
 )
@@ -110,40 +111,86 @@ func NewValidationError(message string) *AppError {
 	}
 }
 
+// NewUnsupportedMediaTypeError creates 415 Unsupported Media Type for request bodies in an unaccepted format
+func NewUnsupportedMediaTypeError(message string) *AppError {
+	return &AppError{
+		Code:    http.StatusUnsupportedMediaType,
+		Message: message,
+	}
+}
+
+// NewPayloadTooLargeError creates 413 Payload Too Large for request bodies over the accepted size
+func NewPayloadTooLargeError(message string) *AppError {
+	return &AppError{
+		Code:    http.StatusRequestEntityTooLarge,
+		Message: message,
+	}
+}
+
 // Error type checkers ---------------------------------------------------------
 
+// hasCode reports whether err is, or wraps, an AppError with the given status code.
+func hasCode(err error, code int) bool {
+	var appErr *AppError
+	return stdErrors.As(err, &appErr) && appErr.Code == code
+}
+
+// IsBadRequest checks if error is 400 Bad Request type
+// Works with both AppError instances and wrapped errors
+func IsBadRequest(err error) bool {
+	return hasCode(err, http.StatusBadRequest)
+}
+
 // IsNotFound checks if error is 404 Not Found type
 // Works with both AppError instances and wrapped errors
 func IsNotFound(err error) bool {
-	if appErr, ok := err.(*AppError); ok {
-		return appErr.Code == http.StatusNotFound
-	}
-	return false
+	return hasCode(err, http.StatusNotFound)
 }
 
 // IsAuthError checks if error is 401 Unauthorized type
 // Useful for differentiating authentication failures
 func IsAuthError(err error) bool {
-	if appErr, ok := err.(*AppError); ok {
-		return appErr.Code == http.StatusUnauthorized
-	}
-	return false
+	return hasCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden checks if error is 403 Forbidden type
+// Distinguishes denied access from missing authentication
+func IsForbidden(err error) bool {
+	return hasCode(err, http.StatusForbidden)
+}
+
+// IsConflict checks if error is 409 Conflict type
+// Identifies requests clashing with the current resource state
+func IsConflict(err error) bool {
+	return hasCode(err, http.StatusConflict)
+}
+
+// IsUnsupportedMediaType checks if error is 415 Unsupported Media Type type
+// Identifies request bodies sent with an unexpected Content-Type
+func IsUnsupportedMediaType(err error) bool {
+	return hasCode(err, http.StatusUnsupportedMediaType)
+}
+
+// IsPayloadTooLarge checks if error is 413 Payload Too Large type
+// Identifies request bodies rejected for their size
+func IsPayloadTooLarge(err error) bool {
+	return hasCode(err, http.StatusRequestEntityTooLarge)
 }
 
 // IsValidationError checks if error is 422 Unprocessable Entity type
 // Identifies validation failures from business logic
 func IsValidationError(err error) bool {
-	if appErr, ok := err.(*AppError); ok {
-		return appErr.Code == http.StatusUnprocessableEntity
-	}
-	return false
+	return hasCode(err, http.StatusUnprocessableEntity)
+}
+
+// IsTooManyRequests checks if error is 429 Too Many Requests type
+// Identifies rate limiting rejections
+func IsTooManyRequests(err error) bool {
+	return hasCode(err, http.StatusTooManyRequests)
 }
 
 // IsInternalError checks if error is 500 Internal Server Error type
 // Helps distinguish system errors from client errors
 func IsInternalError(err error) bool {
-	if appErr, ok := err.(*AppError); ok {
-		return appErr.Code == http.StatusInternalServerError
-	}
-	return false
+	return hasCode(err, http.StatusInternalServerError)
 }
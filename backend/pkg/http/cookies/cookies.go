@@ -95,14 +95,14 @@ func NewCookieConfig(name string, options ...CookieOption) CookieConfig {
 }
 
 // NewAuthCookieConfig creates pre-configured authentication cookie settings:
-// - Name: "token"
+// - Name: Provided cookie name (configured via security.cookie.auth_name, "token" by default)
 // - Value: Provided JWT/access token
 // - MaxAge: 12 hours
 // - HttpOnly: true
 // - SameSite: Lax
 // - Secure: Enabled in production environments
 // Additional options can override these defaults.
-func NewAuthCookieConfig(token string, isProduction bool, options ...CookieOption) CookieConfig {
+func NewAuthCookieConfig(name string, token string, isProduction bool, options ...CookieOption) CookieConfig {
 	defaultOptions := []CookieOption{
 		WithValue(token),
 		WithMaxAge(12 * time.Hour),
@@ -117,7 +117,7 @@ func NewAuthCookieConfig(token string, isProduction bool, options ...CookieOptio
 
 	allOptions := append(defaultOptions, options...)
 
-	return NewCookieConfig("token", allOptions...)
+	return NewCookieConfig(name, allOptions...)
 }
 
 // SetCookie writes a cookie to the HTTP response using configuration.
@@ -145,11 +145,16 @@ func SetCookie(w http.ResponseWriter, config CookieConfig) {
 
 // SetAuthCookie helper combines NewAuthCookieConfig and SetCookie for authentication workflows.
 // Enforces secure settings based on production environment flag.
-func SetAuthCookie(w http.ResponseWriter, token string, isProduction bool, options ...CookieOption) {
-	config := NewAuthCookieConfig(token, isProduction, options...)
+func SetAuthCookie(w http.ResponseWriter, name string, token string, isProduction bool, options ...CookieOption) {
+	config := NewAuthCookieConfig(name, token, isProduction, options...)
 	SetCookie(w, config)
 }
 
+// SetRefreshCookie stores a refresh token with the authentication cookie settings, kept for the token's lifetime ttl.
+func SetRefreshCookie(w http.ResponseWriter, name string, token string, ttl time.Duration, isProduction bool) {
+	SetAuthCookie(w, name, token, isProduction, WithMaxAge(ttl))
+}
+
 // ClearCookie invalidates a cookie by setting empty value and immediate expiration.
 // Uses path "/" to ensure proper invalidation across all paths.
 func ClearCookie(w http.ResponseWriter, name string) {
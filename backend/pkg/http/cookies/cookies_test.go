@@ -197,7 +197,7 @@ func TestNewAuthCookieConfig(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			config := cookies.NewAuthCookieConfig(tc.token, tc.isProduction, tc.options...)
+			config := cookies.NewAuthCookieConfig("token", tc.token, tc.isProduction, tc.options...)
 
 			if config.Name != "token" {
 				t.Errorf("Incorrect name. Expected: %s, Got: %s", "token", config.Name)
@@ -224,6 +224,17 @@ func TestNewAuthCookieConfig(t *testing.T) {
 	}
 }
 
+func TestNewAuthCookieConfigCustomName(t *testing.T) {
+	config := cookies.NewAuthCookieConfig("store_session", "test-token", false)
+
+	if config.Name != "store_session" {
+		t.Errorf("Incorrect name. Expected: %s, Got: %s", "store_session", config.Name)
+	}
+	if config.Value != "test-token" {
+		t.Errorf("Incorrect value. Expected: %s, Got: %s", "test-token", config.Value)
+	}
+}
+
 func TestSetCookie(t *testing.T) {
 	w := httptest.NewRecorder()
 
@@ -305,7 +316,7 @@ func TestSetAuthCookie(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 
-			cookies.SetAuthCookie(w, tc.token, tc.isProduction, tc.options...)
+			cookies.SetAuthCookie(w, "token", tc.token, tc.isProduction, tc.options...)
 
 			cookies := w.Result().Cookies()
 			if len(cookies) != 1 {
@@ -365,7 +376,7 @@ func TestClearCookie(t *testing.T) {
 func ExampleSetAuthCookie() {
 	w := httptest.NewRecorder()
 
-	cookies.SetAuthCookie(w, "user-token", false)
+	cookies.SetAuthCookie(w, "token", "user-token", false)
 }
 
 func ExampleNewCookieConfig_userPreferences() {
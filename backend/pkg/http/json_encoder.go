@@ -0,0 +1,44 @@
+// Package http provides response handling utilities for HTTP APIs.
+// This file contains the JSONEncoder used to render JSON response bodies, which can be switched to indented output for development.
+package http
+
+import "encoding/json"
+
+// JSONEncoder renders a value as a JSON response body.
+type JSONEncoder interface {
+	// Encode returns the JSON encoding of v, terminated by a newline.
+	Encode(v interface{}) ([]byte, error)
+}
+
+// CompactJSONEncoder renders JSON without insignificant whitespace. It is the default encoder.
+type CompactJSONEncoder struct{}
+
+// Encode returns the compact JSON encoding of v followed by a newline.
+func (CompactJSONEncoder) Encode(v interface{}) ([]byte, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(body, '\n'), nil
+}
+
+// PrettyJSONEncoder renders JSON indented with two spaces, for readable responses during development.
+type PrettyJSONEncoder struct{}
+
+// Encode returns the indented JSON encoding of v followed by a newline.
+func (PrettyJSONEncoder) Encode(v interface{}) ([]byte, error) {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(body, '\n'), nil
+}
+
+// defaultJSONEncoder renders the bodies written by SendJSONResponse and WriteError.
+var defaultJSONEncoder JSONEncoder = CompactJSONEncoder{}
+
+// SetDefaultJSONEncoder configures the package-level JSONEncoder.
+// It should be called once at application startup, before any response is written.
+func SetDefaultJSONEncoder(encoder JSONEncoder) {
+	defaultJSONEncoder = encoder
+}
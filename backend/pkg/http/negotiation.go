@@ -0,0 +1,116 @@
+// Package http provides response handling utilities for HTTP APIs.
+// This file contains content negotiation helpers: the preferred response media type is resolved from the Accept header, stored in the request context and honoured by SendResponse.
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Media types supported by SendResponse.
+const (
+	MediaTypeJSON      = "application/json"
+	MediaTypeCSV       = "text/csv"
+	MediaTypePlainText = "text/plain"
+)
+
+// mediaTypeContextKey is the unexported key under which the negotiated media type is stored in the request context.
+type mediaTypeContextKey struct{}
+
+// CSVFormatter is implemented by response payloads that can be rendered as a table.
+// Payloads that do not implement it are always sent as JSON, whatever the client asked for.
+type CSVFormatter interface {
+	// CSVHeader returns the column names.
+	CSVHeader() []string
+	// CSVRows returns one slice of cell values per row, in the same order as CSVHeader.
+	CSVRows() [][]string
+}
+
+// WithMediaType returns a copy of ctx carrying the negotiated response media type.
+func WithMediaType(ctx context.Context, mediaType string) context.Context {
+	return context.WithValue(ctx, mediaTypeContextKey{}, mediaType)
+}
+
+// MediaTypeFromContext returns the negotiated media type, or MediaTypeJSON if none was stored.
+func MediaTypeFromContext(ctx context.Context) string {
+	if mediaType, ok := ctx.Value(mediaTypeContextKey{}).(string); ok && mediaType != "" {
+		return mediaType
+	}
+	return MediaTypeJSON
+}
+
+// NegotiateMediaType picks the supported media type with the highest quality value in an Accept header.
+// An empty header, wildcards and unsupported types resolve to MediaTypeJSON. Ties keep the order in which the client listed the types.
+func NegotiateMediaType(accept string) string {
+	type candidate struct {
+		mediaType string
+		quality   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.TrimSpace(name) == "q" {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		switch mediaType {
+		case MediaTypeJSON, MediaTypeCSV, MediaTypePlainText:
+			if quality > 0 {
+				candidates = append(candidates, candidate{mediaType, quality})
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return MediaTypeJSON
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].mediaType
+}
+
+// SendResponse sends data in the media type negotiated for the request.
+
+// CSV and plain-text tables are only produced when data implements CSVFormatter; otherwise, and for JSON requests, it falls back to SendJSONResponse so the Content-Type header always matches the body.
+func SendResponse(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) {
+	formatter, isTabular := data.(CSVFormatter)
+	if !isTabular {
+		SendJSONResponse(w, statusCode, data)
+		return
+	}
+
+	switch MediaTypeFromContext(r.Context()) {
+	case MediaTypeCSV:
+		w.Header().Set("Content-Type", MediaTypeCSV+"; charset=utf-8")
+		w.WriteHeader(statusCode)
+		writer := csv.NewWriter(w)
+		writer.Write(formatter.CSVHeader())
+		writer.WriteAll(formatter.CSVRows())
+	case MediaTypePlainText:
+		w.Header().Set("Content-Type", MediaTypePlainText+"; charset=utf-8")
+		w.WriteHeader(statusCode)
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.Join(formatter.CSVHeader(), "\t"))
+		for _, row := range formatter.CSVRows() {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+		writer.Flush()
+	default:
+		SendJSONResponse(w, statusCode, data)
+	}
+}
@@ -0,0 +1,67 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+type table struct{}
+
+func (table) CSVHeader() []string { return []string{"ID", "Name"} }
+func (table) CSVRows() [][]string { return [][]string{{"1", "Rolex"}} }
+
+func TestNegotiateMediaType(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected string
+	}{
+		{"", httpUtil.MediaTypeJSON},
+		{"*/*", httpUtil.MediaTypeJSON},
+		{"text/csv", httpUtil.MediaTypeCSV},
+		{"text/plain;q=0.5, text/csv;q=0.9", httpUtil.MediaTypeCSV},
+		{"application/xml, text/plain", httpUtil.MediaTypePlainText},
+		{"text/csv;q=0", httpUtil.MediaTypeJSON},
+	}
+
+	for _, tt := range tests {
+		if got := httpUtil.NegotiateMediaType(tt.accept); got != tt.expected {
+			t.Errorf("NegotiateMediaType(%q) Expected: %v, Got: %v", tt.accept, tt.expected, got)
+		}
+	}
+}
+
+func TestSendResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		mediaType   string
+		data        interface{}
+		contentType string
+		body        string
+	}{
+		{"csv", httpUtil.MediaTypeCSV, table{}, "text/csv; charset=utf-8", "ID,Name\n1,Rolex\n"},
+		{"plain text", httpUtil.MediaTypePlainText, table{}, "text/plain; charset=utf-8", "ID  Name\n1   Rolex\n"},
+		{"json", httpUtil.MediaTypeJSON, map[string]int{"id": 1}, "application/json", "{\"id\":1}\n"},
+		{"csv fallback to json", httpUtil.MediaTypeCSV, map[string]int{"id": 1}, "application/json", "{\"id\":1}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r = r.WithContext(httpUtil.WithMediaType(context.Background(), tt.mediaType))
+			w := httptest.NewRecorder()
+
+			httpUtil.SendResponse(w, r, http.StatusOK, tt.data)
+
+			if got := w.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type Expected: %v, Got: %v", tt.contentType, got)
+			}
+			if got := w.Body.String(); got != tt.body {
+				t.Errorf("Body Expected: %q, Got: %q", tt.body, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Package http provides response handling utilities for HTTP APIs.
+// This file contains the pagination helpers shared by list endpoints: query parameter parsing, the {"items", "meta"} response envelope and RFC 5988 Link headers.
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// Query parameters read by ParsePageParams and written into Link header URLs.
+// PageSizeParam is accepted as an alias of PerPageParam, which takes precedence when both are present.
+const (
+	PageParam     = "page"
+	PerPageParam  = "per_page"
+	PageSizeParam = "page_size"
+)
+
+// PageMeta is the pagination metadata sent alongside the items of every paginated response.
+type PageMeta struct {
+	Total      int `json:"total"`
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	TotalPages int `json:"total_pages"`
+}
+
+// pageEnvelope is the JSON body of a paginated response: {"items": [...], "meta": {...}}.
+type pageEnvelope[T any] struct {
+	Items []T      `json:"items"`
+	Meta  PageMeta `json:"meta"`
+}
+
+// ParsePageParams reads the page and per_page (or page_size) query parameters.
+
+// Missing parameters default to page 1 and defaultPerPage. It returns a ValidationError when page is not a positive integer or the page size is not between 1 and maxPerPage.
+func ParsePageParams(r *http.Request, defaultPerPage, maxPerPage int) (int, int, error) {
+	query := r.URL.Query()
+
+	page := 1
+	if raw := query.Get(PageParam); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 {
+			return 0, 0, errors.NewValidationError("page must be a positive integer")
+		}
+		page = value
+	}
+
+	perPage := defaultPerPage
+	perPageParam := PerPageParam
+	if !query.Has(PerPageParam) && query.Has(PageSizeParam) {
+		perPageParam = PageSizeParam
+	}
+	if raw := query.Get(perPageParam); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 || value > maxPerPage {
+			return 0, 0, errors.NewValidationError(fmt.Sprintf("%s must be between 1 and %d", perPageParam, maxPerPage))
+		}
+		perPage = value
+	}
+
+	return page, perPage, nil
+}
+
+// NewPageMeta extracts the metadata of a page.
+func NewPageMeta[T any](page models.Page[T]) PageMeta {
+	return PageMeta{
+		Total:      page.Total,
+		Page:       page.Page,
+		PerPage:    page.PerPage,
+		TotalPages: page.TotalPages,
+	}
+}
+
+// SendPageResponse sends a page as {"items": [...], "meta": {"total", "page", "per_page", "total_pages"}} with a 200 status and a Link header pointing to the first, previous, next and last pages.
+func SendPageResponse[T any](w http.ResponseWriter, r *http.Request, page models.Page[T]) {
+	meta := NewPageMeta(page)
+	SetPageLinkHeader(w, r, meta)
+	SendJSONResponse(w, http.StatusOK, pageEnvelope[T]{Items: page.Items, Meta: meta})
+}
+
+// SetPageLinkHeader sets an RFC 5988 Link header with rel="first", "prev", "next" and "last" URLs.
+// The URLs reuse the request path and query string with the page parameter replaced; prev and next are omitted on the first and last page.
+func SetPageLinkHeader(w http.ResponseWriter, r *http.Request, meta PageMeta) {
+	if meta.TotalPages == 0 {
+		return
+	}
+
+	links := []string{pageLink(r, 1, meta.PerPage, "first")}
+	if meta.Page > 1 {
+		links = append(links, pageLink(r, min(meta.Page-1, meta.TotalPages), meta.PerPage, "prev"))
+	}
+	if meta.Page < meta.TotalPages {
+		links = append(links, pageLink(r, meta.Page+1, meta.PerPage, "next"))
+	}
+	links = append(links, pageLink(r, meta.TotalPages, meta.PerPage, "last"))
+
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
+
+// pageLink formats a single Link header entry for the given page of the current request URL.
+func pageLink(r *http.Request, page, perPage int, rel string) string {
+	target := *r.URL
+	query := target.Query()
+	query.Set(PageParam, strconv.Itoa(page))
+	query.Set(PerPageParam, strconv.Itoa(perPage))
+	target.RawQuery = query.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, target.RequestURI(), rel)
+}
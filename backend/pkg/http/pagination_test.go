@@ -0,0 +1,86 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+func TestParsePageParams(t *testing.T) {
+	tests := []struct {
+		query       string
+		page        int
+		perPage     int
+		expectError bool
+	}{
+		{"", 1, 20, false},
+		{"?page=3&per_page=50", 3, 50, false},
+		{"?page=0", 0, 0, true},
+		{"?page=abc", 0, 0, true},
+		{"?per_page=101", 0, 0, true},
+		{"?per_page=0", 0, 0, true},
+		{"?page=2&page_size=5", 2, 5, false},
+		{"?page_size=101", 0, 0, true},
+		{"?per_page=10&page_size=5", 1, 10, false},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/comments"+tt.query, nil)
+		page, perPage, err := httpUtil.ParsePageParams(req, 20, 100)
+		if (err != nil) != tt.expectError {
+			t.Errorf("%q: Expected error=%v, Got %v", tt.query, tt.expectError, err)
+			continue
+		}
+		if !tt.expectError && (page != tt.page || perPage != tt.perPage) {
+			t.Errorf("%q: Expected page=%d per_page=%d, Got page=%d per_page=%d", tt.query, tt.page, tt.perPage, page, perPage)
+		}
+	}
+}
+
+func TestSendPageResponse(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/comments?page=2&per_page=10&sort=date", nil)
+	rec := httptest.NewRecorder()
+
+	httpUtil.SendPageResponse(rec, req, models.NewPage([]string{"a", "b"}, 25, 2, 10))
+
+	var body struct {
+		Items []string          `json:"items"`
+		Meta  httpUtil.PageMeta `json:"meta"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Expected a JSON body, Got error: %v", err)
+	}
+	if len(body.Items) != 2 || body.Meta.Total != 25 || body.Meta.TotalPages != 3 || body.Meta.Page != 2 || body.Meta.PerPage != 10 {
+		t.Errorf("Unexpected envelope: %+v", body)
+	}
+
+	expectedLink := `</comments?page=1&per_page=10&sort=date>; rel="first", ` +
+		`</comments?page=1&per_page=10&sort=date>; rel="prev", ` +
+		`</comments?page=3&per_page=10&sort=date>; rel="next", ` +
+		`</comments?page=3&per_page=10&sort=date>; rel="last"`
+	if got := rec.Header().Get("Link"); got != expectedLink {
+		t.Errorf("Expected Link %q, Got %q", expectedLink, got)
+	}
+}
+
+func TestSendPageResponseEmptyList(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/comments", nil)
+	rec := httptest.NewRecorder()
+
+	httpUtil.SendPageResponse(rec, req, models.NewPage[string](nil, 0, 1, 20))
+
+	if rec.Header().Get("Link") != "" {
+		t.Errorf("Expected no Link header for an empty list, Got %q", rec.Header().Get("Link"))
+	}
+	var body struct {
+		Items []string `json:"items"`
+	}
+	json.NewDecoder(rec.Body).Decode(&body)
+	if body.Items == nil {
+		t.Error("Expected items to encode as an empty array")
+	}
+}
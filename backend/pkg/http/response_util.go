@@ -3,28 +3,105 @@
 package http
 
 import (
-	"encoding/json"
+	stdErrors "errors"
 	"net/http"
 
 	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
 )
 
 // SendJSONResponse sends a JSON-encoded response with proper headers and status code.
-// Sets the Content-Type header to "application/json" and encodes the provided data.
-// Note: If JSON encoding fails, this will send a partial response header. Consider using error handling middleware to catch encoding errors.
+// Sets the Content-Type header to "application/json" and encodes the provided data with the default JSONEncoder (see SetDefaultJSONEncoder).
+// The data is encoded before the header is written, so an encoding failure is answered with an empty 500 Internal Server Error instead of a partial body.
 func SendJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	body, err := defaultJSONEncoder.Encode(data)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(data)
+	w.Write(body)
+}
+
+// ErrorResponse is the JSON body of every error response: {"error": "<message>"}.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// ProblemDetail is the RFC 9457 (formerly RFC 7807) problem details body written by WriteError.
+type ProblemDetail struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// ProblemContentType is the media type of a ProblemDetail body.
+const ProblemContentType = "application/problem+json"
+
+// ErrorStatusCode maps an error to its HTTP status code using the errors.Is* checkers.
+// Wrapped AppErrors are recognized; any other error, or an AppError with an unmapped code, yields 500 Internal Server Error.
+func ErrorStatusCode(err error) int {
+	switch {
+	case errors.IsBadRequest(err):
+		return http.StatusBadRequest
+	case errors.IsAuthError(err):
+		return http.StatusUnauthorized
+	case errors.IsForbidden(err):
+		return http.StatusForbidden
+	case errors.IsNotFound(err):
+		return http.StatusNotFound
+	case errors.IsConflict(err):
+		return http.StatusConflict
+	case errors.IsUnsupportedMediaType(err):
+		return http.StatusUnsupportedMediaType
+	case errors.IsPayloadTooLarge(err):
+		return http.StatusRequestEntityTooLarge
+	case errors.IsValidationError(err):
+		return http.StatusUnprocessableEntity
+	case errors.IsTooManyRequests(err):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// WriteError sends err as an application/problem+json response with the status code from ErrorStatusCode.
+// The detail is the AppError message; for 500 responses and errors that are not AppErrors a generic message is sent instead, so internal details are never exposed.
+// Handlers use WriteError; HandleError remains for middleware responses.
+func WriteError(w http.ResponseWriter, err error) {
+	status := ErrorStatusCode(err)
+
+	detail := errors.ErrInternalServer
+	var appErr *errors.AppError
+	if status != http.StatusInternalServerError && stdErrors.As(err, &appErr) {
+		detail = appErr.Message
+	}
+
+	// A ProblemDetail only holds strings and an int, so encoding cannot fail.
+	body, _ := defaultJSONEncoder.Encode(ProblemDetail{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	})
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	w.Write(body)
 }
 
 // HandleError processes application errors and sends appropriate HTTP responses.
-// Recognizes errors of type *errors.AppError to send structured responses with proper status codes and messages. Falls back to 500 Internal Server Error for unexpected error types.
+// Recognizes errors of type *errors.AppError to send a JSON ErrorResponse with the proper status code and message. Falls back to 500 Internal Server Error for unexpected error types, without exposing their details.
 // Usage note: Should typically be used as the final error handler in request chains.
 func HandleError(w http.ResponseWriter, err error) {
-	if appErr, ok := err.(*errors.AppError); ok {
-		http.Error(w, appErr.Message, appErr.Code)
-	} else {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewInternalError(errors.ErrInternalServer)
 	}
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	SendJSONResponse(w, appErr.Code, ErrorResponse{Error: appErr.Message})
 }
@@ -0,0 +1,117 @@
+package http_test
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	httpUtil "github.com/David-Alejandro-Jimenez/sale-watches/pkg/http"
+)
+
+func TestHandleErrorSendsJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		body   string
+	}{
+		{"app error", errors.NewNotFoundError(errors.ErrStaticAssetNotFound), http.StatusNotFound, `{"error":"Static asset not found"}` + "\n"},
+		{"unexpected error", stdErrors.New("connection reset"), http.StatusInternalServerError, `{"error":"Internal Server Error"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			httpUtil.HandleError(rec, tt.err)
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, Got %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Expected JSON content type, Got %q", got)
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("Expected body %q, Got %q", tt.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"bad request", errors.NewBadRequestError(errors.ErrInvalidRequest), http.StatusBadRequest},
+		{"auth", errors.NewAuthError(errors.ErrUnauthorized), http.StatusUnauthorized},
+		{"forbidden", errors.NewForbiddenError(errors.ErrForbidden), http.StatusForbidden},
+		{"not found", errors.NewNotFoundError(errors.ErrCommentNotFound), http.StatusNotFound},
+		{"conflict", errors.NewConflictError(errors.ErrUserAlreadyExists), http.StatusConflict},
+		{"unsupported media type", errors.NewUnsupportedMediaTypeError(errors.ErrUnsupportedMediaType), http.StatusUnsupportedMediaType},
+		{"validation", errors.NewValidationError(errors.ErrInvalidFormat), http.StatusUnprocessableEntity},
+		{"too many requests", errors.NewTooManyRequestsError(errors.ErrTooManyRequests), http.StatusTooManyRequests},
+		{"internal", errors.NewInternalError(errors.ErrDatabaseQuery), http.StatusInternalServerError},
+		{"wrapped app error", fmt.Errorf("loading comment: %w", errors.NewNotFoundError(errors.ErrCommentNotFound)), http.StatusNotFound},
+		{"unexpected error", stdErrors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := httpUtil.ErrorStatusCode(tt.err); got != tt.status {
+				t.Errorf("Expected status %d, Got %d", tt.status, got)
+			}
+		})
+	}
+}
+
+func TestWriteErrorSendsProblemDetail(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		body   string
+	}{
+		{"app error", errors.NewConflictError(errors.ErrUserAlreadyExists), http.StatusConflict, `{"type":"about:blank","title":"Conflict","status":409,"detail":"The user already exists"}` + "\n"},
+		{"internal error hides message", errors.NewInternalError(errors.ErrDatabaseQuery), http.StatusInternalServerError, `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal Server Error"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			httpUtil.WriteError(rec, tt.err)
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, Got %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Content-Type"); got != httpUtil.ProblemContentType {
+				t.Errorf("Expected problem content type, Got %q", got)
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("Expected body %q, Got %q", tt.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSendJSONResponseUsesDefaultEncoder(t *testing.T) {
+	data := map[string]string{"message": "Comment added"}
+
+	rec := httptest.NewRecorder()
+	httpUtil.SendJSONResponse(rec, http.StatusOK, data)
+	if want := `{"message":"Comment added"}` + "\n"; rec.Body.String() != want {
+		t.Errorf("Expected compact body %q, Got %q", want, rec.Body.String())
+	}
+
+	httpUtil.SetDefaultJSONEncoder(httpUtil.PrettyJSONEncoder{})
+	defer httpUtil.SetDefaultJSONEncoder(httpUtil.CompactJSONEncoder{})
+
+	rec = httptest.NewRecorder()
+	httpUtil.SendJSONResponse(rec, http.StatusOK, data)
+	if want := "{\n  \"message\": \"Comment added\"\n}\n"; rec.Body.String() != want {
+		t.Errorf("Expected indented body %q, Got %q", want, rec.Body.String())
+	}
+}
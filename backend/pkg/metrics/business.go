@@ -0,0 +1,82 @@
+// Package metrics provides counters for business events and the health indicators of the admin dashboard.
+// This file contains BusinessMetrics, which counts registrations, logins and added comments by outcome as Prometheus counters.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Values of the status label under which each counter is incremented.
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+// statusLabel is the label distinguishing outcomes in every business counter.
+const statusLabel = "status"
+
+// BusinessMetrics counts business events by outcome, each in a counter with a status label of StatusSuccess or StatusFailure.
+//
+// A nil *BusinessMetrics is valid and records nothing, so services can be built without metrics in tests.
+type BusinessMetrics struct {
+	UserRegistrations *prometheus.CounterVec // user_registrations_total
+	UserLogins        *prometheus.CounterVec // user_logins_total
+	CommentsAdded     *prometheus.CounterVec // comments_added_total
+}
+
+// NewBusinessMetrics creates a BusinessMetrics with all counters at zero.
+// The counters are not exported until Register is called.
+func NewBusinessMetrics() *BusinessMetrics {
+	return &BusinessMetrics{
+		UserRegistrations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "user_registrations_total",
+			Help: "Registration attempts by outcome.",
+		}, []string{statusLabel}),
+		UserLogins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "user_logins_total",
+			Help: "Login attempts by outcome.",
+		}, []string{statusLabel}),
+		CommentsAdded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "comments_added_total",
+			Help: "Attempts to add a comment by outcome.",
+		}, []string{statusLabel}),
+	}
+}
+
+// Register adds the counters to registerer, normally Registry.
+// It fails if counters with the same names are already registered there.
+func (m *BusinessMetrics) Register(registerer prometheus.Registerer) error {
+	for _, counter := range []prometheus.Collector{m.UserRegistrations, m.UserLogins, m.CommentsAdded} {
+		if err := registerer.Register(counter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordRegistration counts a registration attempt, as a failure when err is non-nil.
+func (m *BusinessMetrics) RecordRegistration(err error) {
+	if m != nil {
+		m.UserRegistrations.WithLabelValues(status(err)).Inc()
+	}
+}
+
+// RecordLogin counts a login attempt, as a failure when err is non-nil.
+func (m *BusinessMetrics) RecordLogin(err error) {
+	if m != nil {
+		m.UserLogins.WithLabelValues(status(err)).Inc()
+	}
+}
+
+// RecordCommentAdded counts an attempt to add a comment, as a failure when err is non-nil.
+func (m *BusinessMetrics) RecordCommentAdded(err error) {
+	if m != nil {
+		m.CommentsAdded.WithLabelValues(status(err)).Inc()
+	}
+}
+
+// status returns the status label value for err.
+func status(err error) string {
+	if err != nil {
+		return StatusFailure
+	}
+	return StatusSuccess
+}
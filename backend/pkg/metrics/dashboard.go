@@ -0,0 +1,115 @@
+// Package metrics provides counters for business events and the health indicators of the admin dashboard.
+// This file contains DashboardCollector, which gathers the health indicators reported by the admin dashboard.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// DashboardTimeout bounds how long DashboardCollector.Collect waits for all metrics.
+const DashboardTimeout = 5 * time.Second
+
+// DashboardMetric is one health indicator of the admin dashboard.
+type DashboardMetric interface {
+	// Collect returns the current value of the indicator, which must be JSON encodable.
+	// It should return early with ctx.Err() once ctx is done.
+	Collect(ctx context.Context) (interface{}, error)
+}
+
+// DashboardMetricFunc adapts an ordinary function to the DashboardMetric interface.
+type DashboardMetricFunc func(ctx context.Context) (interface{}, error)
+
+// Collect calls f(ctx).
+func (f DashboardMetricFunc) Collect(ctx context.Context) (interface{}, error) {
+	return f(ctx)
+}
+
+// dashboardEntry is a DashboardMetric together with the JSON field it is reported under.
+type dashboardEntry struct {
+	name   string
+	metric DashboardMetric
+}
+
+// DashboardCollector holds the metrics of the admin dashboard, in registration order.
+type DashboardCollector struct {
+	entries []dashboardEntry
+}
+
+// NewDashboardCollector creates an empty DashboardCollector.
+func NewDashboardCollector() *DashboardCollector {
+	return &DashboardCollector{}
+}
+
+// Register adds metric to the dashboard under name. It returns the collector so registrations can be chained.
+func (c *DashboardCollector) Register(name string, metric DashboardMetric) *DashboardCollector {
+	c.entries = append(c.entries, dashboardEntry{name: name, metric: metric})
+	return c
+}
+
+// DashboardReport is the result of DashboardCollector.Collect.
+// It is encoded as one JSON field per metric, null for failed metrics, plus an "errors" object describing each failure.
+type DashboardReport struct {
+	Values map[string]interface{}
+	Errors map[string]string
+}
+
+// MarshalJSON flattens Values into the top-level object and adds Errors under "errors" when there are any.
+func (r DashboardReport) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(r.Values)+1)
+	for name, value := range r.Values {
+		fields[name] = value
+	}
+	if len(r.Errors) > 0 {
+		fields["errors"] = r.Errors
+	}
+	return json.Marshal(fields)
+}
+
+// dashboardResult is the outcome of one metric, sent back to Collect by its goroutine.
+type dashboardResult struct {
+	name  string
+	value interface{}
+	err   error
+}
+
+// Collect runs every registered metric concurrently and waits for them for at most DashboardTimeout.
+// A metric that fails or does not finish in time is reported as null, with its error in DashboardReport.Errors.
+func (c *DashboardCollector) Collect(ctx context.Context) DashboardReport {
+	ctx, cancel := context.WithTimeout(ctx, DashboardTimeout)
+	defer cancel()
+
+	// The channel holds every result, so metrics finishing after the timeout never block.
+	results := make(chan dashboardResult, len(c.entries))
+	for _, entry := range c.entries {
+		go func(entry dashboardEntry) {
+			value, err := entry.metric.Collect(ctx)
+			results <- dashboardResult{name: entry.name, value: value, err: err}
+		}(entry)
+	}
+
+	report := DashboardReport{
+		Values: make(map[string]interface{}, len(c.entries)),
+		Errors: make(map[string]string),
+	}
+	for _, entry := range c.entries {
+		report.Values[entry.name] = nil
+		report.Errors[entry.name] = context.DeadlineExceeded.Error()
+	}
+
+	for received := 0; received < len(c.entries); received++ {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				report.Errors[result.name] = result.err.Error()
+				continue
+			}
+			report.Values[result.name] = result.value
+			delete(report.Errors, result.name)
+		case <-ctx.Done():
+			return report
+		}
+	}
+	return report
+}
@@ -0,0 +1,30 @@
+package metrics_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/metrics"
+)
+
+func TestDashboardCollectorReportsFailuresAsNull(t *testing.T) {
+	collector := metrics.NewDashboardCollector().
+		Register("rate_limiter_ips", metrics.DashboardMetricFunc(func(ctx context.Context) (interface{}, error) {
+			return 3, nil
+		})).
+		Register("db_connections", metrics.DashboardMetricFunc(func(ctx context.Context) (interface{}, error) {
+			return nil, stdErrors.New("database unavailable")
+		}))
+
+	body, err := json.Marshal(collector.Collect(context.Background()))
+	if err != nil {
+		t.Fatalf("Encoding the report failed: %v", err)
+	}
+
+	want := `{"db_connections":null,"errors":{"db_connections":"database unavailable"},"rate_limiter_ips":3}`
+	if string(body) != want {
+		t.Errorf("Expected %s, Got %s", want, body)
+	}
+}
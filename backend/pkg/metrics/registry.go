@@ -0,0 +1,22 @@
+// Package metrics provides counters for business events and the health indicators of the admin dashboard.
+// This file contains Registry, the Prometheus registry served on GET /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Registry is the Prometheus registry of the application, served on GET /metrics.
+// It starts with the Go runtime and process collectors; application metrics such as BusinessMetrics are registered on it at startup.
+var Registry = newRegistry()
+
+// newRegistry creates a registry holding the Go runtime and process collectors.
+func newRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
@@ -0,0 +1,68 @@
+// Package pkce implements Proof Key for Code Exchange (RFC 7636) with the S256 challenge method.
+// It generates code verifiers, derives their code challenges and verifies a verifier against a stored challenge, as needed by an OAuth2 authorization code flow.
+package pkce
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+)
+
+// Code verifier length bounds from RFC 7636 section 4.1.
+const (
+	MinVerifierLength = 43
+	MaxVerifierLength = 128
+)
+
+// verifierEntropyBytes is the number of random octets encoded into a verifier; 32 octets give the minimum length of 43 characters.
+const verifierEntropyBytes = 32
+
+// GenerateCodeVerifier returns a new high-entropy code verifier: 32 random octets base64url-encoded without padding (43 characters).
+func GenerateCodeVerifier() (string, error) {
+	b := make([]byte, verifierEntropyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating code verifier: %w", err)
+	}
+	return encode(b), nil
+}
+
+// GenerateCodeChallenge derives the S256 code challenge of a verifier: BASE64URL(SHA256(verifier)) without padding.
+func GenerateCodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return encode(sum[:])
+}
+
+// ValidateCodeVerifier checks that verifier has 43 to 128 characters drawn from the unreserved set [A-Z] [a-z] [0-9] "-" "." "_" "~".
+func ValidateCodeVerifier(verifier string) error {
+	if len(verifier) < MinVerifierLength || len(verifier) > MaxVerifierLength {
+		return fmt.Errorf("code verifier must be between %d and %d characters, got %d", MinVerifierLength, MaxVerifierLength, len(verifier))
+	}
+	for _, c := range verifier {
+		if !isUnreserved(c) {
+			return fmt.Errorf("code verifier contains invalid character %q", c)
+		}
+	}
+	return nil
+}
+
+// VerifyCodeChallenge reports whether verifier is well formed and its S256 challenge equals challenge.
+// The comparison runs in constant time.
+func VerifyCodeChallenge(verifier, challenge string) bool {
+	if ValidateCodeVerifier(verifier) != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(GenerateCodeChallenge(verifier)), []byte(challenge)) == 1
+}
+
+// encode base64url-encodes b without padding.
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// isUnreserved reports whether c belongs to the unreserved character set allowed in code verifiers.
+func isUnreserved(c rune) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
@@ -0,0 +1,88 @@
+package pkce
+
+import (
+	"strings"
+	"testing"
+)
+
+// RFC 7636 Appendix B test vector.
+var (
+	rfcVerifierOctets = []byte{
+		116, 24, 223, 180, 151, 153, 224, 37, 79, 250, 96, 125, 216, 173,
+		187, 186, 22, 212, 37, 77, 105, 214, 191, 240, 91, 88, 5, 88, 83,
+		132, 141, 121,
+	}
+	rfcVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	rfcChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func TestRFC7636VerifierEncoding(t *testing.T) {
+	if got := encode(rfcVerifierOctets); got != rfcVerifier {
+		t.Errorf("Expected verifier %q, Got %q", rfcVerifier, got)
+	}
+}
+
+func TestRFC7636CodeChallenge(t *testing.T) {
+	if got := GenerateCodeChallenge(rfcVerifier); got != rfcChallenge {
+		t.Errorf("Expected challenge %q, Got %q", rfcChallenge, got)
+	}
+}
+
+func TestGenerateCodeVerifier(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		verifier, err := GenerateCodeVerifier()
+		if err != nil {
+			t.Fatalf("Expected no error, Got: %v", err)
+		}
+		if err := ValidateCodeVerifier(verifier); err != nil {
+			t.Errorf("Expected a valid verifier, Got %q: %v", verifier, err)
+		}
+		if strings.Contains(verifier, "=") {
+			t.Errorf("Expected no padding, Got %q", verifier)
+		}
+		if seen[verifier] {
+			t.Errorf("Expected unique verifiers, Got duplicate %q", verifier)
+		}
+		seen[verifier] = true
+	}
+}
+
+func TestValidateCodeVerifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier string
+		valid    bool
+	}{
+		{"rfc example", rfcVerifier, true},
+		{"minimum length", strings.Repeat("a", MinVerifierLength), true},
+		{"maximum length", strings.Repeat("a", MaxVerifierLength), true},
+		{"all unreserved characters", "ABCXYZabcxyz0189-._~" + strings.Repeat("a", 23), true},
+		{"too short", strings.Repeat("a", MinVerifierLength-1), false},
+		{"too long", strings.Repeat("a", MaxVerifierLength+1), false},
+		{"padding", rfcVerifier + "=", false},
+		{"plus sign", strings.Repeat("a", 42) + "+", false},
+		{"slash", strings.Repeat("a", 42) + "/", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCodeVerifier(tt.verifier); (err == nil) != tt.valid {
+				t.Errorf("Expected valid=%v, Got error: %v", tt.valid, err)
+			}
+		})
+	}
+}
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	if !VerifyCodeChallenge(rfcVerifier, rfcChallenge) {
+		t.Error("Expected the RFC verifier to match its challenge")
+	}
+	if VerifyCodeChallenge(rfcVerifier, GenerateCodeChallenge(strings.Repeat("a", 43))) {
+		t.Error("Expected a mismatched challenge to be rejected")
+	}
+	if VerifyCodeChallenge("short", GenerateCodeChallenge("short")) {
+		t.Error("Expected a malformed verifier to be rejected even if the challenge matches")
+	}
+}
@@ -2,7 +2,10 @@
 // It implements a maintenance service that periodically purges expired rate limiting records to prevent memory leaks and optimize storage usage.
 package ratelimiter
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // RateLimiterCleaner manages background cleanup of inactive rate limiter entries.
 // Maintains a reference to a RateLimiterManager to perform periodic cleanup operations.
@@ -15,24 +18,34 @@ type RateLimiterCleaner struct {
 // Requires a RateLimiterManager implementation that provides the CleanupInactiveLimiters method.
 // Typical usage:
 //   cleaner := NewRateLimiterCleaner(redisManager)
-//   cleaner.Start(10*time.Minute, 1*time.Minute)
+//   ctx, cancel := context.WithCancel(context.Background())
+//   cleaner.Start(ctx, 10*time.Minute, 1*time.Minute)
+//   defer cancel()
 func NewRateLimiterCleaner(manager RateLimiterManager) *RateLimiterCleaner {
-	return &RateLimiterCleaner{manager: manager}
+	return &RateLimiterCleaner{
+		manager: manager,
+	}
 }
 
 // Start begins the background cleanup goroutine with specified intervals.
 // Parameters:
+//   ctx - Cancelling it stops the goroutine and releases its ticker
 //   expirationDuration - Time since last access after which limiters are considered inactive
 //   cleanupInterval - Frequency between cleanup cycles
 
-// The cleanup runs indefinitely until application shutdown.
-// Example: Start(15*time.Minute, 5*time.Minute) cleans every 5 minutes, removing limiters inactive for 15+ minutes
-func (c *RateLimiterCleaner) Start(expirationDuration, cleanupInterval time.Duration) {
+// The cleanup runs until ctx is cancelled.
+// Example: Start(ctx, 15*time.Minute, 5*time.Minute) cleans every 5 minutes, removing limiters inactive for 15+ minutes
+func (c *RateLimiterCleaner) Start(ctx context.Context, expirationDuration, cleanupInterval time.Duration) {
 	go func() {
 		ticker := time.NewTicker(cleanupInterval)
 		defer ticker.Stop()
-		for range ticker.C {
-			c.manager.CleanupInactiveLimiters(expirationDuration)
+		for {
+			select {
+			case <-ticker.C:
+				c.manager.CleanupInactiveLimiters(expirationDuration)
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 }
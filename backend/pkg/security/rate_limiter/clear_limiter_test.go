@@ -0,0 +1,63 @@
+package ratelimiter_test
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	ratelimiter "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/rate_limiter"
+)
+
+// countingManager is a RateLimiterManager that only counts cleanup calls.
+type countingManager struct {
+	cleanups atomic.Int32
+}
+
+func (m *countingManager) GetRateLimiterForIP(ipAddress string) ratelimiter.Limiter {
+	return nil
+}
+
+func (m *countingManager) CleanupInactiveLimiters(expirationDuration time.Duration) {
+	m.cleanups.Add(1)
+}
+
+func (m *countingManager) SetDefaultLimiterConfig(config models.LimiterConfig) {}
+
+func TestRateLimiterCleanerStopsOnContextCancel(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	manager := &countingManager{}
+	cleaner := ratelimiter.NewRateLimiterCleaner(manager)
+	cleanerCtx, stopCleaner := context.WithCancel(context.Background())
+	cleaner.Start(cleanerCtx, time.Minute, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for manager.cleanups.Load() == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatal("Cleaner did not run a cleanup cycle before the deadline")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	stopCleaner()
+
+	for runtime.NumGoroutine() > baseline {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Cleaner goroutine leaked. Expected at most %d goroutines, Got: %d", baseline, runtime.NumGoroutine())
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	cleanupsAfterStop := manager.cleanups.Load()
+	time.Sleep(20 * time.Millisecond)
+	if manager.cleanups.Load() != cleanupsAfterStop {
+		t.Errorf("Cleanup ran after cancellation. Expected: %d cleanups, Got: %d", cleanupsAfterStop, manager.cleanups.Load())
+	}
+}
@@ -15,17 +15,35 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// RateLimitResult describes the outcome of a rate limiting check.
+type RateLimitResult struct {
+	Allowed         bool      // Whether the request may proceed
+	RemainingTokens float64   // Tokens left in the IP's bucket after the check
+	ResetAt         time.Time // When the bucket will be full again
+}
+
+// RateLimiterEventHook is called after every rate limiting check with the client IP and whether the request was allowed.
+// It runs in its own goroutine, so it must be safe for concurrent use and must not assume ordering between calls.
+type RateLimiterEventHook func(ipAddress string, allowed bool)
+
 // RateLimiterHandler defines the core rate limiting interface for request authorization.
 type RateLimiterHandler interface {
 	// Allow checks if a request from the specified IP address is permitted.
-	// Returns true if the request should be allowed, false if rate limited.
-	Allow(ipAddress string) bool
+	// The returned RateLimitResult reports whether the request should be allowed and the state of the IP's bucket.
+	Allow(ipAddress string) RateLimitResult
+}
+
+// Limiter is the token bucket of a single IP address.
+type Limiter interface {
+	// Allow consumes one token if one is available.
+	// The returned RateLimitResult reports whether the request should be allowed and the state of the bucket after the check.
+	Allow() RateLimitResult
 }
 
 // RateLimiterManager defines operations for managing rate limiter instances.
 type RateLimiterManager interface {
 	// GetRateLimiterForIP retrieves or creates a rate limiter for the specified IP.
-	GetRateLimiterForIP(ipAddress string) *rate.Limiter
+	GetRateLimiterForIP(ipAddress string) Limiter
 
 	// CleanupInactiveLimiters removes rate limiters that haven't been used within expirationDuration.
 	CleanupInactiveLimiters(expirationDuration time.Duration)
@@ -35,9 +53,10 @@ type RateLimiterManager interface {
 }
 
 // DefaultRateLimiter implements RateLimiterHandler using an underlying RateLimiterManager.
-// Provides basic rate limiting capabilities with per-IP tracking.
+// Provides basic rate limiting capabilities with per-IP tracking and an optional event hook for monitoring.
 type DefaultRateLimiter struct {
 	manager RateLimiterManager
+	hook    RateLimiterEventHook
 }
 
 // NewDefaultRateLimiter creates a new rate limiter with specified default configuration.
@@ -55,11 +74,56 @@ func NewDefaultRateLimiter(requestPerSecond float64, burst int) RateLimiterHandl
 	}
 }
 
+// NewRateLimiterWithManager creates a rate limiter backed by an existing RateLimiterManager.
+// Use it when the manager is shared with other components, such as a RateLimiterCleaner.
+func NewRateLimiterWithManager(manager RateLimiterManager) RateLimiterHandler {
+	return &DefaultRateLimiter{
+		manager: manager,
+	}
+}
+
+// WithHook registers a hook notified after every Allow call, e.g. to raise an alert or update a dashboard counter when a limit is hit.
+// Returns the same DefaultRateLimiter to allow fluent construction.
+func (d *DefaultRateLimiter) WithHook(hook RateLimiterEventHook) *DefaultRateLimiter {
+	d.hook = hook
+	return d
+}
+
 // Allow implements rate limiting check for the specified IP address.
-// Consumes one token from the IP's rate limiter bucket.
-func (d *DefaultRateLimiter) Allow(ipAddress string) bool {
-	limiter := d.manager.GetRateLimiterForIP(ipAddress)
-	return limiter.Allow()
+// Consumes one token from the IP's rate limiter bucket and notifies the hook, if any, asynchronously.
+func (d *DefaultRateLimiter) Allow(ipAddress string) RateLimitResult {
+	result := d.manager.GetRateLimiterForIP(ipAddress).Allow()
+
+	if d.hook != nil {
+		go d.hook(ipAddress, result.Allowed)
+	}
+	return result
+}
+
+// localLimiter adapts a rate.Limiter held in memory to the Limiter interface.
+type localLimiter struct {
+	limiter *rate.Limiter
+}
+
+// Allow consumes a token from the in-memory bucket.
+func (l localLimiter) Allow() RateLimitResult {
+	allowed := l.limiter.Allow()
+
+	now := time.Now()
+	remaining := l.limiter.TokensAt(now)
+	return RateLimitResult{
+		Allowed:         allowed,
+		RemainingTokens: remaining,
+		ResetAt:         resetTime(now, remaining, float64(l.limiter.Limit()), l.limiter.Burst()),
+	}
+}
+
+// resetTime returns when a bucket holding remaining tokens, refilled at requestPerSecond up to burst, will be full again.
+func resetTime(now time.Time, remaining, requestPerSecond float64, burst int) time.Time {
+	if missing := float64(burst) - remaining; missing > 0 && requestPerSecond > 0 {
+		return now.Add(time.Duration(missing / requestPerSecond * float64(time.Second)))
+	}
+	return now
 }
 
 // DefaultRateLimiterManager implements RateLimiterManager with in-memory storage.
@@ -89,13 +153,13 @@ func (m *DefaultRateLimiterManager) SetDefaultLimiterConfig(config models.Limite
 
 // GetRateLimiterForIP retrieves or creates a rate limiter for the specified IP.
 // Updates last access time for cleanup tracking. Existing limiters are reused.
-func (m *DefaultRateLimiterManager) GetRateLimiterForIP(ipAddress string) *rate.Limiter {
+func (m *DefaultRateLimiterManager) GetRateLimiterForIP(ipAddress string) Limiter {
 	currentTime := time.Now()
 	record, exists := m.ipLimiterCache.Load(ipAddress)
 	if exists {
 		limiterRecord := record.(*LimiterEntry)
 		limiterRecord.lastSeen = currentTime
-		return limiterRecord.limiter
+		return localLimiter{limiterRecord.limiter}
 	}
 
 	newLimiter := rate.NewLimiter(rate.Limit(m.defaultConfig.RequestPerSecond), m.defaultConfig.Burst)
@@ -106,9 +170,20 @@ func (m *DefaultRateLimiterManager) GetRateLimiterForIP(ipAddress string) *rate.
 
 	actualRecord, loaded := m.ipLimiterCache.LoadOrStore(ipAddress, newRecord)
 	if loaded {
-		return actualRecord.(*LimiterEntry).limiter
+		return localLimiter{actualRecord.(*LimiterEntry).limiter}
 	}
-	return newLimiter
+	return localLimiter{newLimiter}
+}
+
+// Count returns the number of IP addresses that currently have a rate limiter.
+// Entries are only removed by CleanupInactiveLimiters, so the count includes IPs idle since the last cleanup.
+func (m *DefaultRateLimiterManager) Count() int {
+	count := 0
+	m.ipLimiterCache.Range(func(key, value interface{}) bool {
+		count++
+		return true
+	})
+	return count
 }
 
 // CleanupInactiveLimiters removes rate limiters that haven't been accessed within expirationDuration.
@@ -0,0 +1,56 @@
+package ratelimiter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	ratelimiter "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/rate_limiter"
+)
+
+type hookEvent struct {
+	ipAddress string
+	allowed   bool
+}
+
+func TestDefaultRateLimiterHookReportsRateLimitedIP(t *testing.T) {
+	manager := ratelimiter.NewRateLimiterManager()
+	manager.SetDefaultLimiterConfig(models.LimiterConfig{RequestPerSecond: 0.001, Burst: 1})
+
+	events := make(chan hookEvent, 2)
+	limiter := ratelimiter.NewRateLimiterWithManager(manager).(*ratelimiter.DefaultRateLimiter).
+		WithHook(func(ipAddress string, allowed bool) {
+			events <- hookEvent{ipAddress, allowed}
+		})
+
+	first := limiter.Allow("203.0.113.7")
+	second := limiter.Allow("203.0.113.7")
+
+	if !first.Allowed {
+		t.Errorf("First request Expected: allowed, Got: rate limited")
+	}
+	if second.Allowed {
+		t.Errorf("Second request Expected: rate limited, Got: allowed")
+	}
+	if !second.ResetAt.After(time.Now()) {
+		t.Errorf("ResetAt Expected: in the future, Got: %v", second.ResetAt)
+	}
+
+	limited := 0
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-events:
+			if event.ipAddress != "203.0.113.7" {
+				t.Errorf("Hook IP Expected: %s, Got: %s", "203.0.113.7", event.ipAddress)
+			}
+			if !event.allowed {
+				limited++
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected 2 hook calls, Got: %d", i)
+		}
+	}
+	if limited != 1 {
+		t.Errorf("Rate-limited hook calls Expected: %d, Got: %d", 1, limited)
+	}
+}
@@ -0,0 +1,130 @@
+// Package ratelimiter provides IP-based request rate limiting functionality.
+// This file contains RedisRateLimiterManager, which keeps the token buckets in Redis so they survive restarts and are shared by every instance.
+package ratelimiter
+
+import (
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills the bucket stored in the hash KEYS[1] for the time elapsed since its last update, then consumes one token if available.
+// ARGV: requests per second, burst, key TTL in seconds. The clock is Redis' own TIME, so instances with skewed clocks share one timeline.
+// Returns {allowed (0 or 1), remaining tokens as a string}, since Lua numbers are truncated to integers in replies.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1])
+local updated = tonumber(bucket[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - updated) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', tostring(now))
+redis.call('EXPIRE', KEYS[1], ttl)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisRateLimiterManager implements RateLimiterManager with the token buckets stored in Redis.
+// Each IP address has a hash under keyPrefix+IP that expires once the bucket would be full again, so Redis purges inactive clients itself.
+// When Redis cannot be reached requests are allowed, so an outage of the cache does not take the API down with it.
+type RedisRateLimiterManager struct {
+	client    *redis.Client
+	keyPrefix string
+
+	mu            sync.RWMutex
+	defaultConfig models.LimiterConfig
+}
+
+// NewRedisRateLimiterManager creates a rate limiter manager storing its buckets in Redis through client.
+// keyPrefix namespaces the keys, so several limiters (e.g. the API and the CSRF token endpoint) can share a Redis database.
+// config sets the rate and burst of every bucket.
+func NewRedisRateLimiterManager(client *redis.Client, keyPrefix string, config models.LimiterConfig) *RedisRateLimiterManager {
+	return &RedisRateLimiterManager{
+		client:        client,
+		keyPrefix:     keyPrefix,
+		defaultConfig: config,
+	}
+}
+
+// SetDefaultLimiterConfig updates the rate and burst of the buckets.
+// Unlike the in-memory manager it applies to existing buckets too, from their next check.
+func (m *RedisRateLimiterManager) SetDefaultLimiterConfig(config models.LimiterConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultConfig = config
+}
+
+// GetRateLimiterForIP returns a limiter that checks the IP's bucket in Redis on every Allow call.
+func (m *RedisRateLimiterManager) GetRateLimiterForIP(ipAddress string) Limiter {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return &redisLimiter{
+		client: m.client,
+		key:    m.keyPrefix + ipAddress,
+		config: m.defaultConfig,
+	}
+}
+
+// CleanupInactiveLimiters does nothing: the keys of inactive buckets expire in Redis.
+func (m *RedisRateLimiterManager) CleanupInactiveLimiters(expirationDuration time.Duration) {}
+
+// redisLimiter is the Limiter of one IP address, delegating each check to tokenBucketScript.
+type redisLimiter struct {
+	client *redis.Client
+	key    string
+	config models.LimiterConfig
+}
+
+// Allow runs the token bucket script atomically for the IP's key.
+// It allows the request if the script fails, logging the error.
+func (l *redisLimiter) Allow() RateLimitResult {
+	now := time.Now()
+	reply, err := tokenBucketScript.Run(context.Background(), l.client, []string{l.key},
+		l.config.RequestPerSecond, l.config.Burst, l.keyTTLSeconds()).Slice()
+	if err != nil || len(reply) != 2 {
+		log.Printf("[WARN] rate limiter: Redis check failed for %s, allowing request: %v", l.key, err)
+		return RateLimitResult{Allowed: true, RemainingTokens: float64(l.config.Burst), ResetAt: now}
+	}
+
+	allowed, _ := reply[0].(int64)
+	remainingReply, _ := reply[1].(string)
+	remaining, err := strconv.ParseFloat(remainingReply, 64)
+	if err != nil {
+		remaining = 0
+	}
+
+	return RateLimitResult{
+		Allowed:         allowed == 1,
+		RemainingTokens: remaining,
+		ResetAt:         resetTime(now, remaining, l.config.RequestPerSecond, l.config.Burst),
+	}
+}
+
+// keyTTLSeconds is how long an empty bucket takes to refill completely, plus one second; after that an expired key is equivalent to a full bucket.
+func (l *redisLimiter) keyTTLSeconds() int {
+	if l.config.RequestPerSecond <= 0 {
+		return int((24 * time.Hour).Seconds())
+	}
+	return int(math.Ceil(float64(l.config.Burst)/l.config.RequestPerSecond)) + 1
+}
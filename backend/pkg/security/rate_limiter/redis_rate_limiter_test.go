@@ -0,0 +1,72 @@
+package ratelimiter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	ratelimiter "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/rate_limiter"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newRedisManager(t *testing.T, server *miniredis.Miniredis, keyPrefix string) *ratelimiter.RedisRateLimiterManager {
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return ratelimiter.NewRedisRateLimiterManager(client, keyPrefix, models.LimiterConfig{RequestPerSecond: 1, Burst: 2})
+}
+
+func TestRedisRateLimiterSharesBucketsAcrossInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.SetTime(time.Now())
+	first := ratelimiter.NewRateLimiterWithManager(newRedisManager(t, server, "ratelimit:"))
+	second := ratelimiter.NewRateLimiterWithManager(newRedisManager(t, server, "ratelimit:"))
+
+	if !first.Allow("203.0.113.7").Allowed || !second.Allow("203.0.113.7").Allowed {
+		t.Fatalf("Expected the burst of 2 requests to be allowed")
+	}
+	limited := first.Allow("203.0.113.7")
+	if limited.Allowed {
+		t.Errorf("Third request Expected: rate limited, Got: allowed")
+	}
+	if !limited.ResetAt.After(time.Now()) {
+		t.Errorf("ResetAt Expected: in the future, Got: %v", limited.ResetAt)
+	}
+	if !second.Allow("198.51.100.1").Allowed {
+		t.Errorf("Another IP Expected: allowed, Got: rate limited")
+	}
+
+	if ttl := server.TTL("ratelimit:203.0.113.7"); ttl <= 0 {
+		t.Errorf("Expected the bucket key to expire, Got TTL %v", ttl)
+	}
+
+	server.SetTime(time.Now().Add(1500 * time.Millisecond))
+	if !first.Allow("203.0.113.7").Allowed {
+		t.Errorf("After refill Expected: allowed, Got: rate limited")
+	}
+}
+
+func TestRedisRateLimiterKeyPrefixSeparatesLimiters(t *testing.T) {
+	server := miniredis.RunT(t)
+	api := ratelimiter.NewRateLimiterWithManager(newRedisManager(t, server, "ratelimit:"))
+	csrf := ratelimiter.NewRateLimiterWithManager(newRedisManager(t, server, "ratelimit:csrf:"))
+
+	api.Allow("203.0.113.7")
+	api.Allow("203.0.113.7")
+	if api.Allow("203.0.113.7").Allowed {
+		t.Fatalf("Expected the API limiter to be exhausted")
+	}
+	if !csrf.Allow("203.0.113.7").Allowed {
+		t.Errorf("CSRF limiter Expected: allowed, Got: rate limited")
+	}
+}
+
+func TestRedisRateLimiterAllowsWhenRedisIsDown(t *testing.T) {
+	server := miniredis.RunT(t)
+	limiter := ratelimiter.NewRateLimiterWithManager(newRedisManager(t, server, "ratelimit:"))
+	server.Close()
+
+	if !limiter.Allow("203.0.113.7").Allowed {
+		t.Errorf("Expected requests to be allowed while Redis is unreachable")
+	}
+}
@@ -0,0 +1,128 @@
+// Package securityAuth provides interfaces and implementations for password hashing
+// and salt generation, supporting secure authentication workflows in the sale-watches application.
+// This file contains the Argon2id hasher and the helpers used to verify hashes of either supported algorithm.
+package securityAuth
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashAlgorithm identifies the algorithm that produced a stored password hash.
+type HashAlgorithm string
+
+// Supported password hash algorithms.
+const (
+	HashAlgorithmUnknown  HashAlgorithm = "unknown"
+	HashAlgorithmBcrypt   HashAlgorithm = "bcrypt"
+	HashAlgorithmArgon2id HashAlgorithm = "argon2id"
+)
+
+// DetectAlgorithm returns the algorithm of a stored hash from its prefix:
+// bcrypt hashes start with "$2" and Argon2id hashes with "$argon2id$".
+func DetectAlgorithm(hash string) HashAlgorithm {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return HashAlgorithmArgon2id
+	case strings.HasPrefix(hash, "$2"):
+		return HashAlgorithmBcrypt
+	default:
+		return HashAlgorithmUnknown
+	}
+}
+
+// Argon2idHasher implements the Hasher interface using the memory-hard Argon2id algorithm.
+type Argon2idHasher struct {
+	config        models.Argon2Config
+	saltGenerator SaltGenerator
+}
+
+// NewArgon2idHasher creates an Argon2idHasher with the given cost parameters.
+// saltGenerator provides a fresh hex-encoded salt for every hash.
+func NewArgon2idHasher(config models.Argon2Config, saltGenerator SaltGenerator) Argon2idHasher {
+	return Argon2idHasher{config: config, saltGenerator: saltGenerator}
+}
+
+// Hash derives an Argon2id key from the password and a salt from the SaltGenerator.
+// The result is encoded in the standard PHC format, so the parameters travel with the hash:
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<base64 salt>$<base64 key>
+func (h Argon2idHasher) Hash(password []byte) (string, error) {
+	hexSalt, err := h.saltGenerator.Generate()
+	if err != nil {
+		return "", err
+	}
+	salt, err := hex.DecodeString(hexSalt)
+	if err != nil {
+		return "", errors.NewInternalError("error decoding the salt").WithError(err)
+	}
+
+	key := argon2.IDKey(password, salt, h.config.Time, h.config.Memory, h.config.Threads, h.config.KeyLen)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.config.Memory,
+		h.config.Time,
+		h.config.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPassword checks a plain password against a stored bcrypt or Argon2id hash.
+// It returns an AuthError if the password does not match and an InternalError if the hash cannot be parsed.
+func VerifyPassword(hash string, password []byte) error {
+	switch DetectAlgorithm(hash) {
+	case HashAlgorithmBcrypt:
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), password); err != nil {
+			return errors.NewAuthError(errors.ErrInvalidCredentials)
+		}
+		return nil
+	case HashAlgorithmArgon2id:
+		return verifyArgon2id(hash, password)
+	default:
+		return errors.NewInternalError("unsupported password hash format")
+	}
+}
+
+// verifyArgon2id re-derives the key with the parameters and salt embedded in the PHC-encoded hash and compares it in constant time.
+func verifyArgon2id(hash string, password []byte) error {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return errors.NewInternalError("malformed argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return errors.NewInternalError("unsupported argon2id version")
+	}
+
+	var config models.Argon2Config
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &config.Memory, &config.Time, &config.Threads); err != nil {
+		return errors.NewInternalError("malformed argon2id parameters").WithError(err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return errors.NewInternalError("malformed argon2id salt").WithError(err)
+	}
+	expectedKey, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return errors.NewInternalError("malformed argon2id key").WithError(err)
+	}
+
+	key := argon2.IDKey(password, salt, config.Time, config.Memory, config.Threads, uint32(len(expectedKey)))
+	if subtle.ConstantTimeCompare(key, expectedKey) != 1 {
+		return errors.NewAuthError(errors.ErrInvalidCredentials)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package securityAuth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+func TestDetectAlgorithm(t *testing.T) {
+	tests := []struct {
+		hash     string
+		expected securityAuth.HashAlgorithm
+	}{
+		{"$2a$10$abcdefghijklmnopqrstuv", securityAuth.HashAlgorithmBcrypt},
+		{"$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$a2V5", securityAuth.HashAlgorithmArgon2id},
+		{"plain-text", securityAuth.HashAlgorithmUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := securityAuth.DetectAlgorithm(tt.hash); got != tt.expected {
+			t.Errorf("DetectAlgorithm(%q) Expected: %v, Got: %v", tt.hash, tt.expected, got)
+		}
+	}
+}
+
+func TestArgon2idHasherRoundTrip(t *testing.T) {
+	hasher := securityAuth.NewArgon2idHasher(
+		models.Argon2Config{Time: 1, Memory: 8 * 1024, Threads: 1, KeyLen: 32},
+		securityAuth.NewRandomSaltGenerator(16),
+	)
+
+	hash, err := hasher.Hash([]byte("Correct-Password-1"))
+	if err != nil {
+		t.Fatalf("Hash() unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=8192,t=1,p=1$") {
+		t.Errorf("Incorrect hash prefix. Got: %s", hash)
+	}
+
+	if err := securityAuth.VerifyPassword(hash, []byte("Correct-Password-1")); err != nil {
+		t.Errorf("VerifyPassword() with correct password Expected: nil, Got: %v", err)
+	}
+	if err := securityAuth.VerifyPassword(hash, []byte("Wrong-Password-1")); err == nil {
+		t.Errorf("VerifyPassword() with wrong password Expected: error, Got: nil")
+	}
+}
+
+func TestVerifyPasswordBcrypt(t *testing.T) {
+	hash, err := securityAuth.BcryptHasher{}.Hash([]byte("Correct-Password-1"))
+	if err != nil {
+		t.Fatalf("Hash() unexpected error: %v", err)
+	}
+
+	if err := securityAuth.VerifyPassword(hash, []byte("Correct-Password-1")); err != nil {
+		t.Errorf("VerifyPassword() with correct password Expected: nil, Got: %v", err)
+	}
+}
+
+func TestRandomSaltGenerator(t *testing.T) {
+	generator := securityAuth.NewRandomSaltGenerator(16)
+
+	first, err := generator.Generate()
+	if err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+	second, _ := generator.Generate()
+	if first == second {
+		t.Errorf("Generate() returned the same salt twice: %s", first)
+	}
+
+	bits, err := securityAuth.SaltStrength(first)
+	if err != nil || bits != 128 {
+		t.Errorf("SaltStrength() Expected: %d, Got: %d (err: %v)", 128, bits, err)
+	}
+	if _, err := securityAuth.SaltStrength("not-hex"); err == nil {
+		t.Errorf("SaltStrength() with invalid hex Expected: error, Got: nil")
+	}
+}
@@ -0,0 +1,74 @@
+// Package securityAuth provides interfaces and implementations for password hashing
+// and salt generation, supporting secure authentication workflows in the sale-watches application.
+// This file contains helpers to measure hashers and to pick Argon2id parameters for the current machine.
+package securityAuth
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// Parameters used by SuggestArgon2Params. Only the memory cost is tuned: the pass count and key length
+// follow RFC 9106, and the thread count follows the number of CPUs.
+const (
+	suggestedArgon2Time      = 3
+	suggestedArgon2KeyLen    = 32
+	suggestedArgon2MinMemory = 16 * 1024       // 16 MiB, in KiB
+	suggestedArgon2MaxMemory = 2 * 1024 * 1024 // 2 GiB, in KiB
+	suggestIterations        = 3
+)
+
+// BenchmarkHasher hashes password iterations times with h and returns the average duration of a single hash.
+// It returns a ValidationError if iterations is not positive, and the hasher's error if any hash fails.
+func BenchmarkHasher(h Hasher, password string, iterations int) (time.Duration, error) {
+	if iterations <= 0 {
+		return 0, errors.NewValidationError("iterations must be positive")
+	}
+
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		if _, err := h.Hash([]byte(password)); err != nil {
+			return 0, err
+		}
+	}
+	return time.Since(start) / time.Duration(iterations), nil
+}
+
+// SuggestArgon2Params returns the cheapest Argon2id parameters whose hash takes at least targetMillis milliseconds on this machine.
+
+// Starting at 16 MiB, the memory cost is doubled until BenchmarkHasher reports an average at or above the target, up to 2 GiB; if even that is faster than the target, the 2 GiB configuration is returned.
+// Threads is set to runtime.NumCPU() (capped at 255), Time to 3 passes and KeyLen to 32 bytes. It returns a ValidationError if targetMillis is not positive.
+func SuggestArgon2Params(targetMillis int) (models.Argon2Config, error) {
+	if targetMillis <= 0 {
+		return models.Argon2Config{}, errors.NewValidationError("target duration must be positive")
+	}
+
+	threads := runtime.NumCPU()
+	if threads > 255 {
+		threads = 255
+	}
+
+	target := time.Duration(targetMillis) * time.Millisecond
+	config := models.Argon2Config{
+		Time:    suggestedArgon2Time,
+		Threads: uint8(threads),
+		KeyLen:  suggestedArgon2KeyLen,
+	}
+
+	for memory := uint32(suggestedArgon2MinMemory); memory <= suggestedArgon2MaxMemory; memory *= 2 {
+		config.Memory = memory
+		hasher := NewArgon2idHasher(config, NewRandomSaltGenerator(16))
+
+		avg, err := BenchmarkHasher(hasher, "benchmark-password", suggestIterations)
+		if err != nil {
+			return models.Argon2Config{}, err
+		}
+		if avg >= target {
+			break
+		}
+	}
+	return config, nil
+}
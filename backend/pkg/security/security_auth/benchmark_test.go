@@ -0,0 +1,46 @@
+package securityAuth_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+)
+
+// sleepHasher is a Hasher whose every hash takes a fixed duration.
+type sleepHasher struct {
+	delay time.Duration
+}
+
+func (h sleepHasher) Hash(password []byte) (string, error) {
+	time.Sleep(h.delay)
+	return string(password), nil
+}
+
+func TestBenchmarkHasherAveragesDuration(t *testing.T) {
+	avg, err := securityAuth.BenchmarkHasher(sleepHasher{delay: 5 * time.Millisecond}, "password", 4)
+	if err != nil {
+		t.Fatalf("BenchmarkHasher() unexpected error: %v", err)
+	}
+	if avg < 5*time.Millisecond || avg > 50*time.Millisecond {
+		t.Errorf("Expected an average of about 5ms, Got: %v", avg)
+	}
+
+	if _, err := securityAuth.BenchmarkHasher(sleepHasher{}, "password", 0); err == nil {
+		t.Error("Expected an error for zero iterations")
+	}
+}
+
+func TestSuggestArgon2ParamsUsesAllCPUs(t *testing.T) {
+	config, err := securityAuth.SuggestArgon2Params(1)
+	if err != nil {
+		t.Fatalf("SuggestArgon2Params() unexpected error: %v", err)
+	}
+	if want := runtime.NumCPU(); want <= 255 && int(config.Threads) != want {
+		t.Errorf("Threads Expected: %d, Got: %d", want, config.Threads)
+	}
+	if config.Memory < 16*1024 || config.Time != 3 || config.KeyLen != 32 {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+}
@@ -2,34 +2,57 @@
 package securityAuth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	stdErrors "errors"
 	"fmt"
 	"time"
 
 	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/ports/output"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // JWTService manages operations related to JSON Web Tokens.
 // It uses a secret key to sign and verify tokens.
+// ClockSkewTolerance is the leeway applied to the exp claim when validating tokens, so small clock differences between issuer and server do not reject valid sessions.
+// Refresh tokens are only issued once WithRefreshTokens has configured where they are recorded and where their users are looked up.
 type JWTService struct {
-	secretKey []byte
+	secretKey          []byte
+	ClockSkewTolerance time.Duration
+
+	tokenRepository output.TokenRepository
+	userRepository  output.UserRepository
+	refreshConfig   models.RefreshTokenConfig
 }
 
-// NewJWTService creates a new JWTService with the given secret.
+// DefaultClockSkewTolerance is the leeway used by NewJWTService.
+const DefaultClockSkewTolerance = 30 * time.Second
+
+// NewJWTService creates a new JWTService with the given secret and DefaultClockSkewTolerance.
 // secretKey: the HMAC secret used to sign and validate tokens.
 func NewJWTService(secretKey string) *JWTService {
 	return &JWTService{
-		secretKey: []byte(secretKey),
+		secretKey:          []byte(secretKey),
+		ClockSkewTolerance: DefaultClockSkewTolerance,
 	}
 }
 
 // GenerateJWT generates a signed JWT for the specified userName.
-// The token embeds the username and an expiration set to one hour from now.
-func (j *JWTService) GenerateJWT(userId int, userName string) (string, error) {
+// The token embeds the username, the user's role (see models.RoleUser and models.RoleAdmin), a random ID (jti) so it can be revoked on logout, and an expiration set to five hours from now.
+func (j *JWTService) GenerateJWT(userId int, userName, role string) (string, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	var claims = models.Claims{
-		UserId: userId,
+		UserId:   userId,
 		UserName: userName,
+		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(5 * time.Hour)),
 		},
 	}
@@ -38,37 +61,255 @@ func (j *JWTService) GenerateJWT(userId int, userName string) (string, error) {
 	return token.SignedString(j.secretKey)
 }
 
+// ImpersonationTokenTTL is the lifetime of tokens issued for impersonation, shorter than regular sessions.
+const ImpersonationTokenTTL = time.Hour
+
+// GenerateImpersonationJWT generates a signed JWT that authenticates as userId on behalf of impersonatorID.
+// The token carries the impersonator in the impersonated_by claim and expires after ImpersonationTokenTTL. Its role is always models.RoleUser, so impersonating an administrator never grants administrator access.
+func (j *JWTService) GenerateImpersonationJWT(userId int, userName string, impersonatorID int) (string, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	var claims = models.Claims{
+		UserId:         userId,
+		UserName:       userName,
+		Role:           models.RoleUser,
+		ImpersonatedBy: impersonatorID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ImpersonationTokenTTL)),
+		},
+	}
+
+	var token = jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(j.secretKey)
+}
+
+// WithRefreshTokens enables refresh tokens, recorded in tokenRepository so they can be revoked. userRepository is checked on every refresh, so deleted accounts cannot renew their sessions.
+// It returns the service to allow fluent construction. A config with a zero TTL leaves refresh tokens disabled.
+func (j *JWTService) WithRefreshTokens(tokenRepository output.TokenRepository, userRepository output.UserRepository, config models.RefreshTokenConfig) *JWTService {
+	j.tokenRepository = tokenRepository
+	j.userRepository = userRepository
+	j.refreshConfig = config
+	return j
+}
+
+// refreshTokensEnabled reports whether WithRefreshTokens configured both repositories and an enabled config.
+func (j *JWTService) refreshTokensEnabled() bool {
+	return j.tokenRepository != nil && j.userRepository != nil && j.refreshConfig.Enabled()
+}
+
+// tokenIDBytes is the number of random bytes in a token ID, hex-encoded into 32 characters.
+const tokenIDBytes = 16
+
+// newTokenID returns a random token ID for the jti claim.
+func newTokenID() (string, error) {
+	id := make([]byte, tokenIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", errors.NewInternalError(errors.ErrTokenGeneration).WithError(err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// generateRefreshToken signs a refresh token for the user with a new random ID and records it in the token repository.
+// The role is recorded for reference only; RefreshToken issues access tokens with the role currently stored for the user.
+func (j *JWTService) generateRefreshToken(userId int, userName, role string) (string, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+	expiresAt := time.Now().Add(j.refreshConfig.TTL)
+
+	var claims = models.Claims{
+		UserId:    userId,
+		UserName:  userName,
+		Role:      role,
+		TokenType: models.TokenTypeRefresh,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.secretKey)
+	if err != nil {
+		return "", errors.NewInternalError(errors.ErrTokenGeneration).WithError(err)
+	}
+
+	if err := j.tokenRepository.Save(tokenID, userId, expiresAt); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// IssueRefreshToken issues a refresh token for the user authenticated by accessToken, typically right after login or registration.
+// It returns an empty token and no error when refresh tokens are not enabled.
+func (j *JWTService) IssueRefreshToken(accessToken string) (string, error) {
+	if !j.refreshTokensEnabled() {
+		return "", nil
+	}
+
+	claims, err := j.ParseWithTolerance(accessToken, j.ClockSkewTolerance)
+	if err != nil {
+		return "", errors.NewAuthError(errors.ErrTokenValidation).WithError(err)
+	}
+	return j.generateRefreshToken(claims.UserId, claims.UserName, claims.Role)
+}
+
+// RefreshToken redeems a refresh token: it returns a new access token and a new refresh token, and revokes the old one so it cannot be used again.
+// Both new tokens carry the role currently stored for the user, not the role of the old token.
+// The old token is still accepted up to the configured grace period after its expiry. A token that is malformed, expired beyond the grace period, unknown, already used, not a refresh token or issued to a deleted account yields an AuthError.
+func (j *JWTService) RefreshToken(oldToken string) (accessToken, refreshToken string, err error) {
+	if !j.refreshTokensEnabled() {
+		return "", "", errors.NewAuthError(errors.ErrTokenValidation)
+	}
+
+	claims, err := j.ParseWithTolerance(oldToken, j.refreshConfig.GracePeriod)
+	if err != nil {
+		return "", "", errors.NewAuthError(errors.ErrTokenValidation).WithError(err)
+	}
+	if claims.TokenType != models.TokenTypeRefresh || claims.ID == "" {
+		return "", "", errors.NewAuthError(errors.ErrTokenValidation)
+	}
+
+	revoked, err := j.tokenRepository.Revoke(claims.ID)
+	if err != nil {
+		return "", "", err
+	}
+	if !revoked {
+		return "", "", errors.NewAuthError(errors.ErrRefreshTokenReused)
+	}
+	// The role is read again rather than copied from the old token, so promotions and demotions apply on the next refresh.
+	role, err := j.userRepository.GetRole(claims.UserId)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", "", errors.NewAuthError(errors.ErrTokenValidation).WithError(err)
+		}
+		return "", "", err
+	}
+
+	accessToken, err = j.GenerateJWT(claims.UserId, claims.UserName, role)
+	if err != nil {
+		return "", "", errors.NewInternalError(errors.ErrTokenGeneration).WithError(err)
+	}
+	refreshToken, err = j.generateRefreshToken(claims.UserId, claims.UserName, role)
+	if err != nil {
+		return "", "", err
+	}
+	return accessToken, refreshToken, nil
+}
+
+// RevokeRefreshToken revokes a refresh token without redeeming it, as on logout.
+// Tokens that are malformed, expired beyond the grace period or not refresh tokens are ignored; so is the call when refresh tokens are disabled.
+func (j *JWTService) RevokeRefreshToken(token string) error {
+	if !j.refreshTokensEnabled() {
+		return nil
+	}
+
+	claims, err := j.ParseWithTolerance(token, j.refreshConfig.GracePeriod)
+	if err != nil || claims.TokenType != models.TokenTypeRefresh || claims.ID == "" {
+		return nil
+	}
+	_, err = j.tokenRepository.Revoke(claims.ID)
+	return err
+}
+
+// IsTokenExpired reports whether err, returned when parsing a token, means the token is well-formed but expired.
+func IsTokenExpired(err error) bool {
+	return stdErrors.Is(err, jwt.ErrTokenExpired)
+}
+
 // defaultJWTService holds the globally configured JWTService for convenience.
 var defaultJWTService *JWTService
 
 // SetDefaultJWTService configures the package‑level JWTService.
-// It should be called once at application startup with the secret key.
-func SetDefaultJWTService(secretKey string) {
+// It should be called once at application startup with the secret key and the clock skew tolerated when validating tokens.
+func SetDefaultJWTService(secretKey string, clockSkewTolerance time.Duration) {
 	defaultJWTService = NewJWTService(secretKey)
+	defaultJWTService.ClockSkewTolerance = clockSkewTolerance
+}
+
+// GenerateJWT signs a token for userName with the given role using the default service.
+// Returns an error if the service has not been initialized.
+func GenerateJWT(userId int, userName, role string) (string, error) {
+	if defaultJWTService == nil {
+		return "", fmt.Errorf("JWT service not initialized")
+	}
+	return defaultJWTService.GenerateJWT(userId, userName, role)
 }
 
-// GenerateJWT signs a token for userName using the default service.
+// GenerateImpersonationJWT signs an impersonation token using the default service.
 // Returns an error if the service has not been initialized.
-func GenerateJWT(userId int, userName string) (string, error) {
+func GenerateImpersonationJWT(userId int, userName string, impersonatorID int) (string, error) {
 	if defaultJWTService == nil {
 		return "", fmt.Errorf("JWT service not initialized")
 	}
-	return defaultJWTService.GenerateJWT(userId, userName)
+	return defaultJWTService.GenerateImpersonationJWT(userId, userName, impersonatorID)
+}
+
+// SetDefaultRefreshTokens enables refresh tokens on the default service (see JWTService.WithRefreshTokens).
+// It must be called after SetDefaultJWTService, once the token and user repositories are available.
+func SetDefaultRefreshTokens(tokenRepository output.TokenRepository, userRepository output.UserRepository, config models.RefreshTokenConfig) {
+	if defaultJWTService != nil {
+		defaultJWTService.WithRefreshTokens(tokenRepository, userRepository, config)
+	}
 }
 
+// IssueRefreshToken issues a refresh token for the session of accessToken using the default service.
+// Returns an error if the service has not been initialized, and an empty token when refresh tokens are disabled.
+func IssueRefreshToken(accessToken string) (string, error) {
+	if defaultJWTService == nil {
+		return "", fmt.Errorf("JWT service not initialized")
+	}
+	return defaultJWTService.IssueRefreshToken(accessToken)
+}
+
+// RefreshToken redeems a refresh token using the default service.
+// Returns an error if the service has not been initialized.
+func RefreshToken(oldToken string) (accessToken, refreshToken string, err error) {
+	if defaultJWTService == nil {
+		return "", "", fmt.Errorf("JWT service not initialized")
+	}
+	return defaultJWTService.RefreshToken(oldToken)
+}
+
+// RevokeRefreshToken revokes a refresh token using the default service.
+// Returns an error if the service has not been initialized.
+func RevokeRefreshToken(token string) error {
+	if defaultJWTService == nil {
+		return fmt.Errorf("JWT service not initialized")
+	}
+	return defaultJWTService.RevokeRefreshToken(token)
+}
+
+// ParseTokenWithClaims validates an access token with the default service and its ClockSkewTolerance.
+// Refresh tokens are rejected, so they cannot be used in place of an access token. Returns an error if the service has not been initialized.
 func ParseTokenWithClaims(tokenString string) (*models.Claims, error) {
 	if defaultJWTService == nil {
 		return nil, fmt.Errorf("JWT service not initialized")
 	}
+	claims, err := defaultJWTService.ParseWithTolerance(tokenString, defaultJWTService.ClockSkewTolerance)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType == models.TokenTypeRefresh {
+		return nil, fmt.Errorf("refresh token used as access token")
+	}
+	return claims, nil
+}
 
+// ParseWithTolerance validates a HS256 token signed with the service's secret and returns its claims.
+// An expired token is still accepted while it expired less than tolerance ago; pass j.ClockSkewTolerance for the configured leeway, or a per-call override.
+func (j *JWTService) ParseWithTolerance(tokenString string, tolerance time.Duration) (*models.Claims, error) {
 	claims := &models.Claims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		if token.Method != jwt.SigningMethodHS256 {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return defaultJWTService.secretKey, nil
-	})
+		return j.secretKey, nil
+	}, jwt.WithLeeway(tolerance))
 
 	if err != nil {
 		return nil, err
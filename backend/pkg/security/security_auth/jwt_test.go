@@ -0,0 +1,151 @@
+package securityAuth_test
+
+import (
+	"testing"
+	"time"
+
+	repotesting "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository/testing"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestParseWithToleranceAcceptsRecentlyExpiredTokens(t *testing.T) {
+	const secret = "0123456789abcdef0123456789abcdef"
+	claims := models.Claims{
+		UserId:   1,
+		UserName: "alice",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-15 * time.Second)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("Signing failed: %v", err)
+	}
+
+	service := securityAuth.NewJWTService(secret)
+
+	if _, err := service.ParseWithTolerance(token, 30*time.Second); err != nil {
+		t.Errorf("Expected a token expired 15s ago to be accepted with 30s skew, Got: %v", err)
+	}
+	if _, err := service.ParseWithTolerance(token, 0); err == nil {
+		t.Error("Expected a token expired 15s ago to be rejected without skew, Got: nil")
+	}
+}
+
+// memoryTokenRepository is an in-memory output.TokenRepository; a token maps to true while it is active.
+type memoryTokenRepository struct {
+	active  map[string]bool
+	userIDs map[string]int
+}
+
+func (r *memoryTokenRepository) Save(tokenID string, userID int, expiresAt time.Time) error {
+	r.active[tokenID] = true
+	r.userIDs[tokenID] = userID
+	return nil
+}
+
+func (r *memoryTokenRepository) Revoke(tokenID string) (bool, error) {
+	wasActive := r.active[tokenID]
+	r.active[tokenID] = false
+	return wasActive, nil
+}
+
+func (r *memoryTokenRepository) IsRevoked(tokenID string) (bool, error) {
+	active, ok := r.active[tokenID]
+	return ok && !active, nil
+}
+
+func (r *memoryTokenRepository) RevokeAllForUser(userID int) error {
+	for tokenID, owner := range r.userIDs {
+		if owner == userID {
+			r.active[tokenID] = false
+		}
+	}
+	return nil
+}
+
+func newRefreshingJWTService(ttl, grace time.Duration) *securityAuth.JWTService {
+	return securityAuth.NewJWTService("0123456789abcdef0123456789abcdef").WithRefreshTokens(
+		&memoryTokenRepository{active: map[string]bool{}, userIDs: map[string]int{}},
+		repotesting.NewInMemoryUserRepository(repotesting.WithUsers([]models.User{{ID: 7, UserName: "alice"}})),
+		models.RefreshTokenConfig{TTL: ttl, GracePeriod: grace, CookieName: "refresh_token"},
+	)
+}
+
+func TestRefreshTokenRotatesTokens(t *testing.T) {
+	service := newRefreshingJWTService(time.Hour, time.Minute)
+	accessToken, err := service.GenerateJWT(7, "alice", models.RoleUser)
+	if err != nil {
+		t.Fatalf("GenerateJWT failed: %v", err)
+	}
+	refreshToken, err := service.IssueRefreshToken(accessToken)
+	if err != nil || refreshToken == "" {
+		t.Fatalf("IssueRefreshToken() = %q, %v", refreshToken, err)
+	}
+
+	newAccessToken, newRefreshToken, err := service.RefreshToken(refreshToken)
+	if err != nil {
+		t.Fatalf("RefreshToken failed: %v", err)
+	}
+	if newRefreshToken == refreshToken {
+		t.Error("Expected the refresh token to be rotated")
+	}
+	claims, err := service.ParseWithTolerance(newAccessToken, 0)
+	if err != nil || claims.UserId != 7 {
+		t.Errorf("Expected a valid access token for user 7, Got claims %+v, error %v", claims, err)
+	}
+
+	// The old refresh token was revoked by the rotation.
+	if _, _, err := service.RefreshToken(refreshToken); !errors.IsAuthError(err) {
+		t.Errorf("Expected an AuthError when reusing a rotated refresh token, Got: %v", err)
+	}
+}
+
+func TestRefreshTokenUsesTheStoredRole(t *testing.T) {
+	// alice logged in as an administrator and has been demoted since.
+	service := securityAuth.NewJWTService("0123456789abcdef0123456789abcdef").WithRefreshTokens(
+		&memoryTokenRepository{active: map[string]bool{}, userIDs: map[string]int{}},
+		repotesting.NewInMemoryUserRepository(repotesting.WithUsers([]models.User{{ID: 7, UserName: "alice", Role: models.RoleUser}})),
+		models.RefreshTokenConfig{TTL: time.Hour, CookieName: "refresh_token"},
+	)
+	accessToken, _ := service.GenerateJWT(7, "alice", models.RoleAdmin)
+	refreshToken, err := service.IssueRefreshToken(accessToken)
+	if err != nil {
+		t.Fatalf("IssueRefreshToken failed: %v", err)
+	}
+
+	newAccessToken, _, err := service.RefreshToken(refreshToken)
+	if err != nil {
+		t.Fatalf("RefreshToken failed: %v", err)
+	}
+	claims, err := service.ParseWithTolerance(newAccessToken, 0)
+	if err != nil || claims.Role != models.RoleUser {
+		t.Errorf("Expected an access token with role %q, Got claims %+v, error %v", models.RoleUser, claims, err)
+	}
+}
+
+func TestRefreshTokenRejectsTokensExpiredBeyondGracePeriod(t *testing.T) {
+	service := newRefreshingJWTService(time.Nanosecond, 0)
+	accessToken, _ := service.GenerateJWT(7, "alice", models.RoleUser)
+	refreshToken, err := service.IssueRefreshToken(accessToken)
+	if err != nil {
+		t.Fatalf("IssueRefreshToken failed: %v", err)
+	}
+	time.Sleep(1100 * time.Millisecond) // exp has a one second resolution
+
+	if _, _, err := service.RefreshToken(refreshToken); !errors.IsAuthError(err) {
+		t.Errorf("Expected an AuthError for an expired refresh token, Got: %v", err)
+	}
+}
+
+func TestRefreshTokenRejectsAccessTokens(t *testing.T) {
+	service := newRefreshingJWTService(time.Hour, time.Minute)
+	accessToken, _ := service.GenerateJWT(7, "alice", models.RoleUser)
+
+	if _, _, err := service.RefreshToken(accessToken); !errors.IsAuthError(err) {
+		t.Errorf("Expected an AuthError when refreshing with an access token, Got: %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+// Package securityAuth provides interfaces and implementations for password hashing
+// and salt generation, supporting secure authentication workflows in the sale-watches application.
+// This file contains the random salt generator and the helper used to measure salt entropy.
+package securityAuth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// MinSaltEntropyBits is the minimum salt entropy considered safe.
+const MinSaltEntropyBits = 128
+
+// SaltGenerator defines methods for producing random salts.
+type SaltGenerator interface {
+	// Generate returns a new random salt encoded as a hex string, or an error if the system random source fails.
+	Generate() (string, error)
+}
+
+// RandomSaltGenerator implements the SaltGenerator interface using crypto/rand.
+type RandomSaltGenerator struct {
+	byteLen int
+}
+
+// NewRandomSaltGenerator creates a RandomSaltGenerator producing salts of byteLen random bytes
+// (the hex string returned by Generate is twice as long).
+func NewRandomSaltGenerator(byteLen int) RandomSaltGenerator {
+	return RandomSaltGenerator{byteLen: byteLen}
+}
+
+// Generate reads byteLen bytes from crypto/rand and returns them hex-encoded.
+// It returns an InternalError if the random source cannot be read.
+func (g RandomSaltGenerator) Generate() (string, error) {
+	salt := make([]byte, g.byteLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.NewInternalError("error generating the salt").WithError(err)
+	}
+	return hex.EncodeToString(salt), nil
+}
+
+// SaltStrength returns the effective entropy in bits of a hex-encoded salt produced by RandomSaltGenerator.
+// Each decoded byte carries 8 bits, since every byte comes from crypto/rand. It returns a ValidationError if salt is not valid hex.
+func SaltStrength(salt string) (int, error) {
+	decoded, err := hex.DecodeString(salt)
+	if err != nil {
+		return 0, errors.NewValidationError(errors.ErrInvalidFormat).WithError(err)
+	}
+	return len(decoded) * 8, nil
+}
@@ -0,0 +1,54 @@
+// Package slug builds human-readable, URL-safe identifiers from free text.
+// This file contains Generate, which normalizes a title into a slug, and Unique, which disambiguates it against slugs already in use.
+package slug
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/errors"
+)
+
+// maxUniqueAttempts bounds the numeric suffixes tried by Unique before giving up.
+const maxUniqueAttempts = 1000
+
+var (
+	// separatorPattern matches any run of characters that are not lowercase ASCII letters or digits.
+	separatorPattern = regexp.MustCompile(`[^a-z0-9]+`)
+	// hyphenPattern matches consecutive hyphens left over after replacement.
+	hyphenPattern = regexp.MustCompile(`-{2,}`)
+)
+
+// Generate converts input into a slug: lowercase, with spaces and punctuation replaced by single hyphens and no leading or trailing hyphen.
+// For example "Rolex Submariner — Date (2020)" becomes "rolex-submariner-date-2020". It returns an empty string when input has no letters or digits.
+func Generate(input string) string {
+	slug := strings.ToLower(input)
+	slug = separatorPattern.ReplaceAllString(slug, "-")
+	slug = hyphenPattern.ReplaceAllString(slug, "-")
+	return strings.Trim(slug, "-")
+}
+
+// Unique returns base if it is not already taken, otherwise the first of base-2, base-3, ... that is free.
+
+// Parameters:
+//   - base: the slug to disambiguate, usually the output of Generate.
+//   - existing: reports whether a slug is already in use, typically backed by a database lookup.
+
+// Returns:
+//   - string: a slug for which existing returned false.
+//   - error: the error returned by existing, or an InternalError when no free slug was found within 1000 attempts.
+func Unique(base string, existing func(slug string) (bool, error)) (string, error) {
+	candidate := base
+	for attempt := 2; attempt <= maxUniqueAttempts+1; attempt++ {
+		taken, err := existing(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = base + "-" + strconv.Itoa(attempt)
+	}
+	return "", errors.NewInternalError("no unique slug available for " + base)
+}
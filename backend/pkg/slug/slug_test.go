@@ -0,0 +1,58 @@
+package slug_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/slug"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Rolex Submariner", "rolex-submariner"},
+		{"  Omega Speedmaster!  ", "omega-speedmaster"},
+		{"Seiko -- 5 Sports (SRPD)", "seiko-5-sports-srpd"},
+		{"Tag_Heuer/Carrera.2020", "tag-heuer-carrera-2020"},
+		{"---", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := slug.Generate(tt.input); got != tt.expected {
+			t.Errorf("Generate(%q): Expected %q, Got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestUniqueAppendsSuffix(t *testing.T) {
+	taken := map[string]bool{"rolex": true, "rolex-2": true}
+
+	got, err := slug.Unique("rolex", func(s string) (bool, error) {
+		return taken[s], nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, Got: %v", err)
+	}
+	if got != "rolex-3" {
+		t.Errorf("Expected rolex-3, Got %q", got)
+	}
+}
+
+func TestUniqueReturnsBaseWhenFree(t *testing.T) {
+	got, err := slug.Unique("omega", func(string) (bool, error) { return false, nil })
+	if err != nil || got != "omega" {
+		t.Errorf("Expected omega with no error, Got %q, %v", got, err)
+	}
+}
+
+func TestUniquePropagatesLookupError(t *testing.T) {
+	lookupErr := errors.New("database unavailable")
+
+	_, err := slug.Unique("seiko", func(string) (bool, error) { return false, lookupErr })
+	if !errors.Is(err, lookupErr) {
+		t.Errorf("Expected lookup error, Got: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+// Package fixtures provides typed builders that seed the database with deterministic data for integration tests.
+// This file contains NewComment and its functional options.
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// DefaultCommentDate is the fixed timestamp given to comments created without WithDate, so ordering by date is predictable.
+const DefaultCommentDate = "2024-01-01 00:00:00"
+
+// commentFixture holds the values used to insert a comment row.
+type commentFixture struct {
+	User    *models.UserProfile
+	Content string
+	Rating  int
+	Date    string
+}
+
+// CommentOpt defines functional options for modifying the comment created by NewComment.
+type CommentOpt func(*commentFixture)
+
+// WithAuthor attaches the comment to an existing user. Without it, NewComment creates a fresh user with NewUser.
+func WithAuthor(user models.UserProfile) CommentOpt {
+	return func(c *commentFixture) {
+		c.User = &user
+	}
+}
+
+// WithContent sets the comment body instead of the generated "fixture_comment_N".
+func WithContent(content string) CommentOpt {
+	return func(c *commentFixture) {
+		c.Content = content
+	}
+}
+
+// WithRating sets the comment rating. Defaults to 5.
+func WithRating(rating int) CommentOpt {
+	return func(c *commentFixture) {
+		c.Rating = rating
+	}
+}
+
+// WithDate sets the comment timestamp (MySQL DATETIME format). Defaults to DefaultCommentDate.
+func WithDate(date string) CommentOpt {
+	return func(c *commentFixture) {
+		c.Date = date
+	}
+}
+
+// NewComment inserts a comment and returns it with the database-assigned ID and the author's name populated.
+// The row is deleted when the test finishes. Any failure aborts the test.
+func NewComment(t *testing.T, db *sqlx.DB, opts ...CommentOpt) models.Comment {
+	t.Helper()
+
+	comment := &commentFixture{
+		Content: defaultName("comment"),
+		Rating:  5,
+		Date:    DefaultCommentDate,
+	}
+	for _, opt := range opts {
+		opt(comment)
+	}
+
+	if comment.User == nil {
+		user := NewUser(t, db)
+		comment.User = &user
+	}
+
+	result, err := db.Exec(
+		"INSERT INTO comments (UserID, Content, Rating, Date) VALUES (?, ?, ?, ?)",
+		comment.User.ID, comment.Content, comment.Rating, comment.Date,
+	)
+	if err != nil {
+		t.Fatalf("fixtures.NewComment: inserting comment: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatalf("fixtures.NewComment: reading inserted ID: %v", err)
+	}
+
+	// Registered after the author's cleanup, so it runs first and never violates the foreign key.
+	t.Cleanup(func() {
+		if _, err := db.Exec("DELETE FROM comments WHERE ID = ?", id); err != nil {
+			t.Errorf("fixtures.NewComment: cleaning up comment %d: %v", id, err)
+		}
+	})
+
+	return models.Comment{
+		ID:       int(id),
+		Date:     comment.Date,
+		UserID:   comment.User.ID,
+		UserName: comment.User.UserName,
+		Content:  comment.Content,
+		Rating:   comment.Rating,
+	}
+}
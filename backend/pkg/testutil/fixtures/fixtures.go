@@ -0,0 +1,21 @@
+// Package fixtures provides typed builders that seed the database with deterministic data for integration tests.
+// Each builder inserts a single row through the same tables the repositories use, returns the created domain model with its database ID populated, and registers a t.Cleanup that deletes the row when the test finishes.
+package fixtures
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// sequence numbers the rows created during a test run so default values are deterministic but never collide.
+var sequence atomic.Int64
+
+// nextSequence returns the next fixture sequence number, starting at 1.
+func nextSequence() int64 {
+	return sequence.Add(1)
+}
+
+// defaultName builds a deterministic, unique name such as "fixture_user_3".
+func defaultName(kind string) string {
+	return fmt.Sprintf("fixture_%s_%d", kind, nextSequence())
+}
@@ -0,0 +1,78 @@
+// Package fixtures provides typed builders that seed the database with deterministic data for integration tests.
+// This file contains NewUser and its functional options.
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/models"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	"github.com/jmoiron/sqlx"
+)
+
+// DefaultUserPassword is the plain-text password given to users created without WithPassword.
+const DefaultUserPassword = "Fixture-Password-1"
+
+// userFixture holds the values used to insert a user row.
+type userFixture struct {
+	UserName string
+	Password string
+}
+
+// UserOpt defines functional options for modifying the user created by NewUser.
+type UserOpt func(*userFixture)
+
+// WithUserName sets the user's name instead of the generated "fixture_user_N".
+func WithUserName(userName string) UserOpt {
+	return func(u *userFixture) {
+		u.UserName = userName
+	}
+}
+
+// WithPassword sets the plain-text password; it is hashed with bcrypt before insertion, which securityAuth.VerifyPassword accepts.
+func WithPassword(password string) UserOpt {
+	return func(u *userFixture) {
+		u.Password = password
+	}
+}
+
+// NewUser inserts a user into User_Registration and returns its profile with the database-assigned ID.
+// The row is deleted when the test finishes. Any failure aborts the test.
+func NewUser(t *testing.T, db *sqlx.DB, opts ...UserOpt) models.UserProfile {
+	t.Helper()
+
+	user := &userFixture{
+		UserName: defaultName("user"),
+		Password: DefaultUserPassword,
+	}
+	for _, opt := range opts {
+		opt(user)
+	}
+
+	hash, err := securityAuth.BcryptHasher{}.Hash([]byte(user.Password))
+	if err != nil {
+		t.Fatalf("fixtures.NewUser: hashing password: %v", err)
+	}
+
+	result, err := db.Exec("INSERT INTO User_Registration (UserName, Password) VALUES (?, ?)", user.UserName, hash)
+	if err != nil {
+		t.Fatalf("fixtures.NewUser: inserting user %q: %v", user.UserName, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatalf("fixtures.NewUser: reading inserted ID: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if _, err := db.Exec("DELETE FROM User_Registration WHERE UserID = ?", id); err != nil {
+			t.Errorf("fixtures.NewUser: cleaning up user %d: %v", id, err)
+		}
+	})
+
+	return models.UserProfile{
+		ID:          int(id),
+		UserName:    user.UserName,
+		DisplayName: user.UserName,
+	}
+}
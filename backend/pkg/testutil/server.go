@@ -0,0 +1,99 @@
+// Package testutil provides helpers for integration tests that exercise the HTTP API against a real MySQL database.
+// Table-level builders live in the fixtures subpackage.
+package testutil
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	primaryHttp "github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/primary/http"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/repository"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/adapters/secondary/static"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/config"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_auth"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_comments"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_products"
+	"github.com/David-Alejandro-Jimenez/sale-watches/internal/core/domain/services/service_profile"
+	ratelimiter "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/rate_limiter"
+	securityAuth "github.com/David-Alejandro-Jimenez/sale-watches/pkg/security/security_auth"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// TestDatabaseDSNEnv names the environment variable holding the DSN of the MySQL database used by integration tests.
+const TestDatabaseDSNEnv = "TEST_DATABASE_DSN"
+
+// OpenTestDB connects to the database named by TEST_DATABASE_DSN and closes it when the test finishes.
+// The test is skipped when the variable is unset, so integration tests never hit a database by accident.
+func OpenTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv(TestDatabaseDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", TestDatabaseDSNEnv)
+	}
+
+	db, err := sqlx.Connect("mysql", dsn)
+	if err != nil {
+		t.Fatalf("testutil.OpenTestDB: connecting: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// NewTestServer starts an httptest.Server running the full application router against db, wired as in cmd/api with the default configuration.
+// The server is closed when the test finishes.
+func NewTestServer(t *testing.T, db *sqlx.DB) *httptest.Server {
+	t.Helper()
+
+	appConfig := config.NewAppConfig()
+	securityAuth.SetDefaultJWTService(appConfig.GetJWTSecret(), appConfig.GetJWTClockSkew())
+
+	hasher := securityAuth.NewArgon2idHasher(appConfig.GetArgon2Config(), securityAuth.NewRandomSaltGenerator(appConfig.GetSaltByteLength()))
+	userRepo := repository.NewSQLUserRepository(db, hasher)
+	activeAlgorithm := securityAuth.HashAlgorithm(appConfig.GetActiveHashAlgorithm())
+	loginService := service_auth.NewUserLoginService(userRepo, hasher, activeAlgorithm, &service_auth.UserNameValidator{}, &service_auth.PasswordValidator{}, nil)
+	registerService := service_auth.NewUserRegisterService(userRepo, service_auth.NewUserNameValidator(appConfig.GetUsernamePolicy()), &service_auth.PasswordValidator{}, nil)
+
+	commentRepo := repository.NewSqlCommentRepository(db)
+	commentValidator := &service_comments.CommentValidator{}
+	profileService := service_profile.NewUserProfileService(repository.NewSQLUserProfileRepository(db), &service_profile.DisplayNameValidator{})
+
+	newRateLimiter := func() ratelimiter.RateLimiterHandler {
+		manager := ratelimiter.NewRateLimiterManager()
+		manager.SetDefaultLimiterConfig(appConfig.GetRateLimitConfig())
+		return ratelimiter.NewRateLimiterWithManager(manager)
+	}
+
+	router := primaryHttp.NewRouter(
+		appConfig,
+		loginService,
+		registerService,
+		service_comments.NewCommentGetService(commentRepo, commentValidator),
+		service_comments.NewCommentAddService(commentRepo, commentValidator, repository.NewSQLAppSettingsRepository(db), nil),
+		service_comments.NewCommentDeleteService(commentRepo),
+		service_comments.NewCommentUpdateService(commentRepo, commentValidator),
+		service_comments.NewCommentPinService(commentRepo),
+		service_products.NewProductGetService(repository.NewSqlProductRepository(db)),
+		profileService,
+		service_auth.NewPasswordChangeService(userRepo, hasher, &service_auth.PasswordValidator{}),
+		service_auth.NewUserDeletionService(userRepo, repository.NewSQLTokenRepository(db)),
+		service_auth.NewUserListService(userRepo),
+		newRateLimiter(),
+		newRateLimiter(),
+		static.NewStaticFileAdapter(appConfig.GetStaticDir()),
+		nil,
+		repository.NewSQLIdempotencyRepository(db),
+		repository.NewSQLRequestLogRepository(db),
+		repository.NewSQLAuditRepository(db),
+		nil,
+		nil,
+		service_auth.NewTokenRevocationService(repository.NewMemoryTokenRepository()),
+		db,
+	)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
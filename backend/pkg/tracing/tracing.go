@@ -0,0 +1,159 @@
+// Package tracing carries a W3C Trace Context (https://www.w3.org/TR/trace-context/) through request contexts so that HTTP and database log lines can be correlated without a full OpenTelemetry setup.
+// This file contains the TraceContext type, traceparent parsing and formatting, and helpers to extract a trace from incoming requests and inject it into outgoing ones.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// TraceparentHeader is the W3C header carrying the trace ID, parent span ID and trace flags.
+const TraceparentHeader = "traceparent"
+
+const (
+	// traceparentVersion is the only traceparent version this package emits.
+	traceparentVersion = "00"
+	// sampledFlag is the trace-flags bit marking a trace as sampled.
+	sampledFlag = 0x01
+	// traceIDLength and spanIDLength are the hex-encoded lengths of the identifiers.
+	traceIDLength = 32
+	spanIDLength  = 16
+)
+
+// traceContextKey is the unexported context key under which the TraceContext is stored.
+type traceContextKey struct{}
+
+// TraceContext identifies the current span within a distributed trace.
+// Fields:
+//   - TraceID: 32 lowercase hex characters shared by every span of the trace.
+//   - SpanID: 16 lowercase hex characters identifying the span handling the current request.
+//   - Sampled: mirrors the sampled bit of the incoming trace-flags.
+type TraceContext struct {
+	TraceID string
+	SpanID  string
+	Sampled bool
+}
+
+// Traceparent formats the trace context as a traceparent header value, e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func (tc TraceContext) Traceparent() string {
+	flags := "00"
+	if tc.Sampled {
+		flags = "01"
+	}
+	return strings.Join([]string{traceparentVersion, tc.TraceID, tc.SpanID, flags}, "-")
+}
+
+// ParseTraceparent parses a traceparent header value.
+// It returns false when the value is malformed, uses the forbidden version "ff", or has an all-zero trace or span ID.
+func ParseTraceparent(value string) (TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return TraceContext{}, false
+	}
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+
+	// Version 00 has exactly four fields; later versions may append more.
+	if !isHex(version, 2) || version == "ff" || (version == traceparentVersion && len(parts) != 4) {
+		return TraceContext{}, false
+	}
+	if !isHex(traceID, traceIDLength) || isZero(traceID) {
+		return TraceContext{}, false
+	}
+	if !isHex(spanID, spanIDLength) || isZero(spanID) {
+		return TraceContext{}, false
+	}
+	if !isHex(flags, 2) {
+		return TraceContext{}, false
+	}
+
+	flagBytes, _ := hex.DecodeString(flags)
+	return TraceContext{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Sampled: flagBytes[0]&sampledFlag != 0,
+	}, true
+}
+
+// NewTraceContext starts a new sampled trace with random trace and span IDs.
+func NewTraceContext() TraceContext {
+	return TraceContext{
+		TraceID: randomHex(traceIDLength / 2),
+		SpanID:  randomHex(spanIDLength / 2),
+		Sampled: true,
+	}
+}
+
+// ContextWithTrace returns a copy of ctx carrying tc.
+func ContextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// FromContext returns the TraceContext stored in ctx, if any.
+func FromContext(ctx context.Context) (TraceContext, bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// TraceID returns the trace ID stored in ctx, or an empty string when the context carries no trace.
+func TraceID(ctx context.Context) string {
+	tc, _ := FromContext(ctx)
+	return tc.TraceID
+}
+
+// ExtractTraceContext returns the request context enriched with a TraceContext.
+
+// When the request carries a valid traceparent header, the trace ID and sampled flag are kept and a new span ID is generated for this server's span. Otherwise a new trace is started.
+func ExtractTraceContext(r *http.Request) context.Context {
+	tc, ok := ParseTraceparent(r.Header.Get(TraceparentHeader))
+	if ok {
+		tc.SpanID = randomHex(spanIDLength / 2)
+	} else {
+		tc = NewTraceContext()
+	}
+	return ContextWithTrace(r.Context(), tc)
+}
+
+// InjectTraceContext sets the traceparent header of an outgoing request from the TraceContext stored in ctx.
+// It leaves the request untouched when ctx carries no trace.
+func InjectTraceContext(ctx context.Context, r *http.Request) {
+	if tc, ok := FromContext(ctx); ok {
+		r.Header.Set(TraceparentHeader, tc.Traceparent())
+	}
+}
+
+// randomHex returns n random bytes hex-encoded, retrying in the practically impossible case that they are all zero.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			panic("tracing: crypto/rand unavailable: " + err.Error())
+		}
+		if encoded := hex.EncodeToString(b); !isZero(encoded) {
+			return encoded
+		}
+	}
+}
+
+// isHex reports whether s has the given length and consists only of lowercase hex digits.
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isZero reports whether s consists only of '0' characters.
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
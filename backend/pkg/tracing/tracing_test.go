@@ -0,0 +1,87 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/tracing"
+)
+
+const validTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"valid", validTraceparent, true},
+		{"not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true},
+		{"empty", "", false},
+		{"uppercase hex", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false},
+		{"zero trace id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false},
+		{"zero span id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"forbidden version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false},
+		{"extra field on version 00", validTraceparent + "-extra", false},
+		{"short trace id", "00-4bf92f35-00f067aa0ba902b7-01", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tracing.ParseTraceparent(tt.value); ok != tt.valid {
+				t.Errorf("ParseTraceparent(%q): Expected valid=%v, Got %v", tt.value, tt.valid, ok)
+			}
+		})
+	}
+}
+
+func TestExtractTraceContextKeepsIncomingTraceID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/comments", nil)
+	req.Header.Set(tracing.TraceparentHeader, validTraceparent)
+
+	tc, ok := tracing.FromContext(tracing.ExtractTraceContext(req))
+	if !ok {
+		t.Fatal("Expected a trace context")
+	}
+	if tc.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected incoming trace ID, Got %q", tc.TraceID)
+	}
+	if tc.SpanID == "00f067aa0ba902b7" {
+		t.Error("Expected a new span ID for the server span")
+	}
+	if !tc.Sampled {
+		t.Error("Expected the sampled flag to be preserved")
+	}
+}
+
+func TestExtractTraceContextStartsNewTrace(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/comments", nil)
+	req.Header.Set(tracing.TraceparentHeader, "garbage")
+
+	tc, ok := tracing.FromContext(tracing.ExtractTraceContext(req))
+	if !ok {
+		t.Fatal("Expected a trace context")
+	}
+	if _, valid := tracing.ParseTraceparent(tc.Traceparent()); !valid {
+		t.Errorf("Expected a valid generated traceparent, Got %q", tc.Traceparent())
+	}
+}
+
+func TestInjectTraceContext(t *testing.T) {
+	tc, _ := tracing.ParseTraceparent(validTraceparent)
+	ctx := tracing.ContextWithTrace(context.Background(), tc)
+
+	req := httptest.NewRequest(http.MethodGet, "http://upstream/", nil)
+	tracing.InjectTraceContext(ctx, req)
+	if got := req.Header.Get(tracing.TraceparentHeader); got != validTraceparent {
+		t.Errorf("Expected %q, Got %q", validTraceparent, got)
+	}
+
+	untraced := httptest.NewRequest(http.MethodGet, "http://upstream/", nil)
+	tracing.InjectTraceContext(context.Background(), untraced)
+	if got := untraced.Header.Get(tracing.TraceparentHeader); got != "" {
+		t.Errorf("Expected no traceparent without a trace, Got %q", got)
+	}
+}
@@ -0,0 +1,5 @@
+-- Creates the initial administrator account.
+-- The password hash (Argon2id PHC string or bcrypt) is read from the ADMIN_PASSWORD_HASH environment variable; the seed fails when it is not set.
+-- A plain INSERT is used on purpose: if an "admin" account already exists in any casing, the seed fails with a duplicate key error instead of promoting an account whose password it does not control. Registration rejects the name, but check accounts created before that rule before deleting or renaming them.
+INSERT INTO User_Registration (UserName, Password, Role)
+VALUES ('admin', ${ADMIN_PASSWORD_HASH}, 'admin');
@@ -0,0 +1,160 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"testing"
+	"time"
+
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/testutil"
+	"github.com/David-Alejandro-Jimenez/sale-watches/pkg/testutil/fixtures"
+)
+
+// commentsPage is the JSON envelope returned by GET /comments.
+type commentsPage struct {
+	Items []struct {
+		UserName string
+		Content  string
+	} `json:"items"`
+}
+
+// flowClient sends requests to the test server, keeping cookies between them like a browser.
+type flowClient struct {
+	t       *testing.T
+	client  *http.Client
+	baseURL string
+}
+
+// do sends a request with an optional JSON body and headers, and fails the test unless the response status is wantStatus.
+func (c *flowClient) do(method, path string, body interface{}, headers map[string]string, wantStatus int) *http.Response {
+	c.t.Helper()
+
+	var reader bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reader).Encode(body); err != nil {
+			c.t.Fatalf("%s %s: encoding body: %v", method, path, err)
+		}
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, &reader)
+	if err != nil {
+		c.t.Fatalf("%s %s: building request: %v", method, path, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	c.t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != wantStatus {
+		c.t.Fatalf("%s %s: Expected status %d, Got %d", method, path, wantStatus, resp.StatusCode)
+	}
+	return resp
+}
+
+// decode reads the JSON body of resp into v.
+func (c *flowClient) decode(resp *http.Response, v interface{}) {
+	c.t.Helper()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		c.t.Fatalf("decoding %s response: %v", resp.Request.URL.Path, err)
+	}
+}
+
+// commentsBy returns the comments of the first page of GET /comments written by userName.
+func (c *flowClient) commentsBy(userName string) []string {
+	c.t.Helper()
+
+	var page commentsPage
+	c.decode(c.do(http.MethodGet, "/comments?per_page=100", nil, nil, http.StatusOK), &page)
+
+	var contents []string
+	for _, item := range page.Items {
+		if item.UserName == userName {
+			contents = append(contents, item.Content)
+		}
+	}
+	return contents
+}
+
+func TestLoginCommentLogoutFlow(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	server := testutil.NewTestServer(t, db)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &flowClient{t: t, client: &http.Client{Jar: jar}, baseURL: server.URL}
+
+	// Another user's comment, which must not be attributed to the new account
+	fixtures.NewComment(t, db)
+
+	// The account is created through the API, so it is removed here rather than by a fixture.
+	userName := fmt.Sprintf("flow_user_%d", time.Now().UnixNano()%1e9)
+	t.Cleanup(func() {
+		db.Exec("DELETE c FROM comments c JOIN User_Registration u ON u.UserID = c.UserID WHERE u.UserName = ?", userName)
+		db.Exec("DELETE FROM User_Registration WHERE UserName = ?", userName)
+	})
+
+	// 1. Fetch a CSRF token, which every mutating request needs, then register, which logs the user in
+	var csrf struct {
+		Token string `json:"csrf_token"`
+	}
+	c.decode(c.do(http.MethodGet, "/auth/csrf-token", nil, nil, http.StatusOK), &csrf)
+	resp := c.do(http.MethodPost, "/register", map[string]string{
+		"userName": userName,
+		"password": fixtures.DefaultUserPassword,
+	}, map[string]string{"X-CSRF-Token": csrf.Token}, http.StatusOK)
+	if !hasCookie(resp, "token") {
+		t.Fatal("POST /register: Expected the token cookie to be set")
+	}
+
+	// 2. The new user has no comments yet
+	if contents := c.commentsBy(userName); len(contents) != 0 {
+		t.Fatalf("GET /comments: Expected no comments by %s, Got %v", userName, contents)
+	}
+
+	// 3. Post a comment with the CSRF token
+	c.do(http.MethodPost, "/comments/newComments", map[string]interface{}{
+		"Content": "Great watch, keeps perfect time",
+		"Rating":  5,
+	}, map[string]string{"X-CSRF-Token": csrf.Token}, http.StatusOK)
+
+	// 4. The comment is listed
+	if contents := c.commentsBy(userName); len(contents) != 1 || contents[0] != "Great watch, keeps perfect time" {
+		t.Fatalf("GET /comments: Expected the posted comment, Got %v", contents)
+	}
+
+	// 5. Log out, which expires the cookie
+	resp = c.do(http.MethodPost, "/logout", nil, map[string]string{"X-CSRF-Token": csrf.Token}, http.StatusOK)
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "token" && cookie.MaxAge >= 0 {
+			t.Errorf("POST /logout: Expected the token cookie to be expired, Got MaxAge %d", cookie.MaxAge)
+		}
+	}
+	if !hasCookie(resp, "token") {
+		t.Error("POST /logout: Expected the token cookie to be cleared")
+	}
+}
+
+// hasCookie reports whether resp sets the cookie named name.
+func hasCookie(resp *http.Response, name string) bool {
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == name {
+			return true
+		}
+	}
+	return false
+}